@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+)
+
+/*
+AverageTrueRangeOf returns Wilder's average true range of the last _n_ bars, the typical extent of a bar
+including the gap from the previous close.
+
+# Formula
+
+TR = max(H, C<sub>-1</sub>) - min(L, C<sub>-1</sub>)
+
+ATR = ATR<sub>-1</sub> + (TR - ATR<sub>-1</sub>) / N
+
+Where:
+
+* _H_, _L_ - high and low of the bar
+* _C<sub>-1</sub>_ - close of the previous bar, the true range of the first bar is its high-low range
+* _N_ - number of periods.
+
+The first _n_ true ranges are averaged to seed the smoothing.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+atr, _ := NewAverageTrueRange(14)
+atr.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type AverageTrueRangeOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	ema   *ExponentialMovingAverageOf[T]
+	count int
+
+	// close of the last bar, and of the one before it for UpdateLast
+	lastClose     T
+	previousClose T
+}
+
+// AverageTrueRange is a AverageTrueRangeOf float64 values
+type AverageTrueRange = AverageTrueRangeOf[float64]
+
+// NewAverageTrueRange creates a new AverageTrueRange with the given number of periods
+// Example: NewAverageTrueRange(14)
+func NewAverageTrueRange(n int) (*AverageTrueRange, error) {
+	return NewAverageTrueRangeOf[float64](n)
+}
+
+// NewAverageTrueRangeOf creates a new AverageTrueRangeOf values of type T with the given number of periods
+// Example: NewAverageTrueRangeOf[float32](14)
+func NewAverageTrueRangeOf[T Float](n int) (*AverageTrueRangeOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	ema, err := NewExponentialMovingAverageOf[T](n, WithAlpha(1/float64(n)), WithSeeding(SeedMean))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AverageTrueRangeOf[T]{
+		n: n,
+
+		ema: ema,
+	}, nil
+}
+
+// Next takes the next bar and returns the next AverageTrueRange value
+func (atr *AverageTrueRangeOf[T]) Next(bar BarOf[T]) T {
+	if atr.count == 0 {
+		atr.previousClose = bar.Close
+	} else {
+		atr.previousClose = atr.lastClose
+	}
+	atr.lastClose = bar.Close
+	atr.count++
+
+	return atr.ema.Next(trueRange(bar, atr.previousClose))
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (atr *AverageTrueRangeOf[T]) Peek(bar BarOf[T]) T {
+	previousClose := atr.lastClose
+	if atr.count == 0 {
+		previousClose = bar.Close
+	}
+	return atr.ema.Peek(trueRange(bar, previousClose))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated AverageTrueRange value. If no bar has been committed yet it behaves like Next.
+func (atr *AverageTrueRangeOf[T]) UpdateLast(bar BarOf[T]) T {
+	if atr.count == 0 {
+		return atr.Next(bar)
+	}
+
+	if atr.count == 1 {
+		atr.previousClose = bar.Close
+	}
+	atr.lastClose = bar.Close
+
+	return atr.ema.UpdateLast(trueRange(bar, atr.previousClose))
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (atr *AverageTrueRangeOf[T]) Compute(bars []BarOf[T], out []T) []T {
+	return computeBars(atr.Next, bars, out)
+}
+
+// Reset resets the indicators to a clean state
+func (atr *AverageTrueRangeOf[T]) Reset() {
+	atr.ema.Reset()
+	atr.count = 0
+	atr.lastClose = 0
+	atr.previousClose = 0
+}
+
+// Clone returns a deep copy of the indicator, including its ExponentialMovingAverage
+func (atr *AverageTrueRangeOf[T]) Clone() *AverageTrueRangeOf[T] {
+	clone := *atr
+	clone.ema = atr.ema.Clone()
+	return &clone
+}
+
+func (atr *AverageTrueRangeOf[T]) String() string {
+	return fmt.Sprintf("ATR(%d)", atr.n)
+}
+
+// ComputeAverageTrueRange computes a AverageTrueRange with n periods over bars and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the smoothing is seeded, are set to NaN.
+func ComputeAverageTrueRange[T Float](n int, bars []BarOf[T], out []T, fillNaN bool) ([]T, error) {
+	atr, err := NewAverageTrueRangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = atr.Compute(bars, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// trueRange returns the range of bar extended to the close of the previous bar
+func trueRange[T Float](bar BarOf[T], previousClose T) T {
+	high, low := bar.High, bar.Low
+	if previousClose > high {
+		high = previousClose
+	}
+	if previousClose < low {
+		low = previousClose
+	}
+	return high - low
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAverageTrueRange(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		wantErr error
+	}{
+		"negative n": {input: -3, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewAverageTrueRange(tc.input)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.input, got.n, "must return the correct value")
+		})
+	}
+}
+
+func TestAverageTrueRangeNext(t *testing.T) {
+	p, _ := NewAverageTrueRange(4)
+	tests := []struct {
+		input Bar
+		want  float64
+	}{
+		{input: Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5}, want: 1.5},
+		{input: Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.}, want: 1.3},
+		{input: Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6}, want: 1.23333},
+		{input: Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9}, want: 1.2},
+		{input: Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3}, want: 1.1},
+		{input: Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4}, want: 1.175},
+		{input: Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1}, want: 1.10625},
+		{input: Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2}, want: 1.12969},
+		{input: Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7}, want: 1.09727},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := p.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestAverageTrueRangePeek(t *testing.T) {
+	p, _ := NewAverageTrueRange(4)
+	for _, bar := range []Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+		Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4},
+		Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1},
+		Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2},
+		Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7},
+	} {
+		p.Peek(Bar{Open: 100., High: 120., Low: 90., Close: 95.}) // must not be committed
+		got := p.Peek(bar)
+		assert.Equal(t, got, p.Next(bar), "must return the same value as Next")
+	}
+}
+
+func TestAverageTrueRangeUpdateLast(t *testing.T) {
+	p, _ := NewAverageTrueRange(4)
+	tests := []struct {
+		input  Bar
+		update Bar
+		want   float64
+	}{
+		{input: Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5}, update: Bar{Open: 10.2, High: 11.5, Low: 9.9, Close: 11.2}, want: 1.6},
+		{input: Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.}, update: Bar{Open: 10.4, High: 11., Low: 10., Close: 10.2}, want: 1.4},
+		{input: Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6}, update: Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6}, want: 1.36667},
+		{input: Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9}, update: Bar{Open: 10.5, High: 11.4, Low: 10.1, Close: 11.3}, want: 1.35},
+		{input: Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3}, update: Bar{Open: 9.8, High: 10.1, Low: 9.2, Close: 9.4}, want: 1.5375},
+		{input: Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4}, update: Bar{Open: 10.4, High: 10.9, Low: 10., Close: 10.8}, want: 1.52813},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			p.Next(tc.input)
+			got := p.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestAverageTrueRangeCompute(t *testing.T) {
+	bars := []Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+		Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4},
+		Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1},
+		Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2},
+		Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7},
+	}
+
+	stream, _ := NewAverageTrueRange(4)
+	want := make([]float64, len(bars))
+	for i, bar := range bars {
+		want[i] = stream.Next(bar)
+	}
+
+	batch, _ := NewAverageTrueRange(4)
+	out := make([]float64, len(bars))
+	got := batch.Compute(bars, out)
+	assert.Equal(t, want, got, "must return the same values as Next")
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+
+	_, err := ComputeAverageTrueRange(0, bars, nil, false)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+
+	got, err = ComputeAverageTrueRange(4, bars, nil, true)
+	assert.NoError(t, err)
+	want[0], want[1], want[2] = math.NaN(), math.NaN(), math.NaN()
+	diff := cmp.Diff(want, got, nanComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestAverageTrueRangeClone(t *testing.T) {
+	bars := []Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+		Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4},
+		Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1},
+		Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2},
+		Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7},
+	}
+	p, _ := NewAverageTrueRange(4)
+	p.Compute(bars, nil)
+	want, _ := NewAverageTrueRange(4)
+	want.Compute(bars, nil)
+
+	clone := p.Clone()
+	bar := Bar{Open: 12., High: 13., Low: 11.5, Close: 12.5}
+	assert.Equal(t, want.Peek(bar), clone.Next(bar), "clone must continue from the same state")
+	assert.Equal(t, want, p, "original must not see the clone's inputs")
+}
+
+func TestAverageTrueRangeReset(t *testing.T) {
+	p, _ := NewAverageTrueRange(4)
+	p.Compute([]Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+		Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4},
+		Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1},
+		Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2},
+		Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7},
+	}, nil)
+	p.Reset()
+
+	want, _ := NewAverageTrueRange(4)
+	assert.Equal(t, want, p, "must return to a clean state")
+}
+
+func TestAverageTrueRangeString(t *testing.T) {
+	p, _ := NewAverageTrueRange(4)
+	assert.Equal(t, "ATR(4)", p.String())
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+/*
+Package backtest runs trading strategies over historical bars.
+
+The backtest is event-driven and deterministic: every bar is first used to fill the orders that became active
+on it, then handed to the strategy once it closed, so a strategy can only act on what it has seen. The same
+bars, strategy and configuration always produce the same fills, trades and equity curve.
+
+The indicators of the configuration are fed every bar by the backtest, which hands their values to the strategy
+along with the bar. The strategy returns the orders to submit or, through Target, the position to hold:
+
+	ma, _ := tago.NewMovingAverage(20)
+	strategy := backtest.Target(func(bar tago.Bar, values []float64, state backtest.State) float64 {
+		if bar.Close > values[0] {
+			return 100
+		}
+		return 0
+	})
+	result, err := backtest.Run(bars, strategy, backtest.Config{Cash: 10000, Latency: 1, Indicators: []any{ma}})
+*/
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/binhnguyenduc/tago/ingest"
+)
+
+// OrderType is the type of an order
+type OrderType int
+
+const (
+	// Market orders fill at the first price available
+	Market OrderType = iota
+	// Limit orders fill at their price or better
+	Limit
+	// Stop orders become market orders once the price trades through their price
+	Stop
+)
+
+// Order is an order to buy, or sell if the quantity is negative, a number of units
+type Order struct {
+	Type     OrderType
+	Quantity float64
+	// Price is the limit or stop price, unused by market orders
+	Price float64
+}
+
+// State is the state of the backtest handed to the strategy with every bar
+type State struct {
+	// Bar is the index of the bar
+	Bar      int
+	Cash     float64
+	Position float64
+	// Pending is the quantity of the submitted orders not yet active
+	Pending float64
+	// Equity is the value of the cash and position at the close of the bar
+	Equity float64
+}
+
+// Strategy decides on the orders to submit as bars close
+type Strategy interface {
+	// Next takes the bar that just closed, the values of the indicators of the configuration on it, in their
+	// order, and the state of the backtest and returns the orders to submit. values is reused for the next bar.
+	Next(bar tago.Bar, values []float64, state State) []Order
+}
+
+// StrategyFunc adapts a function to a Strategy
+type StrategyFunc func(bar tago.Bar, values []float64, state State) []Order
+
+// Next calls f
+func (f StrategyFunc) Next(bar tago.Bar, values []float64, state State) []Order {
+	return f(bar, values, state)
+}
+
+// Target adapts a function returning the position to hold to a Strategy, submitting market orders
+// for the difference with the position and the pending orders
+func Target(target func(bar tago.Bar, values []float64, state State) float64) Strategy {
+	return StrategyFunc(func(bar tago.Bar, values []float64, state State) []Order {
+		quantity := target(bar, values, state) - state.Position - state.Pending
+		if quantity == 0 {
+			return nil
+		}
+		return []Order{{Type: Market, Quantity: quantity}}
+	})
+}
+
+// Config configures a backtest
+type Config struct {
+	// Cash is the starting cash
+	Cash float64
+	// Latency is the number of bars between the close an order is submitted on and the bar it is active on.
+	// With 0 orders are filled at the close they are submitted on, with 1 they are active on the next bar and
+	// market orders fill at its open. Limit and stop orders that do not fill on their active bar expire.
+	Latency int
+	// Slippage adjusts the prices of the fills, none if nil. Limit orders never fill beyond their limit price.
+	Slippage SlippageModel
+	// Commission charges the fills, free if nil
+	Commission CommissionModel
+	// Indicators are fed every bar before it is handed to the strategy: a tago.Indicator takes the close,
+	// a tago.BarIndicator the bar and a tago.TimedIndicator the close at the time of the bar
+	Indicators []any
+}
+
+// EquityPoint is the value of the portfolio at the close of a bar
+type EquityPoint struct {
+	Time     time.Time
+	Equity   float64
+	Cash     float64
+	Position float64
+}
+
+// Result holds the outcome of a backtest
+type Result struct {
+	// Equity is the equity curve, a point per bar
+	Equity []EquityPoint
+	Fills  []Fill
+	// Trades are the completed round trips, without the trade still open at the end
+	Trades []Trade
+}
+
+// pendingOrder is an order waiting for the bar it becomes active on
+type pendingOrder struct {
+	order  Order
+	active int
+}
+
+// Backtest runs a strategy over bars given one at a time
+type Backtest struct {
+	strategy Strategy
+	config   Config
+
+	bar       int
+	values    []float64
+	pending   []pendingOrder
+	portfolio portfolio
+	result    Result
+}
+
+// New creates a new Backtest of strategy. It returns tago.ErrInvalidParameters if an indicator of config
+// is not a tago.Indicator, tago.BarIndicator or tago.TimedIndicator.
+func New(strategy Strategy, config Config) (*Backtest, error) {
+	if strategy == nil || config.Latency < 0 {
+		return nil, tago.ErrInvalidParameters
+	}
+	for _, indicator := range config.Indicators {
+		switch indicator.(type) {
+		case tago.Indicator, tago.BarIndicator, tago.TimedIndicator:
+		default:
+			return nil, tago.ErrInvalidParameters
+		}
+	}
+
+	return &Backtest{
+		strategy:  strategy,
+		config:    config,
+		values:    make([]float64, len(config.Indicators)),
+		portfolio: portfolio{cash: config.Cash},
+	}, nil
+}
+
+// Next takes the next bar: it fills the orders active on the bar, feeds the indicators, hands the bar and
+// their values to the strategy and records the equity at its close
+func (b *Backtest) Next(bar tago.Bar) {
+	remaining := b.pending[:0]
+	for _, p := range b.pending {
+		switch {
+		case p.active == b.bar:
+			b.execute(p.order, bar, bar.Open)
+		case p.active > b.bar:
+			remaining = append(remaining, p)
+		}
+	}
+	b.pending = remaining
+
+	for i, indicator := range b.config.Indicators {
+		b.values[i] = next(indicator, bar)
+	}
+	state := b.state(bar)
+	for _, order := range b.strategy.Next(bar, b.values, state) {
+		if order.Quantity == 0 {
+			continue
+		}
+		if b.config.Latency == 0 {
+			b.execute(order, tago.Bar{Time: bar.Time, Open: bar.Close, High: bar.Close, Low: bar.Close, Close: bar.Close}, bar.Close)
+			continue
+		}
+		b.pending = append(b.pending, pendingOrder{order: order, active: b.bar + b.config.Latency})
+	}
+
+	b.result.Equity = append(b.result.Equity, EquityPoint{
+		Time:     bar.Time,
+		Equity:   b.portfolio.equity(bar.Close),
+		Cash:     b.portfolio.cash,
+		Position: b.portfolio.position,
+	})
+	b.bar++
+}
+
+// next feeds bar to indicator and returns its next value
+func next(indicator any, bar tago.Bar) float64 {
+	switch indicator := indicator.(type) {
+	case tago.Indicator:
+		return indicator.Next(bar.Close)
+	case tago.BarIndicator:
+		return indicator.Next(bar)
+	case tago.TimedIndicator:
+		return indicator.Next(tago.TimedValue{Time: bar.Time, Value: bar.Close})
+	}
+	panic(fmt.Sprintf("backtest: unsupported indicator %T", indicator))
+}
+
+// execute fills order on bar if its price is reached, market orders at open
+func (b *Backtest) execute(order Order, bar tago.Bar, open float64) {
+	price, ok := fillPrice(order, bar, open)
+	if !ok {
+		return
+	}
+
+	if b.config.Slippage != nil {
+		price = b.config.Slippage(order.Quantity, price)
+		if order.Type == Limit && order.Quantity > 0 {
+			price = math.Min(price, order.Price)
+		}
+		if order.Type == Limit && order.Quantity < 0 {
+			price = math.Max(price, order.Price)
+		}
+	}
+	var commission float64
+	if b.config.Commission != nil {
+		commission = b.config.Commission(order.Quantity, price)
+	}
+
+	fill := Fill{
+		Bar:        b.bar,
+		Time:       bar.Time,
+		Order:      order,
+		Quantity:   order.Quantity,
+		Price:      price,
+		Commission: commission,
+	}
+	b.portfolio.fill(fill)
+	b.result.Fills = append(b.result.Fills, fill)
+}
+
+// fillPrice returns the market price order fills at on bar, opening at open, if it fills
+func fillPrice(order Order, bar tago.Bar, open float64) (float64, bool) {
+	buy := order.Quantity > 0
+	switch order.Type {
+	case Limit:
+		if buy && bar.Low <= order.Price {
+			return math.Min(open, order.Price), true
+		}
+		if !buy && bar.High >= order.Price {
+			return math.Max(open, order.Price), true
+		}
+		return 0, false
+	case Stop:
+		if buy && bar.High >= order.Price {
+			return math.Max(open, order.Price), true
+		}
+		if !buy && bar.Low <= order.Price {
+			return math.Min(open, order.Price), true
+		}
+		return 0, false
+	}
+	return open, true
+}
+
+// state returns the state of the backtest at the close of bar
+func (b *Backtest) state(bar tago.Bar) State {
+	var pending float64
+	for _, p := range b.pending {
+		pending += p.order.Quantity
+	}
+
+	return State{
+		Bar:      b.bar,
+		Cash:     b.portfolio.cash,
+		Position: b.portfolio.position,
+		Pending:  pending,
+		Equity:   b.portfolio.equity(bar.Close),
+	}
+}
+
+// Result returns the outcome of the bars so far
+func (b *Backtest) Result() *Result {
+	b.result.Trades = b.portfolio.trades
+	return &b.result
+}
+
+// Run runs strategy over bars
+func Run(bars []tago.Bar, strategy Strategy, config Config) (*Result, error) {
+	b, err := New(strategy, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bar := range bars {
+		b.Next(bar)
+	}
+	return b.Result(), nil
+}
+
+// RunReader runs strategy over the bars read from r, stopping at the first error such as a malformed row
+func RunReader(r ingest.Reader, strategy Strategy, config Config) (*Result, error) {
+	b, err := New(strategy, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		bar, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return b.Result(), nil
+		}
+		if err != nil {
+			return b.Result(), err
+		}
+		b.Next(bar)
+	}
+}
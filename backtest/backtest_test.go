@@ -0,0 +1,309 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package backtest
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/binhnguyenduc/tago/ingest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+var approxComparer = cmpopts.EquateApprox(0.001, 1e-9)
+
+func at(day int) time.Time {
+	return time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+// closes returns a bar per close, opening at the previous close and ranging 1 around the open and close
+func closes(prices ...float64) []tago.Bar {
+	bars := make([]tago.Bar, len(prices))
+	open := prices[0]
+	for i, price := range prices {
+		bars[i] = tago.Bar{Time: at(i + 1), Open: open, High: math.Max(open, price) + 1, Low: math.Min(open, price) - 1, Close: price}
+		open = price
+	}
+	return bars
+}
+
+// script returns a strategy submitting the orders of every bar index
+func script(orders map[int][]Order) Strategy {
+	return StrategyFunc(func(bar tago.Bar, values []float64, state State) []Order {
+		return orders[state.Bar]
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		strategy Strategy
+		config   Config
+		wantErr  error
+	}{
+		"nil strategy":      {strategy: nil, wantErr: tago.ErrInvalidParameters},
+		"negative latency":  {strategy: script(nil), config: Config{Latency: -1}, wantErr: tago.ErrInvalidParameters},
+		"invalid indicator": {strategy: script(nil), config: Config{Indicators: []any{"MA(3)"}}, wantErr: tago.ErrInvalidParameters},
+		"valid":             {strategy: script(nil), config: Config{Cash: 1000, Latency: 1}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := New(tc.strategy, tc.config)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.config.Cash, got.portfolio.cash, "must start with the cash")
+		})
+	}
+}
+
+func TestBacktestLatency(t *testing.T) {
+	bars := closes(10., 11., 12., 13.)
+	tests := map[string]struct {
+		latency   int
+		wantBar   int
+		wantPrice float64
+	}{
+		"same close": {latency: 0, wantBar: 0, wantPrice: 10.},
+		"next open":  {latency: 1, wantBar: 1, wantPrice: 10.},
+		"two bars":   {latency: 2, wantBar: 2, wantPrice: 11.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Run(bars, script(map[int][]Order{0: {{Quantity: 10.}}}), Config{Cash: 1000., Latency: tc.latency})
+			assert.NoError(t, err)
+			assert.Len(t, got.Fills, 1)
+			assert.Equal(t, tc.wantBar, got.Fills[0].Bar)
+			assert.Equal(t, tc.wantPrice, got.Fills[0].Price)
+			assert.Equal(t, 1000.-10.*tc.wantPrice+10.*13., got.Equity[3].Equity)
+		})
+	}
+}
+
+func TestFillPrice(t *testing.T) {
+	bar := tago.Bar{Open: 10., High: 12., Low: 9., Close: 11.}
+	tests := map[string]struct {
+		order     Order
+		wantPrice float64
+		wantOK    bool
+	}{
+		"market buy":        {order: Order{Type: Market, Quantity: 1.}, wantPrice: 10., wantOK: true},
+		"market sell":       {order: Order{Type: Market, Quantity: -1.}, wantPrice: 10., wantOK: true},
+		"limit buy":         {order: Order{Type: Limit, Quantity: 1., Price: 9.5}, wantPrice: 9.5, wantOK: true},
+		"limit buy gap":     {order: Order{Type: Limit, Quantity: 1., Price: 10.5}, wantPrice: 10., wantOK: true},
+		"limit buy missed":  {order: Order{Type: Limit, Quantity: 1., Price: 8.5}, wantOK: false},
+		"limit sell":        {order: Order{Type: Limit, Quantity: -1., Price: 11.5}, wantPrice: 11.5, wantOK: true},
+		"limit sell gap":    {order: Order{Type: Limit, Quantity: -1., Price: 9.5}, wantPrice: 10., wantOK: true},
+		"limit sell missed": {order: Order{Type: Limit, Quantity: -1., Price: 12.5}, wantOK: false},
+		"stop buy":          {order: Order{Type: Stop, Quantity: 1., Price: 11.5}, wantPrice: 11.5, wantOK: true},
+		"stop buy gap":      {order: Order{Type: Stop, Quantity: 1., Price: 9.5}, wantPrice: 10., wantOK: true},
+		"stop buy missed":   {order: Order{Type: Stop, Quantity: 1., Price: 12.5}, wantOK: false},
+		"stop sell":         {order: Order{Type: Stop, Quantity: -1., Price: 9.5}, wantPrice: 9.5, wantOK: true},
+		"stop sell gap":     {order: Order{Type: Stop, Quantity: -1., Price: 10.5}, wantPrice: 10., wantOK: true},
+		"stop sell missed":  {order: Order{Type: Stop, Quantity: -1., Price: 8.5}, wantOK: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := fillPrice(tc.order, bar, bar.Open)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantPrice, got)
+		})
+	}
+}
+
+func TestBacktestExpiry(t *testing.T) {
+	bars := closes(10., 11., 12., 8.)
+	orders := map[int][]Order{0: {{Type: Limit, Quantity: 1., Price: 8.5}}}
+
+	got, err := Run(bars, script(orders), Config{Latency: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, got.Fills, "limit orders must expire after their active bar")
+}
+
+func TestBacktestCosts(t *testing.T) {
+	bars := closes(10., 11.)
+	orders := map[int][]Order{0: {{Quantity: 100.}}, 1: {{Quantity: -100.}}}
+	config := Config{
+		Cash:       10000.,
+		Slippage:   FixedSlippage(0.05),
+		Commission: PerUnitCommission(0.01, 1.5),
+	}
+
+	got, err := Run(bars, script(orders), config)
+	assert.NoError(t, err)
+	want := []Fill{
+		{Bar: 0, Time: at(1), Order: orders[0][0], Quantity: 100., Price: 10.05, Commission: 1.5},
+		{Bar: 1, Time: at(2), Order: orders[1][0], Quantity: -100., Price: 10.95, Commission: 1.5},
+	}
+	if diff := cmp.Diff(want, got.Fills, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.InDelta(t, 10000.+90.-3., got.Equity[1].Equity, 1e-9)
+	assert.InDelta(t, 87., got.Trades[0].PnL, 1e-9)
+}
+
+func TestBacktestLimitSlippage(t *testing.T) {
+	bars := closes(10., 11.)
+	tests := map[string]struct {
+		order     Order
+		wantPrice float64
+	}{
+		"buy at limit":  {order: Order{Type: Limit, Quantity: 1., Price: 9.5}, wantPrice: 9.5},
+		"buy gap":       {order: Order{Type: Limit, Quantity: 1., Price: 10.5}, wantPrice: 10.05},
+		"sell at limit": {order: Order{Type: Limit, Quantity: -1., Price: 11.5}, wantPrice: 11.5},
+		"sell gap":      {order: Order{Type: Limit, Quantity: -1., Price: 9.5}, wantPrice: 9.95},
+		"stop buy":      {order: Order{Type: Stop, Quantity: 1., Price: 11.5}, wantPrice: 11.55},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Run(bars, script(map[int][]Order{0: {tc.order}}), Config{Latency: 1, Slippage: FixedSlippage(0.05)})
+			assert.NoError(t, err)
+			assert.Len(t, got.Fills, 1)
+			assert.InDelta(t, tc.wantPrice, got.Fills[0].Price, 1e-9)
+		})
+	}
+}
+
+func TestBacktestFractionalRoundTrip(t *testing.T) {
+	bars := closes(10., 11., 12.)
+	orders := map[int][]Order{0: {{Quantity: 0.3}}, 1: {{Quantity: -0.1}, {Quantity: -0.2}}, 2: {{Quantity: -(0.1 + 0.2)}}}
+
+	got, err := Run(bars, script(orders), Config{Cash: 1000.})
+	assert.NoError(t, err)
+	assert.Len(t, got.Trades, 1, "must not open a trade from the rounding error of the closing fills")
+	assert.Equal(t, 0., got.Equity[1].Position, "must be flat once the position is closed")
+	assert.InDelta(t, 0.3, got.Trades[0].Quantity, 1e-12)
+	assert.InDelta(t, 0.3, got.Trades[0].PnL, 1e-12)
+	assert.InDelta(t, -0.3, got.Equity[2].Position, 1e-12)
+
+	got, err = Run(bars[:2], script(map[int][]Order{0: {{Quantity: 0.3}}, 1: {{Quantity: -(0.1 + 0.2)}}}), Config{Cash: 1000.})
+	assert.NoError(t, err)
+	assert.Len(t, got.Trades, 1)
+	assert.Equal(t, 0., got.Equity[1].Position, "must not hold a dust position")
+}
+
+func TestBacktestTrades(t *testing.T) {
+	bars := closes(10., 12., 13., 9., 8., 8.5)
+	orders := map[int][]Order{
+		0: {{Quantity: 10.}},
+		1: {{Quantity: 10.}},
+		2: {{Quantity: -5.}},
+		3: {{Quantity: -25.}},
+		4: {{Quantity: 10.}},
+		5: {{Quantity: -1.}},
+	}
+
+	got, err := Run(bars, script(orders), Config{Cash: 1000., Commission: FixedCommission(2.)})
+	assert.NoError(t, err)
+	want := []Trade{
+		{EntryTime: at(1), ExitTime: at(4), Quantity: 20., EntryPrice: 11., ExitPrice: 10., Commission: 7.2, PnL: -27.2},
+		{EntryTime: at(4), ExitTime: at(5), Quantity: -10., EntryPrice: 9., ExitPrice: 8., Commission: 2.8, PnL: 7.2},
+	}
+	if diff := cmp.Diff(want, got.Trades, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	wantEquity := []EquityPoint{
+		{Time: at(1), Equity: 998., Cash: 898., Position: 10.},
+		{Time: at(2), Equity: 1016., Cash: 776., Position: 20.},
+		{Time: at(3), Equity: 1034., Cash: 839., Position: 15.},
+		{Time: at(4), Equity: 972., Cash: 1062., Position: -10.},
+		{Time: at(5), Equity: 980., Cash: 980., Position: 0.},
+		{Time: at(6), Equity: 978., Cash: 986.5, Position: -1.},
+	}
+	if diff := cmp.Diff(wantEquity, got.Equity, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestTarget(t *testing.T) {
+	bars := closes(10., 11., 12., 11., 10., 9., 10., 11.)
+	strategy := Target(func(bar tago.Bar, values []float64, state State) float64 {
+		if bar.Close > values[0] {
+			return 10.
+		}
+		return 0.
+	})
+	newConfig := func() Config {
+		ma, _ := tago.NewMovingAverage(3)
+		return Config{Cash: 1000., Latency: 1, Indicators: []any{ma}}
+	}
+
+	got, err := Run(bars, strategy, newConfig())
+	assert.NoError(t, err)
+	wantQuantities := []float64{10., -10., 10.}
+	wantBars := []int{2, 4, 7}
+	assert.Len(t, got.Fills, len(wantQuantities))
+	for i, fill := range got.Fills {
+		assert.Equal(t, wantQuantities[i], fill.Quantity)
+		assert.Equal(t, wantBars[i], fill.Bar)
+	}
+
+	again, _ := Run(bars, strategy, newConfig())
+	assert.Equal(t, got, again, "must be deterministic")
+}
+
+func TestTargetPending(t *testing.T) {
+	var states []State
+	strategy := Target(func(bar tago.Bar, values []float64, state State) float64 {
+		states = append(states, state)
+		return 5.
+	})
+
+	got, err := Run(closes(10., 11., 12., 13.), strategy, Config{Latency: 2})
+	assert.NoError(t, err)
+	assert.Len(t, got.Fills, 1, "must not order again while the first order is pending")
+	assert.Equal(t, []float64{0., 5., 0., 0.}, []float64{states[0].Pending, states[1].Pending, states[2].Pending, states[3].Pending})
+	assert.Equal(t, []float64{0., 0., 5., 5.}, []float64{states[0].Position, states[1].Position, states[2].Position, states[3].Position})
+}
+
+func TestBacktestIndicators(t *testing.T) {
+	bars := closes(10., 14., 11., 12., 11.)
+	ma, _ := tago.NewMovingAverage(2)
+	atr, _ := tago.NewAverageTrueRange(2)
+	timed, _ := tago.NewTimedMaximum(48 * time.Hour)
+
+	var got [][]float64
+	strategy := StrategyFunc(func(bar tago.Bar, values []float64, state State) []Order {
+		got = append(got, append([]float64(nil), values...))
+		return nil
+	})
+	_, err := Run(bars, strategy, Config{Indicators: []any{ma, atr, timed}})
+	assert.NoError(t, err)
+
+	wantMA, _ := tago.NewMovingAverage(2)
+	wantATR, _ := tago.NewAverageTrueRange(2)
+	wantTimed, _ := tago.NewTimedMaximum(48 * time.Hour)
+	want := make([][]float64, len(bars))
+	for i, bar := range bars {
+		want[i] = []float64{wantMA.Next(bar.Close), wantATR.Next(bar), wantTimed.Next(tago.TimedValue{Time: bar.Time, Value: bar.Close})}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Equal(t, []float64{12., 12.}, []float64{got[3][2], got[4][2]}, "must feed the timed indicators the time of the bars")
+}
+
+func TestRunReader(t *testing.T) {
+	input := "date,open,high,low,close\n2020-01-01,10,11,9,10\n2020-01-02,10,12,10,11\n2020-01-03,oops,12,10,11\n"
+	r := ingest.NewCSVReader(strings.NewReader(input), ingest.CSVConfig{})
+
+	got, err := RunReader(r, script(map[int][]Order{0: {{Quantity: 1.}}}), Config{Latency: 1})
+	assert.EqualError(t, err, `line 4: column open: invalid number "oops"`)
+	assert.Len(t, got.Equity, 2, "must keep the result up to the malformed row")
+	assert.Equal(t, 10., got.Fills[0].Price)
+}
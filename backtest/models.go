@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package backtest
+
+import "math"
+
+// SlippageModel returns the price an order of quantity units is filled at when the market trades at price.
+// Buys, with a positive quantity, should fill at or above price and sells at or below it.
+type SlippageModel func(quantity, price float64) float64
+
+// CommissionModel returns the commission of a fill of quantity units at price, which is a positive quantity
+// for buys and a negative one for sells
+type CommissionModel func(quantity, price float64) float64
+
+// FixedSlippage fills orders amount worse than the market price, such as half the spread
+func FixedSlippage(amount float64) SlippageModel {
+	return func(quantity, price float64) float64 {
+		if quantity > 0 {
+			return price + amount
+		}
+		return price - amount
+	}
+}
+
+// PercentSlippage fills orders a fraction of the market price worse than it, 0.0005 for 5 basis points
+func PercentSlippage(fraction float64) SlippageModel {
+	return func(quantity, price float64) float64 {
+		if quantity > 0 {
+			return price * (1 + fraction)
+		}
+		return price * (1 - fraction)
+	}
+}
+
+// FixedCommission charges amount per fill
+func FixedCommission(amount float64) CommissionModel {
+	return func(quantity, price float64) float64 {
+		return amount
+	}
+}
+
+// PerUnitCommission charges perUnit for every unit filled, and at least minimum per fill
+func PerUnitCommission(perUnit, minimum float64) CommissionModel {
+	return func(quantity, price float64) float64 {
+		return math.Max(perUnit*math.Abs(quantity), minimum)
+	}
+}
+
+// PercentCommission charges a fraction of the value of the fill, 0.001 for 10 basis points
+func PercentCommission(fraction float64) CommissionModel {
+	return func(quantity, price float64) float64 {
+		return fraction * math.Abs(quantity*price)
+	}
+}
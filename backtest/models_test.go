@@ -0,0 +1,50 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package backtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlippageModels(t *testing.T) {
+	tests := map[string]struct {
+		model    SlippageModel
+		quantity float64
+		want     float64
+	}{
+		"fixed buy":    {model: FixedSlippage(0.05), quantity: 10., want: 100.05},
+		"fixed sell":   {model: FixedSlippage(0.05), quantity: -10., want: 99.95},
+		"percent buy":  {model: PercentSlippage(0.001), quantity: 10., want: 100.1},
+		"percent sell": {model: PercentSlippage(0.001), quantity: -10., want: 99.9},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.model(tc.quantity, 100.), 1e-9)
+		})
+	}
+}
+
+func TestCommissionModels(t *testing.T) {
+	tests := map[string]struct {
+		model    CommissionModel
+		quantity float64
+		want     float64
+	}{
+		"fixed":            {model: FixedCommission(1.), quantity: 10., want: 1.},
+		"per unit":         {model: PerUnitCommission(0.01, 1.), quantity: -200., want: 2.},
+		"per unit minimum": {model: PerUnitCommission(0.01, 1.), quantity: 50., want: 1.},
+		"percent":          {model: PercentCommission(0.001), quantity: -10., want: 1.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.model(tc.quantity, 100.), 1e-9)
+		})
+	}
+}
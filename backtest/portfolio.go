@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// Fill is the execution of an order
+type Fill struct {
+	// Bar is the index of the bar the order was filled on
+	Bar  int
+	Time time.Time
+
+	Order Order
+	// Quantity is the number of units bought, or sold if negative
+	Quantity   float64
+	Price      float64
+	Commission float64
+}
+
+// Trade is a round trip from a flat position back to a flat position, or to a position on the other side
+type Trade struct {
+	EntryTime time.Time
+	ExitTime  time.Time
+
+	// Quantity is the largest position held, negative for a short trade
+	Quantity float64
+	// EntryPrice and ExitPrice are the average prices of the fills opening and closing the position
+	EntryPrice float64
+	ExitPrice  float64
+
+	Commission float64
+	// PnL is the profit or loss of the trade, net of commission
+	PnL float64
+}
+
+// flat is the size below which a position, or what is left of a fill once it closed one, is taken to be 0
+const flat = 1e-12
+
+// portfolio keeps the cash and position of a backtest, and the trade in progress
+type portfolio struct {
+	cash     float64
+	position float64
+	// average price of the position
+	price float64
+
+	trade      Trade
+	exitValue  float64
+	exitAmount float64
+	trades     []Trade
+}
+
+// fill applies a fill to the cash and position
+func (p *portfolio) fill(f Fill) {
+	p.cash -= f.Quantity*f.Price + f.Commission
+
+	quantity, commission := f.Quantity, f.Commission
+	if p.position != 0 && math.Signbit(quantity) != math.Signbit(p.position) {
+		closed := math.Min(math.Abs(quantity), math.Abs(p.position))
+		share := commission * closed / math.Abs(quantity)
+		p.reduce(f.Time, math.Copysign(closed, quantity), f.Price, share)
+
+		quantity -= math.Copysign(closed, quantity)
+		commission -= share
+	}
+	if math.Abs(quantity) >= flat {
+		p.add(f.Time, quantity, f.Price, commission)
+	}
+}
+
+// add opens or adds to the position
+func (p *portfolio) add(t time.Time, quantity, price, commission float64) {
+	if p.position == 0 {
+		p.trade = Trade{EntryTime: t}
+		p.exitValue, p.exitAmount = 0, 0
+	}
+
+	size := math.Abs(p.position) + math.Abs(quantity)
+	p.price = (p.price*math.Abs(p.position) + price*math.Abs(quantity)) / size
+	p.position += quantity
+
+	p.trade.EntryPrice = p.price
+	if math.Abs(p.position) > math.Abs(p.trade.Quantity) {
+		p.trade.Quantity = p.position
+	}
+	p.trade.Commission += commission
+	p.trade.PnL -= commission
+}
+
+// reduce reduces the position by quantity, of the opposite sign and at most its size, closing the trade
+// once the position is flat
+func (p *portfolio) reduce(t time.Time, quantity, price, commission float64) {
+	p.trade.PnL += -quantity*(price-p.price) - commission
+	p.trade.Commission += commission
+	p.exitValue += math.Abs(quantity) * price
+	p.exitAmount += math.Abs(quantity)
+
+	p.position += quantity
+	if math.Abs(p.position) < flat {
+		p.position, p.price = 0, 0
+		p.trade.ExitTime = t
+		p.trade.ExitPrice = p.exitValue / p.exitAmount
+		p.trades = append(p.trades, p.trade)
+	}
+}
+
+// equity returns the value of the portfolio when the market trades at price
+func (p *portfolio) equity(price float64) float64 {
+	return p.cash + p.position*price
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"time"
+)
+
+// BarOf is a price bar of values of type T: the open, high, low and close prices
+// and the volume traded over the period starting at Time
+type BarOf[T Float] struct {
+	Time time.Time
+
+	Open   T
+	High   T
+	Low    T
+	Close  T
+	Volume T
+}
+
+// Bar is a BarOf float64 values
+type Bar = BarOf[float64]
+
+// computeBars feeds every bar of bars through next and writes the results to out,
+// see the Compute methods of the bar indicators
+func computeBars[T Float](next func(bar BarOf[T]) T, bars []BarOf[T], out []T) []T {
+	out = resize(out, len(bars))
+	for i, bar := range bars {
+		out[i] = next(bar)
+	}
+	return out
+}
+
+// TransformBars feeds every bar of bars through transformer and appends the bars it emits to out[:0],
+// reusing out when it has enough capacity
+func TransformBars[T Float](transformer BarTransformerOf[T], bars []BarOf[T], out []BarOf[T]) []BarOf[T] {
+	out = out[:0]
+	for _, bar := range bars {
+		out = append(out, transformer.Next(bar)...)
+	}
+	return out
+}
+
+// maxBricks is the most bricks or range bars a transformer completes for a single bar, so that an outlier
+// cannot build an unbounded number of them
+const maxBricks = 1000
+
+// finiteBar reports whether every price of bar is finite
+func finiteBar[T Float](bar BarOf[T]) bool {
+	return isFinite(bar.Open) && isFinite(bar.High) && isFinite(bar.Low) && isFinite(bar.Close)
+}
+
+// logRatio returns the natural logarithm of a / b
+func logRatio[T Float](a, b T) T {
+	return T(math.Log(float64(a / b)))
+}
+
+// volatility returns the square root of variance, or 0 if rounding errors made it negative
+func volatility[T Float](variance T) T {
+	if variance <= 0 {
+		return 0
+	}
+	return T(math.Sqrt(float64(variance)))
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// AggregatorOption configures a BarAggregator
+type AggregatorOption func(*aggregatorConfig)
+
+type aggregatorConfig struct {
+	location   *time.Location
+	start, end time.Duration
+	hasSession bool
+}
+
+// WithLocation aligns the aggregated bars to the wall clock of location instead of UTC
+func WithLocation(location *time.Location) AggregatorOption {
+	return func(config *aggregatorConfig) {
+		config.location = location
+	}
+}
+
+// WithSession restricts the aggregated bars to a daily trading session from start to end, given as offsets
+// from local midnight. Bars are aligned to the session start and bars outside of the session are dropped.
+// A session that ends before it starts runs past midnight into the next day, such as for futures.
+// Example: WithSession(9*time.Hour+30*time.Minute, 16*time.Hour)
+func WithSession(start, end time.Duration) AggregatorOption {
+	return func(config *aggregatorConfig) {
+		config.start, config.end = start, end
+		config.hasSession = true
+	}
+}
+
+/*
+BarAggregatorOf rolls bars up into bars of a longer timeframe, such as 1-minute bars into 5-minute, hourly or
+daily bars. A bar belongs to the aggregated bar whose interval contains its Time, and the aggregated bar
+takes the open of its first bar, the close of its last bar, the extremes of their highs and lows and the sum
+of their volumes.
+
+Aggregated bars are aligned to local midnight in the configured location, or to the session start when a
+session is configured, so that daily bars follow the trading day in the exchange timezone including across
+daylight saving changes. A timeframe of a day or longer spans a whole day or session.
+
+Several aggregators fed from the same stream run indicators on several timeframes at once.
+
+# Parameters
+
+* _timeframe_ - duration of the aggregated bars (greater than 0)
+
+# Example
+```
+hourly, _ := NewBarAggregator(time.Hour, WithLocation(newYork), WithSession(9*time.Hour+30*time.Minute, 16*time.Hour))
+bar, ok := hourly.Next(Bar{Time: time.Now(), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 100.})
+```
+*/
+type BarAggregatorOf[T Float] struct {
+	timeframe time.Duration
+	config    aggregatorConfig
+
+	current    BarOf[T]
+	hasCurrent bool
+}
+
+// BarAggregator is a BarAggregatorOf float64 values
+type BarAggregator = BarAggregatorOf[float64]
+
+// NewBarAggregator creates a new BarAggregator of bars of the given timeframe
+// Example: NewBarAggregator(5 * time.Minute)
+func NewBarAggregator(timeframe time.Duration, options ...AggregatorOption) (*BarAggregator, error) {
+	return NewBarAggregatorOf[float64](timeframe, options...)
+}
+
+// NewBarAggregatorOf creates a new BarAggregatorOf values of type T of bars of the given timeframe
+// Example: NewBarAggregatorOf[float32](5 * time.Minute)
+func NewBarAggregatorOf[T Float](timeframe time.Duration, options ...AggregatorOption) (*BarAggregatorOf[T], error) {
+	config := aggregatorConfig{location: time.UTC}
+	for _, option := range options {
+		option(&config)
+	}
+
+	if timeframe <= 0 || config.location == nil {
+		return nil, ErrInvalidParameters
+	}
+	if config.hasSession && (config.start < 0 || config.start >= day ||
+		config.end < 0 || config.end >= day || config.start == config.end) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BarAggregatorOf[T]{
+		timeframe: timeframe,
+		config:    config,
+	}, nil
+}
+
+// Next takes the next bar and returns the aggregated bar it completes, if any
+func (a *BarAggregatorOf[T]) Next(bar BarOf[T]) (BarOf[T], bool) {
+	start, ok := a.bucket(bar.Time)
+	if !ok {
+		return BarOf[T]{}, false
+	}
+
+	if !a.hasCurrent || !start.After(a.current.Time) {
+		a.add(bar, start)
+		return BarOf[T]{}, false
+	}
+
+	aggregated := a.current
+	a.hasCurrent = false
+	a.add(bar, start)
+	return aggregated, true
+}
+
+// add adds bar to the aggregated bar in progress, starting one at start if there is none
+func (a *BarAggregatorOf[T]) add(bar BarOf[T], start time.Time) {
+	if !a.hasCurrent {
+		a.current = BarOf[T]{
+			Time: start,
+			Open: bar.Open,
+			High: bar.High,
+			Low:  bar.Low,
+		}
+		a.hasCurrent = true
+	}
+
+	if bar.High > a.current.High {
+		a.current.High = bar.High
+	}
+	if bar.Low < a.current.Low {
+		a.current.Low = bar.Low
+	}
+	a.current.Close = bar.Close
+	a.current.Volume += bar.Volume
+}
+
+// bucket returns the start of the aggregated bar containing t, or false when t is outside of the session
+func (a *BarAggregatorOf[T]) bucket(t time.Time) (time.Time, bool) {
+	local := t.In(a.config.location)
+	anchor := a.wallClock(local, 0, 0)
+
+	if a.config.hasSession {
+		anchor = a.wallClock(local, 0, a.config.start)
+		if t.Before(anchor) {
+			// before today's session start, which can only be inside of yesterday's overnight session
+			anchor = a.wallClock(local, -1, a.config.start)
+		}
+		if !t.Before(a.sessionEnd(anchor)) {
+			return time.Time{}, false
+		}
+	}
+
+	if a.timeframe >= day {
+		return anchor, true
+	}
+	return anchor.Add(t.Sub(anchor) / a.timeframe * a.timeframe), true
+}
+
+// wallClock returns the time at offset from midnight of the local day of t shifted by days
+func (a *BarAggregatorOf[T]) wallClock(t time.Time, days int, offset time.Duration) time.Time {
+	year, month, date := t.Date()
+	// time.Date normalises the nanoseconds on the wall clock so the offset survives daylight saving changes
+	return time.Date(year, month, date+days, 0, 0, 0, int(offset), a.config.location)
+}
+
+// sessionEnd returns the end of the session starting at start
+func (a *BarAggregatorOf[T]) sessionEnd(start time.Time) time.Time {
+	days := 0
+	if a.config.end < a.config.start {
+		days = 1
+	}
+	return a.wallClock(start, days, a.config.end)
+}
+
+// Current returns the aggregated bar in progress, if there is one
+func (a *BarAggregatorOf[T]) Current() (BarOf[T], bool) {
+	return a.current, a.hasCurrent
+}
+
+// Flush completes and returns the aggregated bar in progress, if there is one, such as at the end of a stream
+func (a *BarAggregatorOf[T]) Flush() (BarOf[T], bool) {
+	bar, ok := a.current, a.hasCurrent
+	a.Reset()
+	return bar, ok
+}
+
+// Reset resets the aggregator to a clean state, dropping the bar in progress
+func (a *BarAggregatorOf[T]) Reset() {
+	a.current = BarOf[T]{}
+	a.hasCurrent = false
+}
+
+// Clone returns a copy of the aggregator that can be advanced independently
+func (a *BarAggregatorOf[T]) Clone() *BarAggregatorOf[T] {
+	clone := *a
+	return &clone
+}
+
+func (a *BarAggregatorOf[T]) String() string {
+	return fmt.Sprintf("Bars(%s)", a.timeframe)
+}
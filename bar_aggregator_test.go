@@ -0,0 +1,197 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func aggregateBars(a *BarAggregator, bars []Bar) []Bar {
+	var aggregated []Bar
+	for _, bar := range bars {
+		if bar, ok := a.Next(bar); ok {
+			aggregated = append(aggregated, bar)
+		}
+	}
+	return aggregated
+}
+
+// minuteBar returns a 1-minute bar starting at t closing at close
+func minuteBar(t time.Time, close float64) Bar {
+	return Bar{Time: t, Open: close - 0.5, High: close + 1., Low: close - 1., Close: close, Volume: 10.}
+}
+
+func TestNewBarAggregator(t *testing.T) {
+	tests := map[string]struct {
+		timeframe time.Duration
+		options   []AggregatorOption
+		wantErr   error
+	}{
+		"zero timeframe":       {timeframe: 0, wantErr: ErrInvalidParameters},
+		"nil location":         {timeframe: time.Hour, options: []AggregatorOption{WithLocation(nil)}, wantErr: ErrInvalidParameters},
+		"negative start":       {timeframe: time.Hour, options: []AggregatorOption{WithSession(-time.Hour, 16*time.Hour)}, wantErr: ErrInvalidParameters},
+		"end after midnight":   {timeframe: time.Hour, options: []AggregatorOption{WithSession(9*time.Hour, 24*time.Hour)}, wantErr: ErrInvalidParameters},
+		"empty session":        {timeframe: time.Hour, options: []AggregatorOption{WithSession(9*time.Hour, 9*time.Hour)}, wantErr: ErrInvalidParameters},
+		"positive timeframe":   {timeframe: 5 * time.Minute, wantErr: nil},
+		"overnight session":    {timeframe: day, options: []AggregatorOption{WithSession(18*time.Hour, 17*time.Hour)}, wantErr: nil},
+		"location and session": {timeframe: time.Hour, options: []AggregatorOption{WithLocation(time.Local), WithSession(9*time.Hour, 16*time.Hour)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewBarAggregator(tc.timeframe, tc.options...)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.timeframe, got.timeframe, "must return the correct value")
+		})
+	}
+}
+
+func TestBarAggregatorNext(t *testing.T) {
+	a, _ := NewBarAggregator(5 * time.Minute)
+	var bars []Bar
+	for i := 0; i < 12; i++ {
+		bars = append(bars, minuteBar(at(float64(i*60)), 10.+float64(i%4)))
+	}
+
+	want := []Bar{
+		{Time: at(0), Open: 9.5, High: 14., Low: 9., Close: 10., Volume: 50.},
+		{Time: at(300), Open: 10.5, High: 14., Low: 9., Close: 11., Volume: 50.},
+	}
+	got := aggregateBars(a, bars)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	wantCurrent := Bar{Time: at(600), Open: 11.5, High: 14., Low: 11., Close: 13., Volume: 20.}
+	current, ok := a.Current()
+	assert.True(t, ok)
+	if diff := cmp.Diff(wantCurrent, current, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarAggregatorSession(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	local := func(hour, min int) time.Time {
+		return time.Date(2020, 1, 2, hour, min, 0, 0, newYork)
+	}
+
+	a, _ := NewBarAggregator(time.Hour, WithLocation(newYork), WithSession(9*time.Hour+30*time.Minute, 16*time.Hour))
+	bars := []Bar{
+		minuteBar(local(9, 0), 1.),
+		minuteBar(local(9, 30), 2.),
+		minuteBar(local(10, 29), 3.),
+		minuteBar(local(10, 30), 4.),
+		minuteBar(local(15, 59), 5.),
+		minuteBar(local(16, 0), 6.),
+	}
+
+	want := []Bar{
+		{Time: local(9, 30), Open: 1.5, High: 4., Low: 1., Close: 3., Volume: 20.},
+		{Time: local(10, 30), Open: 3.5, High: 5., Low: 3., Close: 4., Volume: 10.},
+	}
+	got := aggregateBars(a, bars)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	current, _ := a.Current()
+	assert.True(t, current.Time.Equal(local(15, 30)), "must drop the bar at the session end")
+}
+
+func TestBarAggregatorOvernightSession(t *testing.T) {
+	a, _ := NewBarAggregator(day, WithSession(18*time.Hour, 17*time.Hour))
+	utc := func(date, hour int) time.Time {
+		return time.Date(2020, 1, date, hour, 0, 0, 0, time.UTC)
+	}
+	bars := []Bar{
+		minuteBar(utc(1, 18), 1.),
+		minuteBar(utc(2, 2), 2.),
+		minuteBar(utc(2, 16), 3.),
+		minuteBar(utc(2, 17), 4.),
+		minuteBar(utc(2, 18), 5.),
+	}
+
+	want := []Bar{{Time: utc(1, 18), Open: 0.5, High: 4., Low: 0., Close: 3., Volume: 30.}}
+	got := aggregateBars(a, bars)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarAggregatorDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// the clocks move forward on 2020-03-08, the session opens at 14:30 UTC before and 13:30 UTC after
+	a, _ := NewBarAggregator(day, WithLocation(newYork), WithSession(9*time.Hour+30*time.Minute, 16*time.Hour))
+	bars := []Bar{
+		minuteBar(time.Date(2020, 3, 6, 14, 30, 0, 0, time.UTC), 1.),
+		minuteBar(time.Date(2020, 3, 9, 13, 30, 0, 0, time.UTC), 2.),
+	}
+
+	got := aggregateBars(a, bars)
+	assert.Len(t, got, 1)
+	assert.True(t, got[0].Time.Equal(time.Date(2020, 3, 6, 14, 30, 0, 0, time.UTC)))
+
+	current, _ := a.Current()
+	assert.True(t, current.Time.Equal(time.Date(2020, 3, 9, 13, 30, 0, 0, time.UTC)))
+}
+
+func TestBarAggregatorFlush(t *testing.T) {
+	a, _ := NewBarAggregator(5 * time.Minute)
+	_, ok := a.Flush()
+	assert.False(t, ok, "must not flush an empty bar")
+
+	a.Next(minuteBar(at(0), 10.))
+	want, _ := a.Current()
+	got, ok := a.Flush()
+	assert.True(t, ok)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarAggregatorClone(t *testing.T) {
+	a, _ := NewBarAggregator(5 * time.Minute)
+	var bars []Bar
+	for i := 0; i < 12; i++ {
+		bars = append(bars, minuteBar(at(float64(i*60)), 10.+float64(i%4)))
+	}
+	aggregateBars(a, bars[:3])
+	clone := a.Clone()
+
+	want := aggregateBars(a, bars[3:])
+	got := aggregateBars(clone, bars[3:])
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarAggregatorReset(t *testing.T) {
+	a, _ := NewBarAggregator(5 * time.Minute)
+	a.Next(minuteBar(at(0), 10.))
+	a.Reset()
+
+	want, _ := NewBarAggregator(5 * time.Minute)
+	assert.Equal(t, want, a)
+}
+
+func TestBarAggregatorString(t *testing.T) {
+	a, _ := NewBarAggregator(time.Hour)
+	assert.Equal(t, "Bars(1h0m0s)", a.String())
+}
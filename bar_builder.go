@@ -0,0 +1,255 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"time"
+)
+
+// TradeOf is a trade print of values of type T: the size traded at a price at Time
+type TradeOf[T Float] struct {
+	Time time.Time
+
+	Price T
+	Size  T
+}
+
+// Trade is a TradeOf float64 values
+type Trade = TradeOf[float64]
+
+// Bar returns the trade as a bar of a single price, such as to feed ticks to a BarTransformer
+func (t TradeOf[T]) Bar() BarOf[T] {
+	return BarOf[T]{
+		Time:   t.Time,
+		Open:   t.Price,
+		High:   t.Price,
+		Low:    t.Price,
+		Close:  t.Price,
+		Volume: t.Size,
+	}
+}
+
+// barRule selects when a BarBuilder completes a bar
+type barRule int
+
+const (
+	timeBars barRule = iota
+	tickBars
+	volumeBars
+	dollarBars
+)
+
+/*
+BarBuilderOf builds OHLCV bars from a stream of trades. A bar is completed by time, when a trade falls in
+a later interval, or by activity, once it holds a number of trades, a volume or a traded value.
+Its bars can feed the bar indicators, or their closes any other indicator.
+
+Time bars start at multiples of the interval since the zero time, which are UTC clock boundaries for
+intervals that divide a day; intervals without trades produce no bar. A time bar is only known to be
+complete when a later trade arrives, see Current and Flush for the bar in progress. A trade timestamped
+before the bar in progress is added to it.
+
+Activity bars are completed by the trade that reaches their threshold, which belongs to them whole, so their
+volume or value may exceed it. They start at the time of their first trade.
+
+# Example
+```
+bars, _ := NewTimeBarBuilder(time.Minute)
+bar, ok := bars.Next(Trade{Time: time.Now(), Price: 10.5, Size: 100.})
+```
+*/
+type BarBuilderOf[T Float] struct {
+	rule      barRule
+	interval  time.Duration
+	ticks     int
+	threshold T
+
+	// bar in progress
+	current    BarOf[T]
+	hasCurrent bool
+	trades     int
+	value      T
+}
+
+// BarBuilder is a BarBuilderOf float64 values
+type BarBuilder = BarBuilderOf[float64]
+
+// NewTimeBarBuilder creates a new BarBuilder of bars spanning the given interval
+// Example: NewTimeBarBuilder(time.Minute)
+func NewTimeBarBuilder(interval time.Duration) (*BarBuilder, error) {
+	return NewTimeBarBuilderOf[float64](interval)
+}
+
+// NewTimeBarBuilderOf creates a new BarBuilderOf values of type T of bars spanning the given interval
+// Example: NewTimeBarBuilderOf[float32](time.Minute)
+func NewTimeBarBuilderOf[T Float](interval time.Duration) (*BarBuilderOf[T], error) {
+	if interval <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BarBuilderOf[T]{
+		rule:     timeBars,
+		interval: interval,
+	}, nil
+}
+
+// NewTickBarBuilder creates a new BarBuilder of bars of n trades
+// Example: NewTickBarBuilder(100)
+func NewTickBarBuilder(n int) (*BarBuilder, error) {
+	return NewTickBarBuilderOf[float64](n)
+}
+
+// NewTickBarBuilderOf creates a new BarBuilderOf values of type T of bars of n trades
+// Example: NewTickBarBuilderOf[float32](100)
+func NewTickBarBuilderOf[T Float](n int) (*BarBuilderOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BarBuilderOf[T]{
+		rule:  tickBars,
+		ticks: n,
+	}, nil
+}
+
+// NewVolumeBarBuilder creates a new BarBuilder of bars completed once their volume reaches the given volume
+// Example: NewVolumeBarBuilder(10000.)
+func NewVolumeBarBuilder(volume float64) (*BarBuilder, error) {
+	return NewVolumeBarBuilderOf(volume)
+}
+
+// NewVolumeBarBuilderOf creates a new BarBuilderOf values of type T of bars completed once their volume reaches the given volume
+// Example: NewVolumeBarBuilderOf[float32](10000.)
+func NewVolumeBarBuilderOf[T Float](volume T) (*BarBuilderOf[T], error) {
+	if !(volume > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BarBuilderOf[T]{
+		rule:      volumeBars,
+		threshold: volume,
+	}, nil
+}
+
+// NewDollarBarBuilder creates a new BarBuilder of bars completed once their traded value, the sum of price
+// times size, reaches the given value
+// Example: NewDollarBarBuilder(1e6)
+func NewDollarBarBuilder(value float64) (*BarBuilder, error) {
+	return NewDollarBarBuilderOf(value)
+}
+
+// NewDollarBarBuilderOf creates a new BarBuilderOf values of type T of bars completed once their traded value,
+// the sum of price times size, reaches the given value
+// Example: NewDollarBarBuilderOf[float32](1e6)
+func NewDollarBarBuilderOf[T Float](value T) (*BarBuilderOf[T], error) {
+	if !(value > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BarBuilderOf[T]{
+		rule:      dollarBars,
+		threshold: value,
+	}, nil
+}
+
+// Next takes the next trade and returns the bar it completes, if any
+func (b *BarBuilderOf[T]) Next(trade TradeOf[T]) (BarOf[T], bool) {
+	if b.rule == timeBars {
+		start := trade.Time.Truncate(b.interval)
+		if !b.hasCurrent || !start.After(b.current.Time) {
+			b.add(trade, start)
+			return BarOf[T]{}, false
+		}
+
+		bar := b.current
+		b.Reset()
+		b.add(trade, start)
+		return bar, true
+	}
+
+	b.add(trade, trade.Time)
+	if b.complete() {
+		return b.Flush()
+	}
+	return BarOf[T]{}, false
+}
+
+// add adds trade to the bar in progress, starting a bar at start if there is none
+func (b *BarBuilderOf[T]) add(trade TradeOf[T], start time.Time) {
+	if !b.hasCurrent {
+		b.current = BarOf[T]{
+			Time: start,
+			Open: trade.Price,
+			High: trade.Price,
+			Low:  trade.Price,
+		}
+		b.hasCurrent = true
+	}
+
+	if trade.Price > b.current.High {
+		b.current.High = trade.Price
+	}
+	if trade.Price < b.current.Low {
+		b.current.Low = trade.Price
+	}
+	b.current.Close = trade.Price
+	b.current.Volume += trade.Size
+
+	b.trades++
+	b.value += trade.Price * trade.Size
+}
+
+// complete reports whether the bar in progress reached the threshold of an activity bar
+func (b *BarBuilderOf[T]) complete() bool {
+	switch b.rule {
+	case tickBars:
+		return b.trades >= b.ticks
+	case volumeBars:
+		return b.current.Volume >= b.threshold
+	case dollarBars:
+		return b.value >= b.threshold
+	}
+	return false
+}
+
+// Current returns the bar in progress, if there is one
+func (b *BarBuilderOf[T]) Current() (BarOf[T], bool) {
+	return b.current, b.hasCurrent
+}
+
+// Flush completes and returns the bar in progress, if there is one, such as at the end of a stream
+func (b *BarBuilderOf[T]) Flush() (BarOf[T], bool) {
+	bar, ok := b.current, b.hasCurrent
+	b.Reset()
+	return bar, ok
+}
+
+// Reset resets the builder to a clean state, dropping the bar in progress
+func (b *BarBuilderOf[T]) Reset() {
+	b.current = BarOf[T]{}
+	b.hasCurrent = false
+	b.trades = 0
+	b.value = 0
+}
+
+// Clone returns a copy of the builder that can be advanced independently
+func (b *BarBuilderOf[T]) Clone() *BarBuilderOf[T] {
+	clone := *b
+	return &clone
+}
+
+func (b *BarBuilderOf[T]) String() string {
+	switch b.rule {
+	case tickBars:
+		return fmt.Sprintf("TickBars(%d)", b.ticks)
+	case volumeBars:
+		return fmt.Sprintf("VolumeBars(%g)", float64(b.threshold))
+	case dollarBars:
+		return fmt.Sprintf("DollarBars(%g)", float64(b.threshold))
+	}
+	return fmt.Sprintf("TimeBars(%s)", b.interval)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+var builderTrades = []Trade{
+	{Time: at(0), Price: 10., Size: 100.},
+	{Time: at(20), Price: 10.5, Size: 50.},
+	{Time: at(45), Price: 9.8, Size: 200.},
+	{Time: at(61), Price: 10.2, Size: 100.},
+	{Time: at(90), Price: 10.4, Size: 300.},
+	{Time: at(185), Price: 10.1, Size: 150.},
+	{Time: at(190), Price: 10.3, Size: 100.},
+}
+
+func buildBars(b *BarBuilder, trades []Trade) []Bar {
+	var bars []Bar
+	for _, trade := range trades {
+		if bar, ok := b.Next(trade); ok {
+			bars = append(bars, bar)
+		}
+	}
+	return bars
+}
+
+func TestNewBarBuilder(t *testing.T) {
+	tests := map[string]struct {
+		new     func() (*BarBuilder, error)
+		wantErr error
+	}{
+		"zero interval":     {new: func() (*BarBuilder, error) { return NewTimeBarBuilder(0) }, wantErr: ErrInvalidParameters},
+		"negative ticks":    {new: func() (*BarBuilder, error) { return NewTickBarBuilder(-1) }, wantErr: ErrInvalidParameters},
+		"zero volume":       {new: func() (*BarBuilder, error) { return NewVolumeBarBuilder(0.) }, wantErr: ErrInvalidParameters},
+		"NaN value":         {new: func() (*BarBuilder, error) { return NewDollarBarBuilder(math.NaN()) }, wantErr: ErrInvalidParameters},
+		"positive interval": {new: func() (*BarBuilder, error) { return NewTimeBarBuilder(time.Minute) }, wantErr: nil},
+		"positive ticks":    {new: func() (*BarBuilder, error) { return NewTickBarBuilder(3) }, wantErr: nil},
+		"positive volume":   {new: func() (*BarBuilder, error) { return NewVolumeBarBuilder(300.) }, wantErr: nil},
+		"positive value":    {new: func() (*BarBuilder, error) { return NewDollarBarBuilder(3000.) }, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := tc.new()
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestBarBuilderNext(t *testing.T) {
+	timeBars, _ := NewTimeBarBuilder(time.Minute)
+	tickBars, _ := NewTickBarBuilder(3)
+	volumeBars, _ := NewVolumeBarBuilder(300.)
+	dollarBars, _ := NewDollarBarBuilder(3000.)
+
+	tests := map[string]struct {
+		builder *BarBuilder
+		want    []Bar
+		current Bar
+	}{
+		"time": {
+			builder: timeBars,
+			want: []Bar{
+				{Time: at(0), Open: 10., High: 10.5, Low: 9.8, Close: 9.8, Volume: 350.},
+				{Time: at(60), Open: 10.2, High: 10.4, Low: 10.2, Close: 10.4, Volume: 400.},
+			},
+			current: Bar{Time: at(180), Open: 10.1, High: 10.3, Low: 10.1, Close: 10.3, Volume: 250.},
+		},
+		"tick": {
+			builder: tickBars,
+			want: []Bar{
+				{Time: at(0), Open: 10., High: 10.5, Low: 9.8, Close: 9.8, Volume: 350.},
+				{Time: at(61), Open: 10.2, High: 10.4, Low: 10.1, Close: 10.1, Volume: 550.},
+			},
+			current: Bar{Time: at(190), Open: 10.3, High: 10.3, Low: 10.3, Close: 10.3, Volume: 100.},
+		},
+		"volume": {
+			builder: volumeBars,
+			want: []Bar{
+				{Time: at(0), Open: 10., High: 10.5, Low: 9.8, Close: 9.8, Volume: 350.},
+				{Time: at(61), Open: 10.2, High: 10.4, Low: 10.2, Close: 10.4, Volume: 400.},
+			},
+			current: Bar{Time: at(185), Open: 10.1, High: 10.3, Low: 10.1, Close: 10.3, Volume: 250.},
+		},
+		"dollar": {
+			builder: dollarBars,
+			want: []Bar{
+				{Time: at(0), Open: 10., High: 10.5, Low: 9.8, Close: 9.8, Volume: 350.},
+				{Time: at(61), Open: 10.2, High: 10.4, Low: 10.2, Close: 10.4, Volume: 400.},
+			},
+			current: Bar{Time: at(185), Open: 10.1, High: 10.3, Low: 10.1, Close: 10.3, Volume: 250.},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := buildBars(tc.builder, builderTrades)
+			if diff := cmp.Diff(tc.want, got, approxComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+
+			current, ok := tc.builder.Current()
+			assert.True(t, ok)
+			if diff := cmp.Diff(tc.current, current, approxComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestBarBuilderTimeLateTrade(t *testing.T) {
+	b, _ := NewTimeBarBuilder(time.Minute)
+	b.Next(Trade{Time: at(61), Price: 10., Size: 1.})
+	_, ok := b.Next(Trade{Time: at(30), Price: 11., Size: 1.})
+	assert.False(t, ok, "a late trade must not complete the bar")
+
+	want := Bar{Time: at(60), Open: 10., High: 11., Low: 10., Close: 11., Volume: 2.}
+	got, _ := b.Current()
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarBuilderTimeState(t *testing.T) {
+	b, _ := NewTimeBarBuilder(time.Minute)
+	buildBars(b, builderTrades)
+
+	want, _ := NewTimeBarBuilder(time.Minute)
+	buildBars(want, builderTrades[5:])
+	assert.Equal(t, want, b, "must only hold the state of the bar in progress")
+}
+
+func TestBarBuilderFlush(t *testing.T) {
+	b, _ := NewTimeBarBuilder(time.Minute)
+	_, ok := b.Flush()
+	assert.False(t, ok, "must not flush an empty bar")
+
+	buildBars(b, builderTrades)
+	want, _ := b.Current()
+	got, ok := b.Flush()
+	assert.True(t, ok)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	_, ok = b.Current()
+	assert.False(t, ok, "must clear the bar in progress")
+}
+
+func TestBarBuilderClone(t *testing.T) {
+	b, _ := NewTickBarBuilder(3)
+	buildBars(b, builderTrades[:2])
+	clone := b.Clone()
+
+	want := buildBars(b, builderTrades[2:])
+	got := buildBars(clone, builderTrades[2:])
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestBarBuilderReset(t *testing.T) {
+	b, _ := NewDollarBarBuilder(3000.)
+	buildBars(b, builderTrades[:2])
+	b.Reset()
+
+	want, _ := NewDollarBarBuilder(3000.)
+	assert.Equal(t, want, b)
+}
+
+func TestBarBuilderString(t *testing.T) {
+	timeBars, _ := NewTimeBarBuilder(5 * time.Minute)
+	tickBars, _ := NewTickBarBuilder(100)
+	volumeBars, _ := NewVolumeBarBuilder(1e4)
+	dollarBars, _ := NewDollarBarBuilder(1e6)
+
+	assert.Equal(t, "TimeBars(5m0s)", timeBars.String())
+	assert.Equal(t, "TickBars(100)", tickBars.String())
+	assert.Equal(t, "VolumeBars(10000)", volumeBars.String())
+	assert.Equal(t, "DollarBars(1e+06)", dollarBars.String())
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+BetaOf returns the beta of the last n pairs of values, the sensitivity of the second series
+(usually an asset's returns) to the first one (usually a benchmark's returns). It is the slope of
+the least squares regression of y on x, updated in constant time as pairs enter and leave the window.
+
+# Formula
+
+β = cov(x, y) / σ<sub>x</sub><sup>2</sup>
+
+Where:
+
+* _cov(x, y)_ - covariance of the two series, see Covariance
+* _σ<sub>x</sub><sup>2</sup>_ - variance of the first series
+
+The beta is 0 while the first series is constant over the window.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+b, _ := NewBeta(20)
+b.Next(asset, benchmark)
+```
+*/
+type BetaOf[T Float] struct {
+	pairWindow[T]
+}
+
+// Beta is a BetaOf float64 values
+type Beta = BetaOf[float64]
+
+// NewBeta creates a new Beta with the given number of periods
+// Example: NewBeta(20)
+func NewBeta(n int) (*Beta, error) {
+	return NewBetaOf[float64](n)
+}
+
+// NewBetaOf creates a new BetaOf values of type T with the given number of periods
+// Example: NewBetaOf[float32](20)
+func NewBetaOf[T Float](n int) (*BetaOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &BetaOf[T]{
+		pairWindow: newPairWindow[T](n),
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next Beta value
+func (b *BetaOf[T]) Next(x, y T) T {
+	b.push(x, y)
+	return b.moments.beta()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (b *BetaOf[T]) Peek(x, y T) T {
+	moments := b.peek(x, y)
+	return moments.beta()
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated Beta value.
+// If no pair has been committed yet it behaves like Next.
+func (b *BetaOf[T]) UpdateLast(x, y T) T {
+	b.replaceLast(x, y)
+	return b.moments.beta()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (b *BetaOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(b.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (b *BetaOf[T]) Reset() {
+	b.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (b *BetaOf[T]) Clone() *BetaOf[T] {
+	return &BetaOf[T]{
+		pairWindow: b.clone(),
+	}
+}
+
+func (b *BetaOf[T]) String() string {
+	return fmt.Sprintf("Beta(%d)", b.n)
+}
+
+// ComputeBeta computes a Beta with n periods over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeBeta[T Float](n int, xs, ys, out []T, fillNaN bool) ([]T, error) {
+	b, err := NewBetaOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = b.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
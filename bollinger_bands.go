@@ -6,7 +6,7 @@ Licensed under terms of MIT license (see LICENSE)
 package tago
 
 /*
-BollingerBands
+BollingerBandsOf
 The Bollinger Bands are represented by Average EMA and standard deviaton that is moved 'k' times away in both directions from calculated average value.
 
 # Formula
@@ -25,15 +25,18 @@ See SMA, SD documentation.
 ```
 ```
 */
-type BollingerBands struct {
+type BollingerBandsOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n          int
 	multiplier int
 
 	// internal parameters for calculations
-	sd *StandardDeviation
+	sd *StandardDeviationOf[T]
 }
 
+// BollingerBands is a BollingerBandsOf float64 values
+type BollingerBands = BollingerBandsOf[float64]
+
 // // NewBollingerBands creates a new BollingerBands with the given number of periods
 // // Example: NewBollingerBands(9)
 // func NewBollingerBands(n, multiplier int) (*BollingerBands, error) {
@@ -54,9 +57,18 @@ type BollingerBands struct {
 // }
 
 // // Next takes the next input and returns the next BollingerBands value
-// func (bb *BollingerBands) Next(input float64) (float64, float64, float64) {
+// func (bb *BollingerBandsOf[T]) Next(input T) (T, T, T) {
 // 	sd := bb.sd.Next(input)
 // 	// mean := bb.sd.Mean()
 
-// 	// return mean + float64(bb.multiplier)*sd, mean, mean - float64(bb.multiplier)*sd
+// 	// return mean + T(bb.multiplier)*sd, mean, mean - T(bb.multiplier)*sd
 // }
+
+// Clone returns a deep copy of the indicator, including its StandardDeviation
+func (bb *BollingerBandsOf[T]) Clone() *BollingerBandsOf[T] {
+	clone := *bb
+	if bb.sd != nil {
+		clone.sd = bb.sd.Clone()
+	}
+	return &clone
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+/*
+Package candlestick recognises candlestick patterns of one, two and three bars in a stream of bars,
+such as a Doji, a bullish Engulfing or a Morning Star.
+
+A Recognizer reports the patterns each bar completes as a Match of a Pattern, named after the TA-Lib
+CDL* function recognising it, and a strength like the value that function returns: 100 for a bullish
+pattern, -100 for a bearish one and 100 for a neutral one such as Doji. The sizes of bodies and shadows
+are judged against the average range of the bars before the pattern, so the same thresholds suit
+instruments of any price and volatility.
+
+# Example
+```
+recognizer, _ := candlestick.NewRecognizer(10)
+for _, match := range recognizer.Next(bar) {
+fmt.Println(match.Pattern, match.Strength)
+}
+```
+*/
+package candlestick
+
+import (
+	"fmt"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+// Pattern identifies a candlestick pattern
+type Pattern int
+
+const (
+	// Doji is a bar with almost no body, neutral
+	Doji Pattern = iota + 1
+	// Hammer is a short body atop a long lower shadow making a new low, bullish
+	Hammer
+	// HangingMan is a short body atop a long lower shadow making a new high, bearish
+	HangingMan
+	// InvertedHammer is a short body below a long upper shadow making a new low, bullish
+	InvertedHammer
+	// ShootingStar is a short body below a long upper shadow making a new high, bearish
+	ShootingStar
+	// Marubozu is a long body without shadows, bullish if white and bearish if black
+	Marubozu
+	// Engulfing is a body engulfing the opposite body of the bar before it, in its direction
+	Engulfing
+	// Harami is a short body within the long body of the bar before it, against its direction
+	Harami
+	// MorningStar is a long black bar, a short body below it and a white bar closing into the first body, bullish
+	MorningStar
+	// EveningStar is a long white bar, a short body above it and a black bar closing into the first body, bearish
+	EveningStar
+	// ThreeWhiteSoldiers is three white bars closing higher, each opening within the body before it, bullish
+	ThreeWhiteSoldiers
+	// ThreeBlackCrows is three black bars closing lower, each opening within the body before it, bearish
+	ThreeBlackCrows
+)
+
+// String returns the name of the TA-Lib function recognising the pattern
+func (p Pattern) String() string {
+	switch p {
+	case Doji:
+		return "CDLDOJI"
+	case Hammer:
+		return "CDLHAMMER"
+	case HangingMan:
+		return "CDLHANGINGMAN"
+	case InvertedHammer:
+		return "CDLINVERTEDHAMMER"
+	case ShootingStar:
+		return "CDLSHOOTINGSTAR"
+	case Marubozu:
+		return "CDLMARUBOZU"
+	case Engulfing:
+		return "CDLENGULFING"
+	case Harami:
+		return "CDLHARAMI"
+	case MorningStar:
+		return "CDLMORNINGSTAR"
+	case EveningStar:
+		return "CDLEVENINGSTAR"
+	case ThreeWhiteSoldiers:
+		return "CDL3WHITESOLDIERS"
+	case ThreeBlackCrows:
+		return "CDL3BLACKCROWS"
+	default:
+		return fmt.Sprintf("Pattern(%d)", int(p))
+	}
+}
+
+// Match is a pattern completed by a bar
+type Match struct {
+	Pattern Pattern
+	// Strength is 100 for a bullish or neutral pattern and -100 for a bearish one
+	Strength int
+}
+
+// Option configures the thresholds of a Recognizer, each a fraction of the average range of the bars
+type Option func(*config)
+
+// WithDojiBody sets the largest body of a Doji, 0.1 by default
+func WithDojiBody(fraction float64) Option {
+	return func(c *config) {
+		c.dojiBody = fraction
+	}
+}
+
+// WithShortBody sets the largest short body, such as the body of a Hammer, 0.3 by default
+func WithShortBody(fraction float64) Option {
+	return func(c *config) {
+		c.shortBody = fraction
+	}
+}
+
+// WithLongBody sets the smallest long body, such as the body of a Marubozu, 0.6 by default
+func WithLongBody(fraction float64) Option {
+	return func(c *config) {
+		c.longBody = fraction
+	}
+}
+
+// WithShortShadow sets the largest short shadow, such as the upper shadow of a Hammer, 0.1 by default
+func WithShortShadow(fraction float64) Option {
+	return func(c *config) {
+		c.shortShadow = fraction
+	}
+}
+
+// WithLongShadow sets the smallest long shadow, such as the lower shadow of a Hammer, 0.6 by default
+func WithLongShadow(fraction float64) Option {
+	return func(c *config) {
+		c.longShadow = fraction
+	}
+}
+
+// config holds the thresholds of a Recognizer
+type config struct {
+	dojiBody    float64
+	shortBody   float64
+	longBody    float64
+	shortShadow float64
+	longShadow  float64
+}
+
+func newConfig(options []Option) (config, error) {
+	c := config{
+		dojiBody:    0.1,
+		shortBody:   0.3,
+		longBody:    0.6,
+		shortShadow: 0.1,
+		longShadow:  0.6,
+	}
+	for _, option := range options {
+		option(&c)
+	}
+
+	if !(c.dojiBody > 0 && c.dojiBody <= c.shortBody && c.shortBody < c.longBody) ||
+		!(c.shortShadow > 0 && c.shortShadow < c.longShadow) {
+		return config{}, tago.ErrInvalidParameters
+	}
+	return c, nil
+}
+
+/*
+RecognizerOf recognises the candlestick patterns completed by each bar of values of type T. The thresholds
+of the patterns are fractions of the average range, high minus low, of the _n_ bars before the current one,
+and no pattern is recognised until _n_ bars have been seen.
+
+# Parameters
+
+* _n_ - number of bars of the average range (integer greater than 0, usually 10)
+* _options_ - optional Option values: WithDojiBody, WithShortBody, WithLongBody, WithShortShadow and WithLongShadow
+
+# Example
+```
+recognizer, _ := NewRecognizer(10, WithDojiBody(0.05))
+matches := recognizer.Next(tago.Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type RecognizerOf[T tago.Float] struct {
+	// number of bars of the average range (must be an integer greater than 0)
+	n      int
+	config config
+
+	// internal parameters for calculations
+	ranges  *tago.MovingAverageOf[T]
+	average T
+	count   int
+
+	// the two bars before the current one, the most recent first
+	previous [2]candle[T]
+
+	out []Match
+}
+
+// Recognizer is a RecognizerOf float64 values
+type Recognizer = RecognizerOf[float64]
+
+// NewRecognizer creates a new Recognizer with the given number of bars of the average range and options
+// Example: NewRecognizer(10)
+func NewRecognizer(n int, options ...Option) (*Recognizer, error) {
+	return NewRecognizerOf[float64](n, options...)
+}
+
+// NewRecognizerOf creates a new RecognizerOf values of type T with the given number of bars of the average range and options
+// Example: NewRecognizerOf[float32](10)
+func NewRecognizerOf[T tago.Float](n int, options ...Option) (*RecognizerOf[T], error) {
+	c, err := newConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := tago.NewMovingAverageOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecognizerOf[T]{
+		n:      n,
+		config: c,
+
+		ranges: ranges,
+	}, nil
+}
+
+// Next takes the next bar and returns the patterns it completes. The returned slice is reused by the next call.
+func (r *RecognizerOf[T]) Next(bar tago.BarOf[T]) []Match {
+	r.out = r.out[:0]
+	current := newCandle(bar)
+	if r.count >= r.n {
+		r.recognize(current)
+	}
+
+	r.average = r.ranges.Next(bar.High - bar.Low)
+	r.previous[1], r.previous[0] = r.previous[0], current
+	r.count++
+	return r.out
+}
+
+// Compute feeds every bar of bars through Next and writes the strength of pattern for each bar to out,
+// 0 where it is not recognised, as the TA-Lib CDL* functions do. It reuses out when it has enough capacity
+// and returns it resliced to the length of bars.
+func (r *RecognizerOf[T]) Compute(pattern Pattern, bars []tago.BarOf[T], out []int) []int {
+	if cap(out) < len(bars) {
+		out = make([]int, len(bars))
+	}
+	out = out[:len(bars)]
+
+	for i, bar := range bars {
+		out[i] = 0
+		for _, match := range r.Next(bar) {
+			if match.Pattern == pattern {
+				out[i] = match.Strength
+			}
+		}
+	}
+	return out
+}
+
+// Reset resets the recognizer to a clean state
+func (r *RecognizerOf[T]) Reset() {
+	r.ranges.Reset()
+	r.average = 0
+	r.count = 0
+	r.previous = [2]candle[T]{}
+	r.out = r.out[:0]
+}
+
+// Clone returns a deep copy of the recognizer, including its MovingAverage
+func (r *RecognizerOf[T]) Clone() *RecognizerOf[T] {
+	clone := *r
+	clone.ranges = r.ranges.Clone()
+	clone.out = nil
+	return &clone
+}
+
+func (r *RecognizerOf[T]) String() string {
+	return fmt.Sprintf("Candlestick(%d)", r.n)
+}
+
+// ComputePattern computes a Recognizer with n bars of the average range and the given options over bars
+// and writes the strength of pattern for each bar to out (see Compute)
+func ComputePattern[T tago.Float](pattern Pattern, n int, bars []tago.BarOf[T], out []int, options ...Option) ([]int, error) {
+	r, err := NewRecognizerOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Compute(pattern, bars, out), nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package candlestick
+
+import (
+	"testing"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/stretchr/testify/assert"
+)
+
+// bars returns bars with the given open, high, low and close prices, four per bar
+func bars(prices ...float64) []tago.Bar {
+	out := make([]tago.Bar, len(prices)/4)
+	for i := range out {
+		out[i] = tago.Bar{Open: prices[4*i], High: prices[4*i+1], Low: prices[4*i+2], Close: prices[4*i+3]}
+	}
+	return out
+}
+
+// warmup has an average range of 2 and no pattern
+var warmup = bars(
+	10., 11., 9., 10.5,
+	10.5, 11.5, 9.5, 10.,
+	10., 11., 9., 10.5,
+)
+
+func TestNewRecognizer(t *testing.T) {
+	tests := map[string]struct {
+		n       int
+		options []Option
+		wantErr error
+	}{
+		"zero n":                         {n: 0, wantErr: tago.ErrInvalidParameters},
+		"zero doji body":                 {n: 10, options: []Option{WithDojiBody(0.)}, wantErr: tago.ErrInvalidParameters},
+		"doji body above short body":     {n: 10, options: []Option{WithDojiBody(0.4)}, wantErr: tago.ErrInvalidParameters},
+		"short body above long body":     {n: 10, options: []Option{WithShortBody(0.7)}, wantErr: tago.ErrInvalidParameters},
+		"short shadow above long shadow": {n: 10, options: []Option{WithShortShadow(0.6)}, wantErr: tago.ErrInvalidParameters},
+		"NaN long shadow":                {n: 10, options: []Option{WithLongShadow(-1.)}, wantErr: tago.ErrInvalidParameters},
+		"default thresholds":             {n: 10, wantErr: nil},
+		"custom thresholds": {n: 10, options: []Option{
+			WithDojiBody(0.05), WithShortBody(0.25), WithLongBody(0.75), WithShortShadow(0.05), WithLongShadow(0.5),
+		}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewRecognizer(tc.n, tc.options...)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestRecognizerWarmup(t *testing.T) {
+	recognizer, _ := NewRecognizer(3)
+	doji := tago.Bar{Open: 10., High: 11., Low: 9., Close: 10.}
+	for i := 0; i < 3; i++ {
+		assert.Empty(t, recognizer.Next(doji), "must not recognise patterns before n bars")
+	}
+	assert.Equal(t, []Match{{Pattern: Doji, Strength: 100}}, recognizer.Next(doji))
+}
+
+func TestRecognizerCompute(t *testing.T) {
+	inputs := append(append([]tago.Bar{}, warmup...), bars(10.5, 11.3, 9.7, 10.55, 10., 11., 9., 10.5)...)
+
+	stream, _ := NewRecognizer(3)
+	want := make([]int, len(inputs))
+	for i, bar := range inputs {
+		for _, match := range stream.Next(bar) {
+			if match.Pattern == Doji {
+				want[i] = match.Strength
+			}
+		}
+	}
+	assert.Equal(t, []int{0, 0, 0, 100, 0}, want)
+
+	batch, _ := NewRecognizer(3)
+	out := make([]int, len(inputs))
+	got := batch.Compute(Doji, inputs, out)
+	assert.Equal(t, want, got, "must return the same values as Next")
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+
+	_, err := ComputePattern(Doji, 0, inputs, nil)
+	assert.EqualError(t, err, tago.ErrInvalidParameters.Error(), "must return the correct error")
+	got, err = ComputePattern(Doji, 3, inputs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRecognizerClone(t *testing.T) {
+	recognizer, _ := NewRecognizer(3)
+	for _, bar := range warmup {
+		recognizer.Next(bar)
+	}
+
+	clone := recognizer.Clone()
+	doji := tago.Bar{Open: 10.5, High: 11.3, Low: 9.7, Close: 10.55}
+	assert.Equal(t, []Match{{Pattern: Doji, Strength: 100}}, clone.Next(doji), "clone must continue from the same state")
+	assert.Equal(t, []Match{{Pattern: Doji, Strength: 100}}, recognizer.Next(doji), "original must not see the clone's inputs")
+}
+
+func TestRecognizerReset(t *testing.T) {
+	recognizer, _ := NewRecognizer(3)
+	for _, bar := range warmup {
+		recognizer.Next(bar)
+	}
+	recognizer.Reset()
+
+	assert.Empty(t, recognizer.Next(tago.Bar{Open: 10., High: 11., Low: 9., Close: 10.}), "must start a new warm-up")
+}
+
+func TestRecognizerString(t *testing.T) {
+	recognizer, _ := NewRecognizer(10)
+	assert.Equal(t, "Candlestick(10)", recognizer.String())
+}
+
+func TestPatternString(t *testing.T) {
+	tests := map[Pattern]string{
+		Doji:               "CDLDOJI",
+		Hammer:             "CDLHAMMER",
+		HangingMan:         "CDLHANGINGMAN",
+		InvertedHammer:     "CDLINVERTEDHAMMER",
+		ShootingStar:       "CDLSHOOTINGSTAR",
+		Marubozu:           "CDLMARUBOZU",
+		Engulfing:          "CDLENGULFING",
+		Harami:             "CDLHARAMI",
+		MorningStar:        "CDLMORNINGSTAR",
+		EveningStar:        "CDLEVENINGSTAR",
+		ThreeWhiteSoldiers: "CDL3WHITESOLDIERS",
+		ThreeBlackCrows:    "CDL3BLACKCROWS",
+		Pattern(0):         "Pattern(0)",
+	}
+	for pattern, want := range tests {
+		assert.Equal(t, want, pattern.String())
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package candlestick
+
+import "github.com/binhnguyenduc/tago"
+
+// penetration is how far, as a fraction of the first body, the third bar of a star must close into it
+const penetration = 0.3
+
+// candle is a bar with the sizes of its body and shadows
+type candle[T tago.Float] struct {
+	open, high, low, close T
+
+	body, upper, lower T
+}
+
+func newCandle[T tago.Float](bar tago.BarOf[T]) candle[T] {
+	c := candle[T]{open: bar.Open, high: bar.High, low: bar.Low, close: bar.Close}
+	c.body = c.top() - c.bottom()
+	c.upper = c.high - c.top()
+	c.lower = c.bottom() - c.low
+	return c
+}
+
+func (c candle[T]) white() bool {
+	return c.close > c.open
+}
+
+func (c candle[T]) black() bool {
+	return c.close < c.open
+}
+
+// top returns the top of the body
+func (c candle[T]) top() T {
+	if c.open > c.close {
+		return c.open
+	}
+	return c.close
+}
+
+// bottom returns the bottom of the body
+func (c candle[T]) bottom() T {
+	if c.open < c.close {
+		return c.open
+	}
+	return c.close
+}
+
+// thresholds holds the thresholds of config in units of the average range
+type thresholds[T tago.Float] struct {
+	dojiBody, shortBody, longBody T
+	shortShadow, longShadow       T
+}
+
+func newThresholds[T tago.Float](c config, average T) thresholds[T] {
+	return thresholds[T]{
+		dojiBody:    T(c.dojiBody) * average,
+		shortBody:   T(c.shortBody) * average,
+		longBody:    T(c.longBody) * average,
+		shortShadow: T(c.shortShadow) * average,
+		longShadow:  T(c.longShadow) * average,
+	}
+}
+
+// recognize appends the patterns completed by current to the output
+func (r *RecognizerOf[T]) recognize(current candle[T]) {
+	t := newThresholds(r.config, r.average)
+	r.single(t, current)
+	if r.count >= 1 {
+		r.double(t, r.previous[0], current)
+	}
+	if r.count >= 2 {
+		r.triple(t, r.previous[1], r.previous[0], current)
+	}
+}
+
+func (r *RecognizerOf[T]) match(pattern Pattern, strength int) {
+	r.out = append(r.out, Match{Pattern: pattern, Strength: strength})
+}
+
+// single recognises the patterns of current alone, and those of its shape in the context of the bar before it
+func (r *RecognizerOf[T]) single(t thresholds[T], c candle[T]) {
+	if c.body <= t.dojiBody {
+		r.match(Doji, 100)
+	}
+
+	if c.body >= t.longBody && c.upper <= t.shortShadow && c.lower <= t.shortShadow {
+		switch {
+		case c.white():
+			r.match(Marubozu, 100)
+		case c.black():
+			r.match(Marubozu, -100)
+		}
+	}
+
+	if r.count == 0 || c.body > t.shortBody {
+		return
+	}
+	p := r.previous[0]
+	switch {
+	case c.lower >= t.longShadow && c.upper <= t.shortShadow:
+		if c.low < p.low {
+			r.match(Hammer, 100)
+		} else if c.high > p.high {
+			r.match(HangingMan, -100)
+		}
+	case c.upper >= t.longShadow && c.lower <= t.shortShadow:
+		if c.low < p.low {
+			r.match(InvertedHammer, 100)
+		} else if c.high > p.high {
+			r.match(ShootingStar, -100)
+		}
+	}
+}
+
+// double recognises the patterns of the bar p followed by c
+func (r *RecognizerOf[T]) double(t thresholds[T], p, c candle[T]) {
+	switch {
+	case c.white() && p.black() && c.close >= p.open && c.open <= p.close && (c.close > p.open || c.open < p.close):
+		r.match(Engulfing, 100)
+	case c.black() && p.white() && c.open >= p.close && c.close <= p.open && (c.open > p.close || c.close < p.open):
+		r.match(Engulfing, -100)
+	}
+
+	if p.body >= t.longBody && c.body <= t.shortBody && c.body < p.body &&
+		c.top() <= p.top() && c.bottom() >= p.bottom() {
+		switch {
+		case p.black():
+			r.match(Harami, 100)
+		case p.white():
+			r.match(Harami, -100)
+		}
+	}
+}
+
+// triple recognises the patterns of the bars pp and p followed by c
+func (r *RecognizerOf[T]) triple(t thresholds[T], pp, p, c candle[T]) {
+	if pp.body >= t.longBody && p.body <= t.shortBody && c.body > t.shortBody {
+		switch {
+		case pp.black() && p.top() < pp.close && c.white() && c.close > pp.close+penetration*pp.body:
+			r.match(MorningStar, 100)
+		case pp.white() && p.bottom() > pp.close && c.black() && c.close < pp.close-penetration*pp.body:
+			r.match(EveningStar, -100)
+		}
+	}
+
+	soldier := func(before, after candle[T]) bool {
+		return after.white() && after.body > t.shortBody && after.upper <= t.shortShadow &&
+			after.close > before.close && after.open > before.open && after.open <= before.close
+	}
+	if pp.white() && pp.body > t.shortBody && pp.upper <= t.shortShadow && soldier(pp, p) && soldier(p, c) {
+		r.match(ThreeWhiteSoldiers, 100)
+	}
+
+	crow := func(before, after candle[T]) bool {
+		return after.black() && after.body > t.shortBody && after.lower <= t.shortShadow &&
+			after.close < before.close && after.open < before.open && after.open >= before.close
+	}
+	if pp.black() && pp.body > t.shortBody && pp.lower <= t.shortShadow && crow(pp, p) && crow(p, c) {
+		r.match(ThreeBlackCrows, -100)
+	}
+}
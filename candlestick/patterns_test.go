@@ -0,0 +1,145 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package candlestick
+
+import (
+	"testing"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecognizerNext(t *testing.T) {
+	tests := map[string]struct {
+		bars []tago.Bar
+		want []Match
+	}{
+		"doji": {
+			bars: bars(10.5, 11.3, 9.7, 10.55),
+			want: []Match{{Pattern: Doji, Strength: 100}},
+		},
+		"hammer": {
+			bars: bars(10.1, 10.5, 8.5, 10.4),
+			want: []Match{{Pattern: Hammer, Strength: 100}},
+		},
+		"hanging man": {
+			bars: bars(11.1, 11.5, 9.5, 11.4),
+			want: []Match{{Pattern: HangingMan, Strength: -100}},
+		},
+		"inverted hammer": {
+			bars: bars(9., 10.6, 8.9, 9.3),
+			want: []Match{{Pattern: InvertedHammer, Strength: 100}},
+		},
+		"shooting star": {
+			bars: bars(11.1, 12.9, 11., 11.4),
+			want: []Match{{Pattern: ShootingStar, Strength: -100}},
+		},
+		"white marubozu": {
+			bars: bars(9.5, 11.05, 9.45, 11.),
+			want: []Match{{Pattern: Marubozu, Strength: 100}},
+		},
+		"black marubozu": {
+			bars: bars(11., 11.05, 9.45, 9.5),
+			want: []Match{{Pattern: Marubozu, Strength: -100}, {Pattern: Engulfing, Strength: -100}},
+		},
+		"bullish engulfing": {
+			bars: bars(
+				10.5, 10.7, 9.8, 10.,
+				9.9, 10.8, 9.8, 10.7,
+			),
+			want: []Match{{Pattern: Engulfing, Strength: 100}},
+		},
+		"bearish engulfing": {
+			bars: bars(
+				10., 10.7, 9.8, 10.5,
+				10.6, 10.7, 9.7, 9.9,
+			),
+			want: []Match{{Pattern: Engulfing, Strength: -100}},
+		},
+		"bearish harami": {
+			bars: bars(
+				9.5, 11.1, 9.4, 11.,
+				10.6, 10.8, 10.2, 10.3,
+			),
+			want: []Match{{Pattern: Harami, Strength: -100}},
+		},
+		"bullish harami": {
+			bars: bars(
+				11., 11.1, 9.4, 9.5,
+				10.2, 10.8, 10.1, 10.5,
+			),
+			want: []Match{{Pattern: Harami, Strength: 100}},
+		},
+		"morning star": {
+			bars: bars(
+				11.5, 11.6, 9.9, 10.,
+				9.6, 9.8, 9.3, 9.7,
+				9.8, 11.3, 9.5, 10.9,
+			),
+			want: []Match{{Pattern: MorningStar, Strength: 100}},
+		},
+		"evening star": {
+			bars: bars(
+				10., 11.6, 9.9, 11.5,
+				11.8, 12., 11.5, 11.7,
+				11.6, 11.9, 10.4, 10.7,
+			),
+			want: []Match{{Pattern: EveningStar, Strength: -100}},
+		},
+		"three white soldiers": {
+			bars: bars(
+				10., 11., 9.7, 10.9,
+				10.5, 11.6, 10.2, 11.5,
+				11.2, 12.2, 10.9, 12.1,
+			),
+			want: []Match{{Pattern: ThreeWhiteSoldiers, Strength: 100}},
+		},
+		"three black crows": {
+			bars: bars(
+				12.1, 12.4, 11.1, 11.2,
+				11.6, 11.9, 10.4, 10.5,
+				10.9, 11.2, 9.7, 9.8,
+			),
+			want: []Match{{Pattern: ThreeBlackCrows, Strength: -100}},
+		},
+		"no pattern": {
+			bars: bars(10.5, 11.5, 9.5, 10.),
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			recognizer, _ := NewRecognizer(3)
+			for _, bar := range warmup {
+				recognizer.Next(bar)
+			}
+
+			var got []Match
+			for _, bar := range tc.bars {
+				got = recognizer.Next(bar)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRecognizerThresholds(t *testing.T) {
+	bar := tago.Bar{Open: 10.5, High: 11.3, Low: 9.7, Close: 10.65}
+
+	recognizer, _ := NewRecognizer(3)
+	for _, b := range warmup {
+		recognizer.Next(b)
+	}
+	assert.Equal(t, []Match{{Pattern: Doji, Strength: 100}}, recognizer.Next(bar),
+		"must see a doji with a body of 0.075 times the average range")
+
+	recognizer, _ = NewRecognizer(3, WithDojiBody(0.05))
+	for _, b := range warmup {
+		recognizer.Next(b)
+	}
+	assert.Empty(t, recognizer.Next(bar), "must not see a doji with a body above the threshold")
+}
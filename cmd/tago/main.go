@@ -0,0 +1,206 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+/*
+Tago computes indicators over a series of prices or OHLCV bars without writing Go.
+
+Usage:
+
+	tago [flags] indicator...
+
+It reads a CSV with a header row from the file named by -i, or standard input, and writes its columns followed by
+one column per indicator, named by the indicator's String method, as CSV or JSON lines. JSON lines are keyed by
+the column names, so an indicator given twice or named like an input column is rejected. A list of bare prices
+without a header is read as a single price column.
+
+Indicators are given by their String names, such as MA(20), EMA(20,0.1), SD(20), Median(9), ExpandingMean,
+HV(20,252), ATR(14) or TimedMA(5m). Indicators of a single value read the column named by -column, by default
+close, c, price or value, which is also the close of the bars. Indicators of bars such as ATR read the open, high
+and low columns and the volume column if present, and timed indicators such as TimedMA read the time, date,
+timestamp or datetime column.
+
+Example:
+
+	tago -i prices.csv -format jsonl "MA(20)" "SD(20)"
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/binhnguyenduc/tago/ingest"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// options holds the flags of the command
+type options struct {
+	input      string
+	format     string
+	column     string
+	timeLayout string
+	precision  int
+}
+
+// run runs the command with args and returns its exit code: 0 on success, 1 if the input
+// could not be processed and 2 if the command line is invalid
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts options
+	flags := flag.NewFlagSet("tago", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.input, "i", "-", "input CSV `file`, - for standard input")
+	flags.StringVar(&opts.format, "format", "csv", "output `format`, csv or jsonl")
+	flags.StringVar(&opts.column, "column", "", "input `column` of the indicators of a single value and of the close of bars (default close, c, price or value)")
+	flags.StringVar(&opts.timeLayout, "time-layout", "", "`layout` of the time column (default RFC 3339, date and time, date or Unix seconds)")
+	flags.IntVar(&opts.precision, "precision", -1, "significant `digits` of the indicator values, -1 for the fewest that round-trip")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: tago [flags] indicator...\n\nIndicators: %s\n\nFlags:\n", strings.Join(names(), ", "))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	var indicators []any
+	for _, spec := range flags.Args() {
+		indicator, err := parseSpec(spec)
+		if err != nil {
+			fmt.Fprintf(stderr, "tago: %v\n", err)
+			return 2
+		}
+		indicators = append(indicators, indicator)
+	}
+
+	var w writer
+	switch opts.format {
+	case "csv":
+		w = newCSVWriter(stdout)
+	case "jsonl":
+		w = newJSONWriter(stdout)
+	default:
+		fmt.Fprintf(stderr, "tago: unknown format %q, expected csv or jsonl\n", opts.format)
+		return 2
+	}
+
+	in := stdin
+	if opts.input != "-" {
+		f, err := os.Open(opts.input)
+		if err != nil {
+			fmt.Fprintf(stderr, "tago: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if err := process(in, w, indicators, opts); err != nil {
+		fmt.Fprintf(stderr, "tago: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// process feeds every row of in through the indicators and writes the rows and the results to w
+func process(in io.Reader, w writer, indicators []any, opts options) error {
+	needs := requirements(indicators)
+	config := ingest.CSVConfig{Header: ingest.HeaderDetect}
+	if opts.column != "" {
+		config.Columns.Close = opts.column
+	}
+	if needs.bar {
+		config.Columns.Open, config.Columns.High, config.Columns.Low = "open", "high", "low"
+	}
+	if opts.timeLayout != "" {
+		config.TimeLayouts = []string{opts.timeLayout}
+	}
+	r := ingest.NewCSVReader(in, config)
+
+	bar, err := r.Read()
+	if err == io.EOF {
+		return errors.New("empty input")
+	}
+	if err != nil {
+		return err
+	}
+
+	header := r.Header()
+	if header == nil {
+		if len(r.Record()) != 1 {
+			return errors.New("line 1: missing header")
+		}
+		header = []string{"price"}
+	}
+	if needs.time && r.Columns().Time == "" {
+		return errors.New("missing column time, date, timestamp, datetime")
+	}
+
+	names := append([]string(nil), header...)
+	for _, indicator := range indicators {
+		names = append(names, indicator.(fmt.Stringer).String())
+	}
+	if err := w.header(names); err != nil {
+		return err
+	}
+
+	values := make([]float64, len(indicators))
+	for ; err != io.EOF; bar, err = r.Read() {
+		if err != nil {
+			return err
+		}
+
+		for i, indicator := range indicators {
+			values[i] = next(indicator, bar)
+		}
+		if err := w.write(r.Record(), values, opts.precision); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// next feeds the input of indicator from bar, whose close is the value of the indicators of a single value,
+// and returns its next value
+func next(indicator any, bar tago.Bar) float64 {
+	switch indicator := indicator.(type) {
+	case tago.Indicator:
+		return indicator.Next(bar.Close)
+	case tago.BarIndicator:
+		return indicator.Next(bar)
+	case tago.TimedIndicator:
+		return indicator.Next(tago.TimedValue{Time: bar.Time, Value: bar.Close})
+	}
+	panic(fmt.Sprintf("tago: unsupported indicator %T", indicator))
+}
+
+// needs tells which inputs the indicators read besides the value
+type needs struct {
+	bar, time bool
+}
+
+// requirements returns the inputs read by the indicators
+func requirements(indicators []any) needs {
+	var n needs
+	for _, indicator := range indicators {
+		switch indicator.(type) {
+		case tago.BarIndicator:
+			n.bar = true
+		case tago.TimedIndicator:
+			n.time = true
+		}
+	}
+	return n
+}
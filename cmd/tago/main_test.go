@@ -0,0 +1,103 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestRunGolden(t *testing.T) {
+	tests := map[string]struct {
+		args  []string
+		stdin string
+	}{
+		"prices": {
+			args: []string{"-i", "testdata/prices.txt", "-precision", "10", "MA(3)", "SD(3)", "Median(3)", "EMA(3)", "ExpandingMean"},
+		},
+		"bars": {
+			args: []string{"-i", "testdata/bars.csv", "-precision", "10", "MA(3)", "ATR(3)", "Parkinson(3)", "TimedMA(2m)", "LogReturn(1)"},
+		},
+		"bars_jsonl": {
+			args: []string{"-i", "testdata/bars.csv", "-format", "jsonl", "-precision", "10", "Max(4)", "Min(4)", "YangZhang(3)"},
+		},
+		"column": {
+			args: []string{"-i", "testdata/bars.csv", "-column", "volume", "-precision", "10", "Sum(3)", "ZScore(4)"},
+		},
+		"stdin_jsonl": {
+			args:  []string{"-format", "jsonl", "-precision", "10", "Diff(1)", "Lag(2)"},
+			stdin: "day,price\n2020-01-02,10\n2020-01-03,10.5\n2020-01-06,11\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+			assert.Equal(t, 0, code, stderr.String())
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				assert.NoError(t, os.WriteFile(golden, stdout.Bytes(), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(want), stdout.String())
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		stdin    string
+		wantCode int
+		wantErr  string
+	}{
+		"no indicator":        {args: nil, wantCode: 2, wantErr: "Usage: tago"},
+		"unknown indicator":   {args: []string{"Foo(3)"}, wantCode: 2, wantErr: `unknown indicator "Foo"`},
+		"invalid spec":        {args: []string{"MA 3"}, wantCode: 2, wantErr: `invalid indicator "MA 3"`},
+		"invalid periods":     {args: []string{"MA(x)"}, wantCode: 2, wantErr: `invalid number of periods "x"`},
+		"invalid arguments":   {args: []string{"MA(3,4)"}, wantCode: 2, wantErr: "expected 1 arguments, got 2"},
+		"invalid parameters":  {args: []string{"MA(0)"}, wantCode: 2, wantErr: `invalid indicator "MA(0)"`},
+		"unknown format":      {args: []string{"-format", "xml", "MA(3)"}, wantCode: 2, wantErr: `unknown format "xml"`},
+		"missing file":        {args: []string{"-i", "testdata/missing.csv", "MA(3)"}, wantCode: 1, wantErr: "missing.csv"},
+		"empty input":         {args: []string{"MA(3)"}, wantCode: 1, wantErr: "empty input"},
+		"missing header":      {args: []string{"MA(3)"}, stdin: "1,2\n3,4\n", wantCode: 1, wantErr: "line 1: missing header"},
+		"missing column":      {args: []string{"-i", "testdata/prices.txt", "ATR(3)"}, wantCode: 1, wantErr: "missing column open"},
+		"malformed row":       {args: []string{"-i", "testdata/malformed.csv", "MA(2)"}, wantCode: 1, wantErr: `line 4: column close: invalid number "n/a"`},
+		"malformed time":      {args: []string{"TimedMA(1h)"}, stdin: "time,price\nyesterday,1\n", wantCode: 1, wantErr: `line 2: column time: invalid time "yesterday"`},
+		"missing time":        {args: []string{"TimedMA(1h)"}, stdin: "day,price\n2020-01-02,1\n", wantCode: 1, wantErr: "missing column time, date, timestamp, datetime"},
+		"missing value":       {args: []string{"-column", "adj", "MA(3)"}, stdin: "date,close\n2020-01-02,1\n", wantCode: 1, wantErr: "missing column adj"},
+		"duplicate indicator": {args: []string{"-format", "jsonl", "MA(3)", "MA(3)"}, stdin: "price\n1\n", wantCode: 1, wantErr: `duplicate column "MA(3)" in JSON output`},
+		"duplicate column":    {args: []string{"-format", "jsonl", "TimedMA(5m)"}, stdin: "time,price,TimedMA(5m0s)\n2020-01-02,1,1\n", wantCode: 1, wantErr: `duplicate column "TimedMA(5m0s)" in JSON output`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+			assert.Equal(t, tc.wantCode, code)
+			assert.Contains(t, stderr.String(), tc.wantErr)
+		})
+	}
+}
+
+func TestParseSpecNames(t *testing.T) {
+	for _, spec := range []string{"MA(20)", "EMA(20)", "EMA(20,0.1)", "Median(9)", "HV(20,252)", "TimedMA(5m0s)", "ExpandingSD", "YangZhang(20)"} {
+		indicator, err := parseSpec(spec)
+		assert.NoError(t, err)
+		assert.Equal(t, spec, indicator.(interface{ String() string }).String(), "must match the String name")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// writer writes the input records and the values of the indicators
+type writer interface {
+	header(names []string) error
+	write(record []string, values []float64, precision int) error
+	flush() error
+}
+
+// formatValue formats v with the given number of significant digits
+func formatValue(v float64, precision int) string {
+	return strconv.FormatFloat(v, 'g', precision, 64)
+}
+
+// csvWriter writes CSV, with NaN for the values that are not a number
+type csvWriter struct {
+	w      *csv.Writer
+	fields []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) header(names []string) error {
+	return w.w.Write(names)
+}
+
+func (w *csvWriter) write(record []string, values []float64, precision int) error {
+	w.fields = append(w.fields[:0], record...)
+	for _, v := range values {
+		w.fields = append(w.fields, formatValue(v, precision))
+	}
+	return w.w.Write(w.fields)
+}
+
+func (w *csvWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// jsonWriter writes a JSON object per line keyed by the column names, which must be unique. The input fields that are numbers
+// are written as numbers and the others as strings, and the values that are not finite as null.
+type jsonWriter struct {
+	w    *bufio.Writer
+	keys []string
+	line []byte
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (w *jsonWriter) header(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("duplicate column %q in JSON output", name)
+		}
+		seen[name] = true
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		w.keys = append(w.keys, string(key))
+	}
+	return nil
+}
+
+func (w *jsonWriter) write(record []string, values []float64, precision int) error {
+	w.line = append(w.line[:0], '{')
+	for i, field := range record {
+		w.key(i)
+		field = strings.TrimSpace(field)
+		if v, err := strconv.ParseFloat(field, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			w.line = strconv.AppendFloat(w.line, v, 'g', -1, 64)
+			continue
+		}
+		s, err := json.Marshal(field)
+		if err != nil {
+			return err
+		}
+		w.line = append(w.line, s...)
+	}
+	for i, v := range values {
+		w.key(len(record) + i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			w.line = append(w.line, "null"...)
+			continue
+		}
+		w.line = strconv.AppendFloat(w.line, v, 'g', precision, 64)
+	}
+	w.line = append(w.line, '}', '\n')
+
+	_, err := w.w.Write(w.line)
+	return err
+}
+
+// key appends the key of column i
+func (w *jsonWriter) key(i int) {
+	if i > 0 {
+		w.line = append(w.line, ',')
+	}
+	w.line = append(w.line, w.keys[i]...)
+	w.line = append(w.line, ':')
+}
+
+func (w *jsonWriter) flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+// factory creates an indicator from the arguments of a spec, one of tago.Indicator, tago.BarIndicator
+// or tago.TimedIndicator
+type factory func(args []string) (any, error)
+
+// factories maps the names used by the String methods of the indicators to their constructors
+var factories = map[string]factory{
+	"MA":          periods(tago.NewMovingAverage),
+	"Mean":        periods(tago.NewMean),
+	"SD":          periods(tago.NewStandardDeviation),
+	"Median":      periods(tago.NewMedian),
+	"Max":         periods(tago.NewMaximum),
+	"Min":         periods(tago.NewMinimum),
+	"Mode":        periods(tago.NewRollingMode),
+	"Rank":        periods(tago.NewRollingRank),
+	"PercentRank": periods(tago.NewPercentRank),
+	"Sum":         periods(tago.NewRollingSum),
+	"Product":     periods(tago.NewRollingProduct),
+	"Skew":        periods(tago.NewSkewness),
+	"Kurt":        periods(tago.NewKurtosis),
+	"ZScore":      periods(tago.NewZScore),
+	"IQR":         periods(tago.NewInterquartileRange),
+	"MAD":         periods(tago.NewMedianAbsoluteDeviation),
+	"Lag":         periods(tago.NewLag),
+	"Diff":        periods(tago.NewDifference),
+	"MOM":         periods(tago.NewMomentum),
+	"ROC":         periods(tago.NewRateOfChange),
+	"PctChange":   periods(tago.NewPercentChange),
+	"LogReturn":   periods(tago.NewLogReturn),
+	"LinReg":      periods(tago.NewLinearRegression),
+
+	"EMA":  exponential(tago.NewExponentialMovingAverage),
+	"ESD":  exponential(tago.NewExponentialStandardDeviation),
+	"EVar": exponential(tago.NewExponentialVariance),
+
+	"ExpandingMean": expanding(tago.NewExpandingMean),
+	"ExpandingSD":   expanding(tago.NewExpandingStandardDeviation),
+	"ExpandingMax":  expanding(tago.NewExpandingMaximum),
+	"ExpandingMin":  expanding(tago.NewExpandingMinimum),
+
+	"HV": historicalVolatility,
+
+	"ATR":            periods(tago.NewAverageTrueRange),
+	"Parkinson":      periods(tago.NewParkinson),
+	"GarmanKlass":    periods(tago.NewGarmanKlass),
+	"RogersSatchell": periods(tago.NewRogersSatchell),
+	"YangZhang":      periods(tago.NewYangZhang),
+
+	"TimedMA":     duration(tago.NewTimedMovingAverage),
+	"TimedSD":     duration(tago.NewTimedStandardDeviation),
+	"TimedMedian": duration(tago.NewTimedMedian),
+	"TimedMax":    duration(tago.NewTimedMaximum),
+	"TimedMin":    duration(tago.NewTimedMinimum),
+}
+
+// specPattern matches a spec such as MA(20), EMA(20,0.1) or ExpandingMean
+var specPattern = regexp.MustCompile(`^(\w+)(?:\((.*)\))?$`)
+
+// parseSpec creates the indicator named by spec
+func parseSpec(spec string) (any, error) {
+	match := specPattern.FindStringSubmatch(strings.TrimSpace(spec))
+	if match == nil {
+		return nil, fmt.Errorf("invalid indicator %q", spec)
+	}
+
+	create, ok := factories[match[1]]
+	if !ok {
+		return nil, fmt.Errorf("unknown indicator %q, expected one of %s", match[1], strings.Join(names(), ", "))
+	}
+
+	var args []string
+	if match[2] != "" {
+		args = strings.Split(match[2], ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+	}
+
+	indicator, err := create(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid indicator %q: %w", spec, err)
+	}
+	return indicator, nil
+}
+
+// names returns the sorted names of the indicators
+func names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// arity returns an error unless there are between min and max args
+func arity(args []string, min, max int) error {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return fmt.Errorf("expected %d arguments, got %d", min, len(args))
+		}
+		return fmt.Errorf("expected %d to %d arguments, got %d", min, max, len(args))
+	}
+	return nil
+}
+
+// periods adapts the constructor of an indicator taking a number of periods
+func periods[I any](create func(n int) (I, error)) factory {
+	return func(args []string) (any, error) {
+		if err := arity(args, 1, 1); err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of periods %q", args[0])
+		}
+
+		indicator, err := create(n)
+		if err != nil {
+			return nil, err
+		}
+		return indicator, nil
+	}
+}
+
+// exponential adapts the constructor of an exponentially weighted indicator taking a number of periods
+// and an optional smoothing factor
+func exponential[I any](create func(n int, options ...tago.ExponentialOption) (I, error)) factory {
+	return func(args []string) (any, error) {
+		if err := arity(args, 1, 2); err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of periods %q", args[0])
+		}
+
+		var options []tago.ExponentialOption
+		if len(args) == 2 {
+			alpha, err := strconv.ParseFloat(args[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid alpha %q", args[1])
+			}
+			options = append(options, tago.WithAlpha(alpha))
+		}
+
+		indicator, err := create(n, options...)
+		if err != nil {
+			return nil, err
+		}
+		return indicator, nil
+	}
+}
+
+// expanding adapts the constructor of an indicator without parameters
+func expanding[I any](create func() I) factory {
+	return func(args []string) (any, error) {
+		if err := arity(args, 0, 0); err != nil {
+			return nil, err
+		}
+		return create(), nil
+	}
+}
+
+// duration adapts the constructor of an indicator taking a duration, such as 5m
+func duration[I any](create func(d time.Duration) (I, error)) factory {
+	return func(args []string) (any, error) {
+		if err := arity(args, 1, 1); err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", args[0])
+		}
+
+		indicator, err := create(d)
+		if err != nil {
+			return nil, err
+		}
+		return indicator, nil
+	}
+}
+
+// historicalVolatility creates a HistoricalVolatility from a number of periods and periods per year
+func historicalVolatility(args []string) (any, error) {
+	if err := arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of periods %q", args[0])
+	}
+	periodsPerYear, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid periods per year %q", args[1])
+	}
+
+	indicator, err := tago.NewHistoricalVolatility(n, periodsPerYear)
+	if err != nil {
+		return nil, err
+	}
+	return indicator, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+// coMoments keeps the means of two series and the sums of the products of their deviations
+// from the means, updated online as pairs enter and leave a window
+type coMoments[T Float] struct {
+	count int
+
+	meanX T
+	meanY T
+	cxx   T
+	cyy   T
+	cxy   T
+}
+
+// add includes the pair (x, y) in the moments
+func (cm *coMoments[T]) add(x, y T) {
+	cm.count++
+	n := T(cm.count)
+
+	dx := x - cm.meanX
+	dy := y - cm.meanY
+	cm.meanX += dx / n
+	cm.meanY += dy / n
+
+	cm.cxx += dx * (x - cm.meanX)
+	cm.cyy += dy * (y - cm.meanY)
+	cm.cxy += dx * (y - cm.meanY)
+}
+
+// remove excludes the pair (x, y), which must have been added before, by inverting add
+func (cm *coMoments[T]) remove(x, y T) {
+	if cm.count <= 1 {
+		*cm = coMoments[T]{}
+		return
+	}
+
+	n := T(cm.count)
+	cm.count--
+	n1 := T(cm.count)
+
+	meanX := (n*cm.meanX - x) / n1
+	meanY := (n*cm.meanY - y) / n1
+	dx := x - meanX
+	dy := y - meanY
+
+	cm.cxx -= dx * (x - cm.meanX)
+	cm.cyy -= dy * (y - cm.meanY)
+	cm.cxy -= dx * (y - cm.meanY)
+
+	cm.meanX = meanX
+	cm.meanY = meanY
+}
+
+// covariance returns the population covariance of the pairs
+func (cm *coMoments[T]) covariance() T {
+	if cm.count == 0 {
+		return 0
+	}
+	return cm.cxy / T(cm.count)
+}
+
+// correlation returns the Pearson correlation of the pairs, or 0 if either series is constant
+func (cm *coMoments[T]) correlation() T {
+	if cm.cxx <= 0 || cm.cyy <= 0 {
+		return 0
+	}
+	r := cm.cxy / T(math.Sqrt(float64(cm.cxx*cm.cyy)))
+
+	// keep rounding errors from leaving the valid range
+	if r > 1 {
+		return 1
+	}
+	if r < -1 {
+		return -1
+	}
+	return r
+}
+
+// rSquared returns the coefficient of determination of the pairs
+func (cm *coMoments[T]) rSquared() T {
+	r := cm.correlation()
+	return r * r
+}
+
+// beta returns the slope of the least squares regression of y on x, or 0 if x is constant
+func (cm *coMoments[T]) beta() T {
+	if cm.cxx <= 0 {
+		return 0
+	}
+	return cm.cxy / cm.cxx
+}
+
+// windowCoMoments returns the co-moments of the count most recent pairs of the ring buffers xs and ys,
+// the last at index, taking (lastX, lastY) as the pair at index, computed in float64 with two passes
+func windowCoMoments[T Float](xs, ys []T, index, count int, lastX, lastY T) coMoments[T] {
+	if count == 0 {
+		return coMoments[T]{}
+	}
+
+	sumX, sumY := 0., 0.
+	for k := 0; k < count; k++ {
+		sumX += float64(windowValue(xs, index, k, lastX))
+		sumY += float64(windowValue(ys, index, k, lastY))
+	}
+	meanX, meanY := sumX/float64(count), sumY/float64(count)
+
+	cxx, cyy, cxy := 0., 0., 0.
+	for k := 0; k < count; k++ {
+		dx := float64(windowValue(xs, index, k, lastX)) - meanX
+		dy := float64(windowValue(ys, index, k, lastY)) - meanY
+		cxx += dx * dx
+		cyy += dy * dy
+		cxy += dx * dy
+	}
+	return coMoments[T]{count: count, meanX: T(meanX), meanY: T(meanY), cxx: T(cxx), cyy: T(cyy), cxy: T(cxy)}
+}
+
+// pairWindow keeps the co-moments of the last n pairs
+type pairWindow[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index   int
+	moments coMoments[T]
+	// number of updates since moments were last recomputed from xs and ys
+	updates int
+
+	// slices of data needed for calculation
+	xs []T
+	ys []T
+}
+
+func newPairWindow[T Float](n int) pairWindow[T] {
+	return pairWindow[T]{
+		n: n,
+
+		index: 0,
+
+		xs: make([]T, n),
+		ys: make([]T, n),
+	}
+}
+
+// push adds the pair (x, y) to the window, dropping the oldest pair once the window is full
+func (w *pairWindow[T]) push(x, y T) {
+	w.index = (w.index + 1) % w.n
+	if w.moments.count == w.n {
+		w.moments.remove(w.xs[w.index], w.ys[w.index])
+	}
+	w.xs[w.index], w.ys[w.index] = x, y
+	w.moments.add(x, y)
+	w.resync()
+}
+
+// peek returns the co-moments push would produce for (x, y) without changing the window
+func (w *pairWindow[T]) peek(x, y T) coMoments[T] {
+	index := (w.index + 1) % w.n
+	moments := w.moments
+	if moments.count == w.n {
+		moments.remove(w.xs[index], w.ys[index])
+	}
+	moments.add(x, y)
+	if w.updates+1 >= w.n {
+		return windowCoMoments(w.xs, w.ys, index, moments.count, x, y)
+	}
+	return moments
+}
+
+// replaceLast replaces the most recent pair with (x, y), pushing it if the window is empty
+func (w *pairWindow[T]) replaceLast(x, y T) {
+	if w.moments.count == 0 {
+		w.push(x, y)
+		return
+	}
+	w.moments.remove(w.xs[w.index], w.ys[w.index])
+	w.xs[w.index], w.ys[w.index] = x, y
+	w.moments.add(x, y)
+	w.resync()
+}
+
+// resync recomputes the moments from xs and ys once every n updates, so that the rounding errors of add
+// and remove do not accumulate over long streams
+func (w *pairWindow[T]) resync() {
+	w.updates++
+	if w.updates >= w.n {
+		w.moments = windowCoMoments(w.xs, w.ys, w.index, w.moments.count, w.xs[w.index], w.ys[w.index])
+		w.updates = 0
+	}
+}
+
+func (w *pairWindow[T]) reset() {
+	w.index = 0
+	w.moments = coMoments[T]{}
+	w.updates = 0
+	w.xs = make([]T, w.n)
+	w.ys = make([]T, w.n)
+}
+
+func (w pairWindow[T]) clone() pairWindow[T] {
+	w.xs = cloneSlice(w.xs)
+	w.ys = cloneSlice(w.ys)
+	return w
+}
+
+// computePairs feeds the pairs of xs and ys through next and writes the results to out,
+// see the Compute methods of the pair indicators
+func computePairs[T Float](next func(x, y T) T, xs, ys, out []T) []T {
+	n := len(xs)
+	if len(ys) < n {
+		n = len(ys)
+	}
+
+	out = resize(out, n)
+	for i := 0; i < n; i++ {
+		out[i] = next(xs[i], ys[i])
+	}
+	return out
+}
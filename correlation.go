@@ -0,0 +1,114 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+PearsonCorrelationOf returns the Pearson correlation coefficient of the last n pairs of values
+of two series, updated in constant time as pairs enter and leave the window.
+
+# Formula
+
+ρ(x, y) = cov(x, y) / (σ<sub>x</sub> σ<sub>y</sub>)
+
+Where:
+
+* _cov(x, y)_ - covariance of the two series, see Covariance
+* _σ<sub>x</sub>_, _σ<sub>y</sub>_ - standard deviations of the two series, see StandardDeviation
+
+The correlation is 0 while either series is constant over the window.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+corr, _ := NewPearsonCorrelation(20)
+corr.Next(asset, benchmark)
+```
+*/
+type PearsonCorrelationOf[T Float] struct {
+	pairWindow[T]
+}
+
+// PearsonCorrelation is a PearsonCorrelationOf float64 values
+type PearsonCorrelation = PearsonCorrelationOf[float64]
+
+// NewPearsonCorrelation creates a new PearsonCorrelation with the given number of periods
+// Example: NewPearsonCorrelation(20)
+func NewPearsonCorrelation(n int) (*PearsonCorrelation, error) {
+	return NewPearsonCorrelationOf[float64](n)
+}
+
+// NewPearsonCorrelationOf creates a new PearsonCorrelationOf values of type T with the given number of periods
+// Example: NewPearsonCorrelationOf[float32](20)
+func NewPearsonCorrelationOf[T Float](n int) (*PearsonCorrelationOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &PearsonCorrelationOf[T]{
+		pairWindow: newPairWindow[T](n),
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next PearsonCorrelation value
+func (corr *PearsonCorrelationOf[T]) Next(x, y T) T {
+	corr.push(x, y)
+	return corr.moments.correlation()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (corr *PearsonCorrelationOf[T]) Peek(x, y T) T {
+	moments := corr.peek(x, y)
+	return moments.correlation()
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated PearsonCorrelation value.
+// If no pair has been committed yet it behaves like Next.
+func (corr *PearsonCorrelationOf[T]) UpdateLast(x, y T) T {
+	corr.replaceLast(x, y)
+	return corr.moments.correlation()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (corr *PearsonCorrelationOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(corr.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (corr *PearsonCorrelationOf[T]) Reset() {
+	corr.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (corr *PearsonCorrelationOf[T]) Clone() *PearsonCorrelationOf[T] {
+	return &PearsonCorrelationOf[T]{
+		pairWindow: corr.clone(),
+	}
+}
+
+func (corr *PearsonCorrelationOf[T]) String() string {
+	return fmt.Sprintf("Corr(%d)", corr.n)
+}
+
+// ComputePearsonCorrelation computes a PearsonCorrelation with n periods over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputePearsonCorrelation[T Float](n int, xs, ys, out []T, fillNaN bool) ([]T, error) {
+	corr, err := NewPearsonCorrelationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = corr.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
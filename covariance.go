@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+CovarianceOf returns the covariance of the last n pairs of values of two series,
+updated in constant time as pairs enter and leave the window.
+
+# Formula
+
+cov(x, y) = Σ(x<sub>i</sub> - x̄)(y<sub>i</sub> - ȳ) / N
+
+Where:
+
+* _x̄_, _ȳ_ - means of the two series over the window
+* _N_ - number of pairs in observation.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+cov, _ := NewCovariance(20)
+cov.Next(asset, benchmark)
+```
+*/
+type CovarianceOf[T Float] struct {
+	pairWindow[T]
+}
+
+// Covariance is a CovarianceOf float64 values
+type Covariance = CovarianceOf[float64]
+
+// NewCovariance creates a new Covariance with the given number of periods
+// Example: NewCovariance(20)
+func NewCovariance(n int) (*Covariance, error) {
+	return NewCovarianceOf[float64](n)
+}
+
+// NewCovarianceOf creates a new CovarianceOf values of type T with the given number of periods
+// Example: NewCovarianceOf[float32](20)
+func NewCovarianceOf[T Float](n int) (*CovarianceOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &CovarianceOf[T]{
+		pairWindow: newPairWindow[T](n),
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next Covariance value
+func (cov *CovarianceOf[T]) Next(x, y T) T {
+	cov.push(x, y)
+	return cov.moments.covariance()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (cov *CovarianceOf[T]) Peek(x, y T) T {
+	moments := cov.peek(x, y)
+	return moments.covariance()
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated Covariance value.
+// If no pair has been committed yet it behaves like Next.
+func (cov *CovarianceOf[T]) UpdateLast(x, y T) T {
+	cov.replaceLast(x, y)
+	return cov.moments.covariance()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (cov *CovarianceOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(cov.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (cov *CovarianceOf[T]) Reset() {
+	cov.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (cov *CovarianceOf[T]) Clone() *CovarianceOf[T] {
+	return &CovarianceOf[T]{
+		pairWindow: cov.clone(),
+	}
+}
+
+func (cov *CovarianceOf[T]) String() string {
+	return fmt.Sprintf("Cov(%d)", cov.n)
+}
+
+// ComputeCovariance computes a Covariance with n periods over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeCovariance[T Float](n int, xs, ys, out []T, fillNaN bool) ([]T, error) {
+	cov, err := NewCovarianceOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = cov.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
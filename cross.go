@@ -0,0 +1,239 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+CrossOf reports when a series crosses another one, such as a fast moving average crossing a slow one,
+or crosses a level, such as an oscillator leaving its oversold level.
+
+# Formula
+
+The series _x_ is above _y_ when x - y > h and below it when x - y < -h, otherwise it stays on
+its previous side. CrossAbove is reported when _x_ moves from below _y_ to above it, and CrossBelow
+when it moves the other way. The side of the first input is not reported.
+
+Where:
+
+* _h_ - hysteresis band, see WithHysteresis
+
+# Parameters
+
+* _options_ - optional SignalOption values: WithHysteresis, WithMinBars and WithConfirmation
+
+# Example
+```
+cross, _ := NewCross(WithConfirmation(2))
+cross.Next(fast.Next(10.), slow.Next(10.))
+```
+*/
+type CrossOf[T Float] struct {
+	config signalConfig
+
+	// internal parameters for calculations
+	filter signalFilter
+	count  int
+
+	// filter before the most recent input, used to replace it
+	previous signalFilter
+}
+
+// Cross is a CrossOf float64 values
+type Cross = CrossOf[float64]
+
+// NewCross creates a new Cross with the given options
+// Example: NewCross(WithHysteresis(0.5))
+func NewCross(options ...SignalOption) (*Cross, error) {
+	return NewCrossOf[float64](options...)
+}
+
+// NewCrossOf creates a new CrossOf values of type T with the given options
+// Example: NewCrossOf[float32](WithHysteresis(0.5))
+func NewCrossOf[T Float](options ...SignalOption) (*CrossOf[T], error) {
+	config, err := newSignalConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrossOf[T]{
+		config: config,
+
+		filter: newSignalFilter(config, 0),
+	}, nil
+}
+
+// Next takes the next values of the two series and returns the Signal of x crossing y
+func (c *CrossOf[T]) Next(x, y T) Signal {
+	c.previous = c.filter
+	c.count++
+	return crossSignal(c.filter.next(c.side(x, y)))
+}
+
+// Peek returns the value Next would return for x and y without committing them
+func (c *CrossOf[T]) Peek(x, y T) Signal {
+	filter := c.filter
+	return crossSignal(filter.next(c.side(x, y)))
+}
+
+// UpdateLast replaces the most recent values with x and y and returns the updated Signal.
+// If no values have been committed yet it behaves like Next.
+func (c *CrossOf[T]) UpdateLast(x, y T) Signal {
+	if c.count == 0 {
+		return c.Next(x, y)
+	}
+
+	c.filter = c.previous
+	return crossSignal(c.filter.next(c.side(x, y)))
+}
+
+// side returns 1 if x is above y by more than the hysteresis band, -1 if it is below by more, or 0
+func (c *CrossOf[T]) side(x, y T) int {
+	band := T(c.config.band)
+	switch diff := x - y; {
+	case diff > band:
+		return 1
+	case diff < -band:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter of xs and ys.
+func (c *CrossOf[T]) Compute(xs, ys []T, out []Signal) []Signal {
+	n := len(xs)
+	if len(ys) < n {
+		n = len(ys)
+	}
+
+	out = resize(out, n)
+	for i := 0; i < n; i++ {
+		out[i] = c.Next(xs[i], ys[i])
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (c *CrossOf[T]) Reset() {
+	c.filter = newSignalFilter(c.config, 0)
+	c.previous = signalFilter{}
+	c.count = 0
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (c *CrossOf[T]) Clone() *CrossOf[T] {
+	clone := *c
+	return &clone
+}
+
+func (c *CrossOf[T]) String() string {
+	return c.config.name("Cross")
+}
+
+// ComputeCross computes a Cross with the given options over the pairs of xs and ys and writes
+// the results to out (see Compute)
+func ComputeCross[T Float](xs, ys []T, out []Signal, options ...SignalOption) ([]Signal, error) {
+	c, err := NewCrossOf[T](options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Compute(xs, ys, out), nil
+}
+
+// crossSignal returns the Signal of a change to side
+func crossSignal(side int) Signal {
+	switch side {
+	case 1:
+		return CrossAbove
+	case -1:
+		return CrossBelow
+	default:
+		return NoSignal
+	}
+}
+
+/*
+CrossOverOf reports when a fast indicator crosses a slow one fed with the same inputs, such as
+a MovingAverage crossing an ExponentialMovingAverage. It feeds each input through both indicators
+and their values through a Cross.
+
+# Parameters
+
+* _fast_ - indicator expected to cross _slow_
+* _slow_ - indicator crossed by _fast_
+* _options_ - optional SignalOption values: WithHysteresis, WithMinBars and WithConfirmation
+
+# Example
+```
+fast, _ := NewMovingAverage(10)
+slow, _ := NewExponentialMovingAverage(20)
+crossover, _ := NewCrossOver(fast, slow)
+crossover.Next(10.)
+```
+*/
+type CrossOverOf[T Float] struct {
+	fast IndicatorOf[T]
+	slow IndicatorOf[T]
+
+	// internal parameters for calculations
+	cross *CrossOf[T]
+}
+
+// CrossOver is a CrossOverOf float64 values
+type CrossOver = CrossOverOf[float64]
+
+// NewCrossOver creates a new CrossOver of the given indicators with the given options
+// Example: NewCrossOver(fast, slow)
+func NewCrossOver(fast, slow Indicator, options ...SignalOption) (*CrossOver, error) {
+	return NewCrossOverOf[float64](fast, slow, options...)
+}
+
+// NewCrossOverOf creates a new CrossOverOf values of type T of the given indicators with the given options
+// Example: NewCrossOverOf[float32](fast, slow)
+func NewCrossOverOf[T Float](fast, slow IndicatorOf[T], options ...SignalOption) (*CrossOverOf[T], error) {
+	if fast == nil || slow == nil {
+		return nil, ErrInvalidParameters
+	}
+
+	cross, err := NewCrossOf[T](options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrossOverOf[T]{
+		fast: fast,
+		slow: slow,
+
+		cross: cross,
+	}, nil
+}
+
+// Next takes the next input and returns the Signal of the fast indicator crossing the slow one
+func (co *CrossOverOf[T]) Next(input T) Signal {
+	return co.cross.Next(co.fast.Next(input), co.slow.Next(input))
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (co *CrossOverOf[T]) Compute(inputs []T, out []Signal) []Signal {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = co.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state, including the fast and slow indicators
+func (co *CrossOverOf[T]) Reset() {
+	co.fast.Reset()
+	co.slow.Reset()
+	co.cross.Reset()
+}
+
+func (co *CrossOverOf[T]) String() string {
+	return co.cross.config.name("CrossOver", co.fast.String(), co.slow.String())
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCross(t *testing.T) {
+	tests := map[string]struct {
+		options []SignalOption
+		want    *Cross
+		wantErr error
+	}{
+		"invalid option": {options: []SignalOption{WithConfirmation(0)}, want: nil, wantErr: ErrInvalidParameters},
+		"no options": {options: nil, want: &Cross{
+			config: signalConfig{confirmation: 1},
+			filter: signalFilter{config: signalConfig{confirmation: 1}},
+		}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewCross(tc.options...)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestCrossNext(t *testing.T) {
+	tests := map[string]struct {
+		options []SignalOption
+		xs      []float64
+		want    []Signal
+	}{
+		"touching the level": {
+			xs:   []float64{1., 2., 3., 2., 1., 2., 3.},
+			want: []Signal{NoSignal, NoSignal, CrossAbove, NoSignal, CrossBelow, NoSignal, CrossAbove},
+		},
+		"without hysteresis": {
+			xs:   []float64{1., 2.3, 1.8, 2.4, 1.7, 2.6},
+			want: []Signal{NoSignal, CrossAbove, CrossBelow, CrossAbove, CrossBelow, CrossAbove},
+		},
+		"with hysteresis": {
+			options: []SignalOption{WithHysteresis(0.5)},
+			xs:      []float64{1., 2.3, 1.8, 2.4, 1.7, 2.6},
+			want:    []Signal{NoSignal, NoSignal, NoSignal, NoSignal, NoSignal, CrossAbove},
+		},
+		"with min bars": {
+			options: []SignalOption{WithMinBars(3)},
+			xs:      []float64{1., 3., 1., 3., 1., 3., 3., 1.},
+			want:    []Signal{NoSignal, CrossAbove, NoSignal, NoSignal, CrossBelow, NoSignal, NoSignal, CrossBelow},
+		},
+		"with confirmation": {
+			options: []SignalOption{WithConfirmation(2)},
+			xs:      []float64{1., 3., 3., 1., 3., 1., 1., 1.},
+			want:    []Signal{NoSignal, NoSignal, CrossAbove, NoSignal, NoSignal, NoSignal, CrossBelow, NoSignal},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cross, _ := NewCross(tc.options...)
+			got := make([]Signal, len(tc.xs))
+			for i, x := range tc.xs {
+				got[i] = cross.Next(x, 2.)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCrossPeek(t *testing.T) {
+	cross, _ := NewCross()
+	xs := []float64{1., 2., 3., 2., 1., 2., 3.}
+	for _, x := range xs {
+		cross.Peek(-x, 2.) // must not be committed
+		got := cross.Peek(x, 2.)
+		assert.Equal(t, got, cross.Next(x, 2.), "must return the same value as Next")
+	}
+}
+
+func TestCrossUpdateLast(t *testing.T) {
+	cross, _ := NewCross()
+	assert.Equal(t, NoSignal, cross.UpdateLast(1., 2.), "must behave like Next when empty")
+	assert.Equal(t, CrossAbove, cross.Next(3., 2.))
+	assert.Equal(t, NoSignal, cross.UpdateLast(1., 2.), "must undo the cross")
+	assert.Equal(t, CrossAbove, cross.UpdateLast(3., 2.), "must redo the cross")
+	assert.Equal(t, CrossBelow, cross.Next(1., 2.))
+}
+
+func TestCrossCompute(t *testing.T) {
+	xs := []float64{1., 2., 3., 2., 1., 2., 3., 4.}
+	ys := []float64{2., 2., 2., 2., 2., 2., 2.}
+
+	stream, _ := NewCross()
+	want := make([]Signal, len(ys))
+	for i := range ys {
+		want[i] = stream.Next(xs[i], ys[i])
+	}
+
+	batch, _ := NewCross()
+	out := make([]Signal, len(xs))
+	got := batch.Compute(xs, ys, out)
+	assert.Equal(t, want, got, "must return the same values as Next, up to the shorter series")
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+
+	_, err := ComputeCross(xs, ys, nil, WithMinBars(-1))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	got, err = ComputeCross(xs, ys, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestCrossClone(t *testing.T) {
+	cross, _ := NewCross()
+	cross.Compute([]float64{1., 3.}, []float64{2., 2.}, nil)
+	want, _ := NewCross()
+	want.Compute([]float64{1., 3.}, []float64{2., 2.}, nil)
+
+	clone := cross.Clone()
+	assert.Equal(t, CrossBelow, clone.Next(1., 2.), "clone must continue from the same state")
+	assert.Equal(t, want, cross, "original must not see the clone's inputs")
+}
+
+func TestCrossReset(t *testing.T) {
+	cross, _ := NewCross(WithMinBars(2))
+	cross.Compute([]float64{1., 3., 1.}, []float64{2., 2., 2.}, nil)
+	cross.Reset()
+
+	want, _ := NewCross(WithMinBars(2))
+	assert.Equal(t, want, cross, "must return to a clean state")
+}
+
+func TestCrossString(t *testing.T) {
+	cross, _ := NewCross()
+	assert.Equal(t, "Cross", cross.String())
+	cross, _ = NewCross(WithHysteresis(0.5), WithConfirmation(2))
+	assert.Equal(t, "Cross(0.5,0,2)", cross.String())
+}
+
+func TestNewCrossOver(t *testing.T) {
+	ma, _ := NewMovingAverage(3)
+	_, err := NewCrossOver(nil, ma)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	_, err = NewCrossOver(ma, nil)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	_, err = NewCrossOver(ma, ma, WithConfirmation(0))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+}
+
+func TestCrossOverNext(t *testing.T) {
+	fast, _ := NewMovingAverage(1)
+	slow, _ := NewMovingAverage(3)
+	crossover, _ := NewCrossOver(fast, slow)
+	want := []Signal{NoSignal, NoSignal, NoSignal, CrossBelow, NoSignal, CrossAbove, NoSignal, NoSignal}
+	got := crossover.Compute([]float64{1., 2., 3., 2., 1., 2., 3., 4.}, nil)
+	assert.Equal(t, want, got)
+}
+
+func TestCrossOverReset(t *testing.T) {
+	fast, _ := NewMovingAverage(10)
+	slow, _ := NewExponentialMovingAverage(20)
+	crossover, _ := NewCrossOver(fast, slow)
+	crossover.Compute([]float64{1., 2., 3., 2., 1., 2., 3., 4.}, nil)
+	crossover.Reset()
+
+	wantFast, _ := NewMovingAverage(10)
+	wantSlow, _ := NewExponentialMovingAverage(20)
+	want, _ := NewCrossOver(wantFast, wantSlow)
+	assert.Equal(t, want, crossover, "must reset the indicators it crosses")
+}
+
+func TestCrossOverString(t *testing.T) {
+	fast, _ := NewMovingAverage(10)
+	slow, _ := NewExponentialMovingAverage(20)
+	crossover, _ := NewCrossOver(fast, slow, WithMinBars(5))
+	assert.Equal(t, "CrossOver(MA(10),EMA(20),0,5,1)", crossover.String())
+}
@@ -0,0 +1,517 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the largest number of fractional digits a Decimal can hold
+const MaxDecimalScale = 18
+
+// RoundingMode selects how digits are dropped when a Decimal result has more precision than its scale
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, ties to the even neighbour (banker's rounding)
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, ties away from zero
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest value, ties towards zero
+	RoundHalfDown
+	// RoundDown truncates towards zero
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+)
+
+// valid reports whether m is one of the rounding modes above
+func (m RoundingMode) valid() bool {
+	return m >= RoundHalfEven && m <= RoundCeiling
+}
+
+// pow10 holds the powers of ten that fit in an int64
+var pow10 = [MaxDecimalScale + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+/*
+Decimal is a fixed-point number stored as an int64 number of units of 10^-scale.
+Arithmetic on decimals of the same scale is exact; whenever digits have to be dropped
+the caller chooses the RoundingMode, and results that do not fit in an int64 are
+reported with ErrDecimalOverflow instead of wrapping around. A RoundingMode outside RoundHalfEven
+to RoundCeiling is rejected with ErrInvalidParameters.
+
+# Example
+```
+price, _ := ParseDecimal("101.25")  // 10125 units with scale 2
+qty, _ := NewDecimal(3, 0)
+notional, _ := price.Mul(qty, 2, RoundHalfEven) // 303.75
+```
+*/
+type Decimal struct {
+	units int64
+	scale int
+}
+
+// NewDecimal creates a new Decimal of units * 10^-scale
+// Example: NewDecimal(10125, 2) is 101.25
+func NewDecimal(units int64, scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrInvalidParameters
+	}
+
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// ParseDecimal parses a decimal string such as "-101.25", keeping all of its fractional digits
+func ParseDecimal(s string) (Decimal, error) {
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(s) - i - 1
+	}
+	if scale > MaxDecimalScale {
+		return Decimal{}, ErrInvalidParameters
+	}
+	return parseDecimal(s, scale, RoundDown)
+}
+
+// DecimalFromFloat converts f to a Decimal with the given scale, rounding its shortest decimal representation with mode
+func DecimalFromFloat(f float64, scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() || math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, ErrInvalidParameters
+	}
+	return parseDecimal(strconv.FormatFloat(f, 'f', -1, 64), scale, mode)
+}
+
+// parseDecimal parses s into a Decimal of the given scale, rounding extra fractional digits with mode
+func parseDecimal(s string, scale int, mode RoundingMode) (Decimal, error) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, ErrInvalidParameters
+	}
+
+	// digits kept at the target scale, and the dropped ones deciding the rounding
+	kept, dropped := fracPart, ""
+	if len(kept) > scale {
+		kept, dropped = fracPart[:scale], fracPart[scale:]
+	} else {
+		kept += strings.Repeat("0", scale-len(kept))
+	}
+
+	var units uint64
+	for _, c := range intPart + kept {
+		if c < '0' || c > '9' {
+			return Decimal{}, ErrInvalidParameters
+		}
+		hi, lo := bits.Mul64(units, 10)
+		lo, carry := bits.Add64(lo, uint64(c-'0'), 0)
+		if hi != 0 || carry != 0 {
+			return Decimal{}, ErrDecimalOverflow
+		}
+		units = lo
+	}
+
+	exact, half := true, -1
+	for i, c := range dropped {
+		if c < '0' || c > '9' {
+			return Decimal{}, ErrInvalidParameters
+		}
+		if c == '0' {
+			continue
+		}
+		exact = false
+		if i == 0 {
+			switch {
+			case c > '5':
+				half = 1
+			case c == '5':
+				half = 0
+			}
+		} else if half == 0 {
+			// a non-zero digit after a leading 5 puts the remainder above half
+			half = 1
+		}
+	}
+
+	value, err := finish(units, neg, exact, half, mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{units: value, scale: scale}, nil
+}
+
+// Units returns the unscaled integer value of d
+func (d Decimal) Units() int64 {
+	return d.units
+}
+
+// Scale returns the number of fractional digits of d
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// IsZero reports whether d is zero
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// Rescale returns d with the given number of fractional digits, rounding with mode if digits are dropped
+func (d Decimal) Rescale(scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return Decimal{}, ErrInvalidParameters
+	}
+
+	var units int64
+	var err error
+	if scale >= d.scale {
+		units, err = mulDiv(d.units, pow10[scale-d.scale], 1, mode)
+	} else {
+		units, err = mulDiv(d.units, 1, pow10[d.scale-scale], mode)
+	}
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// Add returns d + e exactly, at the larger scale of the two
+func (d Decimal) Add(e Decimal) (Decimal, error) {
+	d, e, err := align(d, e)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	units, ok := add64(d.units, e.units)
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units, scale: d.scale}, nil
+}
+
+// Sub returns d - e exactly, at the larger scale of the two
+func (d Decimal) Sub(e Decimal) (Decimal, error) {
+	if e.units == math.MinInt64 {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return d.Add(Decimal{units: -e.units, scale: e.scale})
+}
+
+// Mul returns d * e at the given scale, rounding with mode
+func (d Decimal) Mul(e Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return Decimal{}, ErrInvalidParameters
+	}
+
+	// the exact product has d.scale + e.scale fractional digits
+	neg := (d.units < 0) != (e.units < 0)
+	hi, lo := bits.Mul64(abs64(d.units), abs64(e.units))
+
+	var units int64
+	var err error
+	shift := d.scale + e.scale - scale
+	switch {
+	case shift < 0:
+		hi, lo, err = mul128(hi, lo, uint64(pow10[-shift]))
+		if err == nil {
+			units, err = divRound(neg, hi, lo, 1, mode)
+		}
+	case shift <= MaxDecimalScale:
+		units, err = divRound(neg, hi, lo, uint64(pow10[shift]), mode)
+	default:
+		units, err = divRoundPow10(neg, hi, lo, shift, mode)
+	}
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// Div returns d / e at the given scale, rounding with mode
+func (d Decimal) Div(e Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return Decimal{}, ErrInvalidParameters
+	}
+	if e.units == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	// the quotient is d.units * 10^shift / e.units
+	neg := (d.units < 0) != (e.units < 0)
+	shift := scale + e.scale - d.scale
+
+	var units int64
+	var err error
+	if shift >= 0 {
+		hi, lo := uint64(0), abs64(d.units)
+		for shift > 0 && err == nil {
+			step := shift
+			if step > MaxDecimalScale {
+				step = MaxDecimalScale
+			}
+			hi, lo, err = mul128(hi, lo, uint64(pow10[step]))
+			shift -= step
+		}
+		if err == nil {
+			units, err = divRound(neg, hi, lo, abs64(e.units), mode)
+		}
+	} else {
+		// scale the divisor instead; if it no longer fits in 64 bits the quotient is below half a unit
+		dhi, dlo := bits.Mul64(abs64(e.units), uint64(pow10[-shift]))
+		if dhi == 0 {
+			units, err = divRound(neg, 0, abs64(d.units), dlo, mode)
+		} else {
+			units, err = finish(0, neg, d.units == 0, -1, mode)
+		}
+	}
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// Cmp compares d and e and returns -1, 0 or 1
+func (d Decimal) Cmp(e Decimal) int {
+	if ds, es := d.Sign(), e.Sign(); ds != es {
+		if ds < es {
+			return -1
+		}
+		return 1
+	}
+
+	// compare the magnitudes at the larger scale using 128 bit products
+	scale := d.scale
+	if e.scale > scale {
+		scale = e.scale
+	}
+	dhi, dlo := bits.Mul64(abs64(d.units), uint64(pow10[scale-d.scale]))
+	ehi, elo := bits.Mul64(abs64(e.units), uint64(pow10[scale-e.scale]))
+
+	c := 0
+	switch {
+	case dhi < ehi || (dhi == ehi && dlo < elo):
+		c = -1
+	case dhi > ehi || (dhi == ehi && dlo > elo):
+		c = 1
+	}
+	if d.units < 0 {
+		return -c
+	}
+	return c
+}
+
+// Float64 returns the nearest float64 to d; it is meant for display, not further exact arithmetic
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	digits := strconv.FormatUint(abs64(d.units), 10)
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.units < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// align rescales d and e to the larger of their scales
+func align(d, e Decimal) (Decimal, Decimal, error) {
+	var err error
+	switch {
+	case d.scale < e.scale:
+		d, err = d.Rescale(e.scale, RoundDown)
+	case e.scale < d.scale:
+		e, err = e.Rescale(d.scale, RoundDown)
+	}
+	return d, e, err
+}
+
+// mulDiv returns a * b / c rounded with mode, using a 128 bit intermediate product
+func mulDiv(a, b, c int64, mode RoundingMode) (int64, error) {
+	if c == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	neg := (a < 0) != (b < 0) != (c < 0)
+	hi, lo := bits.Mul64(abs64(a), abs64(b))
+	return divRound(neg, hi, lo, abs64(c), mode)
+}
+
+// divRound divides the 128 bit magnitude (hi, lo) by d, then applies the rounding mode and the sign
+func divRound(neg bool, hi, lo, d uint64, mode RoundingMode) (int64, error) {
+	if hi >= d {
+		return 0, ErrDecimalOverflow
+	}
+
+	q, r := bits.Div64(hi, lo, d)
+	return finish(q, neg, r == 0, compareHalf(r, d), mode)
+}
+
+// divRoundPow10 divides the 128 bit magnitude (hi, lo) by 10^shift for shifts larger than MaxDecimalScale
+func divRoundPow10(neg bool, hi, lo uint64, shift int, mode RoundingMode) (int64, error) {
+	// divide by 10^18 first, then by the remaining power of ten
+	d1 := uint64(pow10[MaxDecimalScale])
+	qhi, r1 := hi/d1, hi%d1
+	qlo, r1 := bits.Div64(r1, lo, d1)
+
+	d2 := uint64(pow10[shift-MaxDecimalScale])
+	if qhi >= d2 {
+		return 0, ErrDecimalOverflow
+	}
+	q, r2 := bits.Div64(qhi, qlo, d2)
+
+	// d2 is even, so the total remainder is exactly half only if r2 is and nothing is left in r1
+	half := compareHalf(r2, d2)
+	if half == 0 && r1 != 0 {
+		half = 1
+	}
+	return finish(q, neg, r1 == 0 && r2 == 0, half, mode)
+}
+
+// finish rounds the magnitude q with mode and applies the sign, reporting overflows
+func finish(q uint64, neg, exact bool, half int, mode RoundingMode) (int64, error) {
+	q, ok := roundUnits(q, neg, exact, half, mode)
+	if !ok {
+		return 0, ErrDecimalOverflow
+	}
+	result, ok := signed(q, neg)
+	if !ok {
+		return 0, ErrDecimalOverflow
+	}
+	return result, nil
+}
+
+// mul128 multiplies the 128 bit magnitude (hi, lo) by m
+func mul128(hi, lo, m uint64) (uint64, uint64, error) {
+	carry, lo := bits.Mul64(lo, m)
+	h1, h0 := bits.Mul64(hi, m)
+	hi, c := bits.Add64(h0, carry, 0)
+	if h1 != 0 || c != 0 {
+		return 0, 0, ErrDecimalOverflow
+	}
+	return hi, lo, nil
+}
+
+// compareHalf compares the remainder r with half of the divisor d: -1 below, 0 tie, 1 above
+func compareHalf(r, d uint64) int {
+	switch {
+	case r < d-r:
+		return -1
+	case r > d-r:
+		return 1
+	}
+	return 0
+}
+
+// roundUnits applies mode to the truncated magnitude q, given whether the division was exact
+// and how the remainder compares with half a unit (-1 below, 0 tie, 1 above)
+func roundUnits(q uint64, neg, exact bool, half int, mode RoundingMode) (uint64, bool) {
+	if exact {
+		return q, true
+	}
+
+	up := false
+	switch mode {
+	case RoundHalfEven:
+		up = half > 0 || (half == 0 && q%2 == 1)
+	case RoundHalfUp:
+		up = half >= 0
+	case RoundHalfDown:
+		up = half > 0
+	case RoundDown:
+		up = false
+	case RoundUp:
+		up = true
+	case RoundFloor:
+		up = neg
+	case RoundCeiling:
+		up = !neg
+	}
+
+	if !up {
+		return q, true
+	}
+	if q == math.MaxUint64 {
+		return 0, false
+	}
+	return q + 1, true
+}
+
+// signed applies the sign to the magnitude u, reporting whether the result fits in an int64
+func signed(u uint64, neg bool) (int64, bool) {
+	if neg {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return int64(-u), true
+	}
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+// add64 returns a + b, reporting whether the sum fits in an int64
+func add64(a, b int64) (int64, bool) {
+	sum := a + b
+	if (sum > a) != (b > 0) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// sub64 returns a - b, reporting whether the difference fits in an int64
+func sub64(a, b int64) (int64, bool) {
+	diff := a - b
+	if (diff < a) != (b > 0) {
+		return 0, false
+	}
+	return diff, true
+}
+
+func abs64(x int64) uint64 {
+	if x < 0 {
+		return uint64(^x) + 1
+	}
+	return uint64(x)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+DecimalMean returns the arithmetic mean of the last n Decimal values, see Mean.
+
+Inputs are rounded to the indicator's scale when they enter the window and their sum is kept exactly,
+so the division by the number of periods is the only other place where rounding happens.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _scale_ - number of fractional digits of the inputs and results (0 to MaxDecimalScale)
+* _mode_ - rounding mode used whenever digits have to be dropped
+
+# Example
+```
+m, _ := NewDecimalMean(3, 2, RoundHalfEven)
+price, _ := ParseDecimal("101.25")
+mean, err := m.Next(price)
+```
+*/
+type DecimalMean struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// precision of the inputs and results
+	scale int
+	mode  RoundingMode
+
+	// internal parameters for calculations
+	index int
+	count int
+
+	sum int64
+
+	// units of the values in the window
+	data []int64
+}
+
+// NewDecimalMean creates a new DecimalMean with the given number of periods, scale and rounding mode
+// Example: NewDecimalMean(9, 2, RoundHalfEven)
+func NewDecimalMean(n, scale int, mode RoundingMode) (*DecimalMean, error) {
+	if n <= 0 || scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return nil, ErrInvalidParameters
+	}
+
+	return &DecimalMean{
+		n: n,
+
+		scale: scale,
+		mode:  mode,
+
+		index: 0,
+		count: 0,
+
+		sum: 0,
+
+		data: make([]int64, n),
+	}, nil
+}
+
+// Next takes the next input and returns the next DecimalMean value.
+// If the input or the sum of the window cannot be represented, the indicator is left unchanged and an error is returned.
+func (m *DecimalMean) Next(input Decimal) (Decimal, error) {
+	value, err := input.Rescale(m.scale, m.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	index := (m.index + 1) % m.n
+	sum, ok := sub64(m.sum, m.data[index])
+	if ok {
+		sum, ok = add64(sum, value.units)
+	}
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+
+	count := m.count
+	if count < m.n {
+		count++
+	}
+	mean, err := mulDiv(sum, 1, int64(count), m.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	m.index = index
+	m.count = count
+	m.sum = sum
+	m.data[index] = value.units
+	return Decimal{units: mean, scale: m.scale}, nil
+}
+
+// Sum returns the exact sum of the values in the window
+func (m *DecimalMean) Sum() Decimal {
+	return Decimal{units: m.sum, scale: m.scale}
+}
+
+// Reset resets the indicators to a clean state
+func (m *DecimalMean) Reset() {
+	m.index = 0
+	m.count = 0
+
+	m.sum = 0
+
+	m.data = make([]int64, m.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *DecimalMean) Clone() *DecimalMean {
+	clone := *m
+	clone.data = cloneSlice(m.data)
+	return &clone
+}
+
+func (m *DecimalMean) String() string {
+	return fmt.Sprintf("DecimalMean(%d)", m.n)
+}
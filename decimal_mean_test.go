@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimalMean(t *testing.T) {
+	tests := map[string]struct {
+		n       int
+		scale   int
+		mode    RoundingMode
+		want    *DecimalMean
+		wantErr error
+	}{
+		"zero n":                {n: 0, scale: 2, mode: RoundHalfUp, want: nil, wantErr: ErrInvalidParameters},
+		"negative scale":        {n: 9, scale: -1, mode: RoundHalfUp, want: nil, wantErr: ErrInvalidParameters},
+		"invalid rounding mode": {n: 9, scale: 2, mode: RoundingMode(42), want: nil, wantErr: ErrInvalidParameters},
+		"valid":                 {n: 9, scale: 2, mode: RoundHalfUp, want: &DecimalMean{n: 9, scale: 2, mode: RoundHalfUp, data: make([]int64, 9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDecimalMean(tc.n, tc.scale, tc.mode)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalMeanNext(t *testing.T) {
+	m, _ := NewDecimalMean(3, 2, RoundHalfEven)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "1", want: "1.00"},
+		{input: "2", want: "1.50"},
+		{input: "2.01", want: "1.67"},
+		{input: "3.005", want: "2.34"}, // input rounded to 3.00
+		{input: "0.1", want: "1.70"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := m.Next(mustParseDecimal(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must return the correct value")
+		})
+	}
+	assert.Equal(t, "5.11", m.Sum().String(), "must keep the exact sum of the window")
+}
+
+func TestDecimalMeanOverflow(t *testing.T) {
+	m, _ := NewDecimalMean(2, 0, RoundHalfEven)
+	_, err := m.Next(Decimal{units: math.MaxInt64})
+	assert.NoError(t, err)
+
+	_, err = m.Next(Decimal{units: 1})
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report the overflow")
+	assert.Equal(t, Decimal{units: math.MaxInt64}, m.Sum(), "must leave the indicator unchanged")
+}
+
+func TestDecimalMeanReset(t *testing.T) {
+	m, _ := NewDecimalMean(3, 2, RoundHalfEven)
+	m.Next(mustParseDecimal("1"))
+	m.Next(mustParseDecimal("2"))
+
+	m.Reset()
+	got, err := m.Next(mustParseDecimal("5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "5.00", got.String(), "must start from a clean state")
+}
+
+func TestDecimalMeanClone(t *testing.T) {
+	m, _ := NewDecimalMean(2, 2, RoundHalfEven)
+	m.Next(mustParseDecimal("1"))
+
+	clone := m.Clone()
+	got, _ := clone.Next(mustParseDecimal("3"))
+	assert.Equal(t, "2.00", got.String(), "clone must continue from the same state")
+
+	got, _ = m.Next(mustParseDecimal("5"))
+	assert.Equal(t, "3.00", got.String(), "original must not see the clone's inputs")
+}
+
+func TestDecimalMeanString(t *testing.T) {
+	m, _ := NewDecimalMean(4, 2, RoundHalfEven)
+	assert.Equal(t, "DecimalMean(4)", m.String())
+}
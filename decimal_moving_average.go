@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+DecimalMovingAverage returns the average in _n_ number of periods of Decimal values, see MovingAverage.
+
+Inputs are rounded to the indicator's scale when they enter the window and their sum is kept exactly,
+so the division by the number of periods is the only other place where rounding happens.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _scale_ - number of fractional digits of the inputs and results (0 to MaxDecimalScale)
+* _mode_ - rounding mode used whenever digits have to be dropped
+
+# Example
+```
+ma, _ := NewDecimalMovingAverage(3, 2, RoundHalfEven)
+price, _ := ParseDecimal("101.25")
+avg, err := ma.Next(price)
+```
+*/
+type DecimalMovingAverage struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// precision of the inputs and results
+	scale int
+	mode  RoundingMode
+
+	// internal parameters for calculations
+	index int
+	count int
+
+	sum int64
+
+	// units of the values in the window
+	data []int64
+}
+
+// NewDecimalMovingAverage creates a new DecimalMovingAverage with the given number of periods, scale and rounding mode
+// Example: NewDecimalMovingAverage(9, 2, RoundHalfEven)
+func NewDecimalMovingAverage(n, scale int, mode RoundingMode) (*DecimalMovingAverage, error) {
+	if n <= 0 || scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return nil, ErrInvalidParameters
+	}
+
+	return &DecimalMovingAverage{
+		n: n,
+
+		scale: scale,
+		mode:  mode,
+
+		index: 0,
+		count: 0,
+
+		sum: 0,
+
+		data: make([]int64, n),
+	}, nil
+}
+
+// Next takes the next input and returns the next DecimalMovingAverage value.
+// If the input or the sum of the window cannot be represented, the indicator is left unchanged and an error is returned.
+func (ma *DecimalMovingAverage) Next(input Decimal) (Decimal, error) {
+	value, err := input.Rescale(ma.scale, ma.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	index := (ma.index + 1) % ma.n
+	sum, ok := sub64(ma.sum, ma.data[index])
+	if ok {
+		sum, ok = add64(sum, value.units)
+	}
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+
+	count := ma.count
+	if count < ma.n {
+		count++
+	}
+	avg, err := mulDiv(sum, 1, int64(count), ma.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	ma.index = index
+	ma.count = count
+	ma.sum = sum
+	ma.data[index] = value.units
+	return Decimal{units: avg, scale: ma.scale}, nil
+}
+
+// Sum returns the exact sum of the values in the window
+func (ma *DecimalMovingAverage) Sum() Decimal {
+	return Decimal{units: ma.sum, scale: ma.scale}
+}
+
+// Reset resets the indicators to a clean state
+func (ma *DecimalMovingAverage) Reset() {
+	ma.index = 0
+	ma.count = 0
+
+	ma.sum = 0
+
+	ma.data = make([]int64, ma.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (ma *DecimalMovingAverage) Clone() *DecimalMovingAverage {
+	clone := *ma
+	clone.data = cloneSlice(ma.data)
+	return &clone
+}
+
+func (ma *DecimalMovingAverage) String() string {
+	return fmt.Sprintf("DecimalMA(%d)", ma.n)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimalMovingAverage(t *testing.T) {
+	tests := map[string]struct {
+		n       int
+		scale   int
+		mode    RoundingMode
+		want    *DecimalMovingAverage
+		wantErr error
+	}{
+		"zero n":                {n: 0, scale: 2, mode: RoundHalfUp, want: nil, wantErr: ErrInvalidParameters},
+		"negative scale":        {n: 9, scale: -1, mode: RoundHalfUp, want: nil, wantErr: ErrInvalidParameters},
+		"invalid rounding mode": {n: 9, scale: 2, mode: RoundingMode(42), want: nil, wantErr: ErrInvalidParameters},
+		"valid":                 {n: 9, scale: 2, mode: RoundHalfUp, want: &DecimalMovingAverage{n: 9, scale: 2, mode: RoundHalfUp, data: make([]int64, 9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDecimalMovingAverage(tc.n, tc.scale, tc.mode)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalMovingAverageNext(t *testing.T) {
+	ma, _ := NewDecimalMovingAverage(3, 2, RoundHalfEven)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "1", want: "1.00"},
+		{input: "2", want: "1.50"},
+		{input: "2.01", want: "1.67"},
+		{input: "3.005", want: "2.34"}, // input rounded to 3.00
+		{input: "0.1", want: "1.70"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ma.Next(mustParseDecimal(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must return the correct value")
+		})
+	}
+	assert.Equal(t, "5.11", ma.Sum().String(), "must keep the exact sum of the window")
+}
+
+func TestDecimalMovingAverageOverflow(t *testing.T) {
+	ma, _ := NewDecimalMovingAverage(2, 0, RoundHalfEven)
+	_, err := ma.Next(Decimal{units: math.MaxInt64})
+	assert.NoError(t, err)
+
+	_, err = ma.Next(Decimal{units: 1})
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report the overflow")
+	assert.Equal(t, Decimal{units: math.MaxInt64}, ma.Sum(), "must leave the indicator unchanged")
+}
+
+func TestDecimalMovingAverageReset(t *testing.T) {
+	ma, _ := NewDecimalMovingAverage(3, 2, RoundHalfEven)
+	ma.Next(mustParseDecimal("1"))
+	ma.Next(mustParseDecimal("2"))
+
+	ma.Reset()
+	got, err := ma.Next(mustParseDecimal("5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "5.00", got.String(), "must start from a clean state")
+}
+
+func TestDecimalMovingAverageClone(t *testing.T) {
+	ma, _ := NewDecimalMovingAverage(2, 2, RoundHalfEven)
+	ma.Next(mustParseDecimal("1"))
+
+	clone := ma.Clone()
+	got, _ := clone.Next(mustParseDecimal("3"))
+	assert.Equal(t, "2.00", got.String(), "clone must continue from the same state")
+
+	got, _ = ma.Next(mustParseDecimal("5"))
+	assert.Equal(t, "3.00", got.String(), "original must not see the clone's inputs")
+}
+
+func TestDecimalMovingAverageString(t *testing.T) {
+	ma, _ := NewDecimalMovingAverage(4, 2, RoundHalfEven)
+	assert.Equal(t, "DecimalMA(4)", ma.String())
+}
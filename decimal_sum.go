@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+DecimalRunningSum returns the exact cumulative sum of all Decimal inputs since the last Reset.
+It is the building block for cumulative indicators such as OBV or the A/D line whose totals
+must reconcile with a ledger.
+
+# Parameters
+
+* _scale_ - number of fractional digits of the inputs and results (0 to MaxDecimalScale)
+* _mode_ - rounding mode used when inputs have more fractional digits than scale
+
+# Example
+```
+sum, _ := NewDecimalRunningSum(2, RoundHalfEven)
+flow, _ := ParseDecimal("-1500.25")
+total, err := sum.Next(flow)
+```
+*/
+type DecimalRunningSum struct {
+	// precision of the inputs and results
+	scale int
+	mode  RoundingMode
+
+	// internal parameters for calculations
+	sum int64
+}
+
+// NewDecimalRunningSum creates a new DecimalRunningSum with the given scale and rounding mode
+// Example: NewDecimalRunningSum(2, RoundHalfEven)
+func NewDecimalRunningSum(scale int, mode RoundingMode) (*DecimalRunningSum, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return nil, ErrInvalidParameters
+	}
+
+	return &DecimalRunningSum{
+		scale: scale,
+		mode:  mode,
+
+		sum: 0,
+	}, nil
+}
+
+// Next takes the next input and returns the next DecimalRunningSum value.
+// If the input or the sum cannot be represented, the indicator is left unchanged and an error is returned.
+func (s *DecimalRunningSum) Next(input Decimal) (Decimal, error) {
+	value, err := input.Rescale(s.scale, s.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	sum, ok := add64(s.sum, value.units)
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+
+	s.sum = sum
+	return Decimal{units: sum, scale: s.scale}, nil
+}
+
+// Reset resets the indicators to a clean state
+func (s *DecimalRunningSum) Reset() {
+	s.sum = 0
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (s *DecimalRunningSum) Clone() *DecimalRunningSum {
+	clone := *s
+	return &clone
+}
+
+func (s *DecimalRunningSum) String() string {
+	return "DecimalSum"
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimalRunningSum(t *testing.T) {
+	tests := map[string]struct {
+		scale   int
+		mode    RoundingMode
+		want    *DecimalRunningSum
+		wantErr error
+	}{
+		"negative scale":        {scale: -1, mode: RoundDown, want: nil, wantErr: ErrInvalidParameters},
+		"scale too big":         {scale: 19, mode: RoundDown, want: nil, wantErr: ErrInvalidParameters},
+		"invalid rounding mode": {scale: 2, mode: RoundingMode(42), want: nil, wantErr: ErrInvalidParameters},
+		"valid":                 {scale: 2, mode: RoundDown, want: &DecimalRunningSum{scale: 2, mode: RoundDown}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDecimalRunningSum(tc.scale, tc.mode)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalRunningSumNext(t *testing.T) {
+	sum, _ := NewDecimalRunningSum(2, RoundDown)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "0.1", want: "0.10"},
+		{input: "0.2", want: "0.30"},
+		{input: "-1500.259", want: "-1499.95"}, // input truncated to -1500.25
+		{input: "1499.95", want: "0.00"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := sum.Next(mustParseDecimal(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must return the exact sum")
+		})
+	}
+
+	sum.Next(Decimal{units: math.MaxInt64, scale: 2})
+	_, err := sum.Next(Decimal{units: 1, scale: 2})
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report the overflow")
+}
+
+func TestDecimalRunningSumReset(t *testing.T) {
+	sum, _ := NewDecimalRunningSum(2, RoundDown)
+	sum.Next(mustParseDecimal("10"))
+
+	sum.Reset()
+	got, _ := sum.Next(mustParseDecimal("1"))
+	assert.Equal(t, "1.00", got.String(), "must start from a clean state")
+}
+
+func TestDecimalRunningSumClone(t *testing.T) {
+	sum, _ := NewDecimalRunningSum(0, RoundDown)
+	sum.Next(mustParseDecimal("1"))
+
+	clone := sum.Clone()
+	got, _ := clone.Next(mustParseDecimal("5"))
+	assert.Equal(t, "6", got.String(), "clone must continue from the same state")
+
+	got, _ = sum.Next(mustParseDecimal("1"))
+	assert.Equal(t, "2", got.String(), "original must not see the clone's inputs")
+}
+
+func TestDecimalRunningSumString(t *testing.T) {
+	sum, _ := NewDecimalRunningSum(2, RoundDown)
+	assert.Equal(t, "DecimalSum", sum.String())
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestNewDecimal(t *testing.T) {
+	tests := map[string]struct {
+		units   int64
+		scale   int
+		want    Decimal
+		wantErr error
+	}{
+		"negative scale": {units: 1, scale: -1, want: Decimal{}, wantErr: ErrInvalidParameters},
+		"scale too big":  {units: 1, scale: 19, want: Decimal{}, wantErr: ErrInvalidParameters},
+		"valid":          {units: 10125, scale: 2, want: Decimal{units: 10125, scale: 2}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDecimal(tc.units, tc.scale)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Decimal
+		wantErr error
+	}{
+		"integer":         {input: "42", want: Decimal{units: 42, scale: 0}},
+		"fraction":        {input: "101.25", want: Decimal{units: 10125, scale: 2}},
+		"negative":        {input: "-0.005", want: Decimal{units: -5, scale: 3}},
+		"plus sign":       {input: "+1.50", want: Decimal{units: 150, scale: 2}},
+		"leading dot":     {input: ".5", want: Decimal{units: 5, scale: 1}},
+		"empty":           {input: "", wantErr: ErrInvalidParameters},
+		"letters":         {input: "1.2a", wantErr: ErrInvalidParameters},
+		"too many digits": {input: "0.0000000000000000001", wantErr: ErrInvalidParameters},
+		"overflow":        {input: "99999999999999999999", wantErr: ErrDecimalOverflow},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseDecimal(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		input Decimal
+		want  string
+	}{
+		{input: Decimal{units: 10125, scale: 2}, want: "101.25"},
+		{input: Decimal{units: -5, scale: 3}, want: "-0.005"},
+		{input: Decimal{units: 42, scale: 0}, want: "42"},
+		{input: Decimal{units: 0, scale: 2}, want: "0.00"},
+		{input: Decimal{units: math.MinInt64, scale: 18}, want: "-9.223372036854775808"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.String(), "must format the exact value")
+		})
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	tests := []struct {
+		input string
+		mode  RoundingMode
+		want  string
+	}{
+		{input: "2.345", mode: RoundHalfEven, want: "2.34"},
+		{input: "2.355", mode: RoundHalfEven, want: "2.36"},
+		{input: "2.345", mode: RoundHalfUp, want: "2.35"},
+		{input: "2.345", mode: RoundHalfDown, want: "2.34"},
+		{input: "2.346", mode: RoundHalfDown, want: "2.35"},
+		{input: "2.349", mode: RoundDown, want: "2.34"},
+		{input: "2.341", mode: RoundUp, want: "2.35"},
+		{input: "2.349", mode: RoundFloor, want: "2.34"},
+		{input: "2.341", mode: RoundCeiling, want: "2.35"},
+		{input: "-2.345", mode: RoundHalfEven, want: "-2.34"},
+		{input: "-2.345", mode: RoundHalfUp, want: "-2.35"},
+		{input: "-2.345", mode: RoundHalfDown, want: "-2.34"},
+		{input: "-2.349", mode: RoundDown, want: "-2.34"},
+		{input: "-2.341", mode: RoundUp, want: "-2.35"},
+		{input: "-2.341", mode: RoundFloor, want: "-2.35"},
+		{input: "-2.349", mode: RoundCeiling, want: "-2.34"},
+		{input: "2.340", mode: RoundUp, want: "2.34"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := mustParseDecimal(tc.input).Rescale(2, tc.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must round with the given mode")
+		})
+	}
+
+	up, err := mustParseDecimal("1.5").Rescale(4, RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Decimal{units: 15000, scale: 4}, up, "must add digits exactly")
+
+	_, err = mustParseDecimal("9223372036854775807").Rescale(1, RoundDown)
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report overflows")
+
+	_, err = mustParseDecimal("2.345").Rescale(2, RoundingMode(42))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must reject an invalid rounding mode")
+}
+
+func TestDecimalFromFloat(t *testing.T) {
+	tests := []struct {
+		input   float64
+		scale   int
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{input: 0.1, scale: 2, mode: RoundHalfEven, want: "0.10"},
+		{input: 101.255, scale: 2, mode: RoundHalfEven, want: "101.26"},
+		{input: -1e-30, scale: 4, mode: RoundFloor, want: "-0.0001"},
+		{input: 1e-30, scale: 4, mode: RoundHalfUp, want: "0.0000"},
+		{input: math.NaN(), scale: 2, wantErr: ErrInvalidParameters},
+		{input: math.Inf(1), scale: 2, wantErr: ErrInvalidParameters},
+		{input: 0.1, scale: 2, mode: RoundingMode(-1), wantErr: ErrInvalidParameters},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := DecimalFromFloat(tc.input, tc.scale, tc.mode)
+			if tc.wantErr != nil {
+				assert.EqualError(t, err, tc.wantErr.Error(), "must return the correct error")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := mustParseDecimal("101.25"), mustParseDecimal("0.125")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "101.375", sum.String(), "must add at the larger scale")
+
+	diff, err := b.Sub(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "-101.125", diff.String(), "must subtract at the larger scale")
+
+	product, err := a.Mul(b, 2, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.66", product.String(), "must round the product") // 12.65625
+
+	product, err = a.Mul(mustParseDecimal("3"), 4, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "303.7500", product.String(), "must extend the product")
+
+	tiny := Decimal{units: 5, scale: 18}
+	product, err = tiny.Mul(Decimal{units: 3, scale: 18}, 0, RoundUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", product.String(), "must round products with more than 18 dropped digits")
+
+	quotient, err := a.Div(mustParseDecimal("3"), 4, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "33.7500", quotient.String(), "must divide")
+
+	quotient, err = mustParseDecimal("1").Div(mustParseDecimal("3"), 18, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.333333333333333333", quotient.String(), "must divide with full precision")
+
+	quotient, err = mustParseDecimal("2.000000").Div(mustParseDecimal("3"), 2, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.67", quotient.String(), "must divide to a smaller scale")
+
+	_, err = a.Div(Decimal{}, 2, RoundHalfUp)
+	assert.EqualError(t, err, ErrDivisionByZero.Error(), "must reject division by zero")
+
+	_, err = Decimal{units: math.MaxInt64}.Add(Decimal{units: 1})
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report overflows")
+
+	_, err = Decimal{units: math.MaxInt64}.Mul(Decimal{units: 2}, 0, RoundDown)
+	assert.EqualError(t, err, ErrDecimalOverflow.Error(), "must report overflows")
+
+	_, err = a.Mul(b, 2, RoundingMode(42))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must reject an invalid rounding mode")
+
+	_, err = a.Div(b, 2, RoundingMode(42))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must reject an invalid rounding mode")
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.50", b: "1.5", want: 0},
+		{a: "1.49", b: "1.5", want: -1},
+		{a: "-1", b: "-1.01", want: 1},
+		{a: "-1", b: "0", want: -1},
+		{a: "9223372036854775807", b: "0.000000000000000001", want: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.want, mustParseDecimal(tc.a).Cmp(mustParseDecimal(tc.b)), "must compare the values")
+		})
+	}
+}
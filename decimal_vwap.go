@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+DecimalVWAP returns the volume weighted average price of all trades since the last Reset,
+computed on Decimal prices and volumes.
+
+# Formula
+
+VWAP = Σ (price * volume) / Σ volume
+
+Prices and volumes are rounded to the indicator's scale when they are added, each notional
+(price * volume) is rounded once to that scale, and both sums are then kept exactly so they
+can be reconciled with the trades booked in a ledger. Only the final division is rounded again.
+
+# Parameters
+
+* _scale_ - number of fractional digits of the inputs, sums and results (0 to MaxDecimalScale)
+* _mode_ - rounding mode used whenever digits have to be dropped
+
+# Example
+```
+vwap, _ := NewDecimalVWAP(4, RoundHalfEven)
+price, _ := ParseDecimal("101.25")
+volume, _ := ParseDecimal("300")
+value, err := vwap.Next(price, volume)
+```
+*/
+type DecimalVWAP struct {
+	// precision of the inputs, sums and results
+	scale int
+	mode  RoundingMode
+
+	// internal parameters for calculations
+	notional int64
+	volume   int64
+}
+
+// NewDecimalVWAP creates a new DecimalVWAP with the given scale and rounding mode
+// Example: NewDecimalVWAP(4, RoundHalfEven)
+func NewDecimalVWAP(scale int, mode RoundingMode) (*DecimalVWAP, error) {
+	if scale < 0 || scale > MaxDecimalScale || !mode.valid() {
+		return nil, ErrInvalidParameters
+	}
+
+	return &DecimalVWAP{
+		scale: scale,
+		mode:  mode,
+
+		notional: 0,
+		volume:   0,
+	}, nil
+}
+
+// Next takes the price and volume of the next trade and returns the next DecimalVWAP value.
+// The value is zero until some volume has traded. Negative volumes are rejected with ErrInvalidParameters;
+// on any error the indicator is left unchanged.
+func (v *DecimalVWAP) Next(price, volume Decimal) (Decimal, error) {
+	if volume.Sign() < 0 {
+		return Decimal{}, ErrInvalidParameters
+	}
+
+	p, err := price.Rescale(v.scale, v.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	q, err := volume.Rescale(v.scale, v.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	pv, err := p.Mul(q, v.scale, v.mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	notional, ok := add64(v.notional, pv.units)
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	total, ok := add64(v.volume, q.units)
+	if !ok {
+		return Decimal{}, ErrDecimalOverflow
+	}
+
+	vwap := Decimal{scale: v.scale}
+	if total != 0 {
+		vwap, err = Decimal{units: notional, scale: v.scale}.Div(Decimal{units: total, scale: v.scale}, v.scale, v.mode)
+		if err != nil {
+			return Decimal{}, err
+		}
+	}
+
+	v.notional = notional
+	v.volume = total
+	return vwap, nil
+}
+
+// Notional returns the exact sum of the rounded price * volume of all trades
+func (v *DecimalVWAP) Notional() Decimal {
+	return Decimal{units: v.notional, scale: v.scale}
+}
+
+// Volume returns the exact sum of the volume of all trades
+func (v *DecimalVWAP) Volume() Decimal {
+	return Decimal{units: v.volume, scale: v.scale}
+}
+
+// Reset resets the indicators to a clean state
+func (v *DecimalVWAP) Reset() {
+	v.notional = 0
+	v.volume = 0
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (v *DecimalVWAP) Clone() *DecimalVWAP {
+	clone := *v
+	return &clone
+}
+
+func (v *DecimalVWAP) String() string {
+	return "DecimalVWAP"
+}
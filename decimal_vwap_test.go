@@ -0,0 +1,89 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimalVWAP(t *testing.T) {
+	tests := map[string]struct {
+		scale   int
+		mode    RoundingMode
+		want    *DecimalVWAP
+		wantErr error
+	}{
+		"negative scale":        {scale: -1, mode: RoundHalfEven, want: nil, wantErr: ErrInvalidParameters},
+		"invalid rounding mode": {scale: 4, mode: RoundingMode(-1), want: nil, wantErr: ErrInvalidParameters},
+		"valid":                 {scale: 4, mode: RoundHalfEven, want: &DecimalVWAP{scale: 4, mode: RoundHalfEven}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDecimalVWAP(tc.scale, tc.mode)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestDecimalVWAPNext(t *testing.T) {
+	vwap, _ := NewDecimalVWAP(2, RoundHalfEven)
+	tests := []struct {
+		price  string
+		volume string
+		want   string
+	}{
+		{price: "10", volume: "0", want: "0.00"},
+		{price: "10", volume: "100", want: "10.00"},
+		{price: "11", volume: "300", want: "10.75"},
+		{price: "10.33", volume: "1.5", want: "10.75"}, // notional 15.495 rounded to 15.50
+		{price: "9", volume: "598.5", want: "9.70"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.price, func(t *testing.T) {
+			got, err := vwap.Next(mustParseDecimal(tc.price), mustParseDecimal(tc.volume))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String(), "must return the correct value")
+		})
+	}
+	assert.Equal(t, "9702.00", vwap.Notional().String(), "must keep the exact notional")
+	assert.Equal(t, "1000.00", vwap.Volume().String(), "must keep the exact volume")
+
+	_, err := vwap.Next(mustParseDecimal("10"), mustParseDecimal("-1"))
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must reject negative volumes")
+	assert.Equal(t, "1000.00", vwap.Volume().String(), "must leave the indicator unchanged")
+}
+
+func TestDecimalVWAPReset(t *testing.T) {
+	vwap, _ := NewDecimalVWAP(2, RoundHalfEven)
+	vwap.Next(mustParseDecimal("10"), mustParseDecimal("5"))
+
+	vwap.Reset()
+	got, _ := vwap.Next(mustParseDecimal("12"), mustParseDecimal("1"))
+	assert.Equal(t, "12.00", got.String(), "must start a new session")
+}
+
+func TestDecimalVWAPClone(t *testing.T) {
+	vwap, _ := NewDecimalVWAP(2, RoundHalfEven)
+	vwap.Next(mustParseDecimal("10"), mustParseDecimal("1"))
+
+	clone := vwap.Clone()
+	got, _ := clone.Next(mustParseDecimal("20"), mustParseDecimal("1"))
+	assert.Equal(t, "15.00", got.String(), "clone must continue from the same state")
+
+	got, _ = vwap.Next(mustParseDecimal("12"), mustParseDecimal("1"))
+	assert.Equal(t, "11.00", got.String(), "original must not see the clone's inputs")
+}
+
+func TestDecimalVWAPString(t *testing.T) {
+	vwap, _ := NewDecimalVWAP(2, RoundHalfEven)
+	assert.Equal(t, "DecimalVWAP", vwap.String())
+}
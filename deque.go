@@ -0,0 +1,80 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+// deque is a double-ended queue on a ring buffer that doubles its capacity when it is full,
+// so pushing and popping at either end take amortised constant time
+type deque[E any] struct {
+	data  []E
+	head  int
+	count int
+}
+
+func (q *deque[E]) len() int {
+	return q.count
+}
+
+// at returns the i-th element from the front
+func (q *deque[E]) at(i int) E {
+	return q.data[(q.head+i)%len(q.data)]
+}
+
+func (q *deque[E]) front() E {
+	return q.at(0)
+}
+
+func (q *deque[E]) back() E {
+	return q.at(q.count - 1)
+}
+
+func (q *deque[E]) pushBack(e E) {
+	if q.count == len(q.data) {
+		q.grow()
+	}
+	q.data[(q.head+q.count)%len(q.data)] = e
+	q.count++
+}
+
+func (q *deque[E]) popFront() E {
+	e := q.front()
+	var zero E
+	q.data[q.head] = zero
+	q.head = (q.head + 1) % len(q.data)
+	q.count--
+	return e
+}
+
+func (q *deque[E]) popBack() E {
+	e := q.back()
+	var zero E
+	q.data[(q.head+q.count-1)%len(q.data)] = zero
+	q.count--
+	return e
+}
+
+// grow doubles the capacity, moving the elements to the start of a new buffer
+func (q *deque[E]) grow() {
+	size := 2 * len(q.data)
+	if size == 0 {
+		size = 8
+	}
+
+	data := make([]E, size)
+	for i := 0; i < q.count; i++ {
+		data[i] = q.at(i)
+	}
+	q.data = data
+	q.head = 0
+}
+
+func (q *deque[E]) reset() {
+	*q = deque[E]{}
+}
+
+func (q deque[E]) clone() deque[E] {
+	q.data = cloneSlice(q.data)
+	return q
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+DifferenceOf returns the change of the input over _n_ periods. With _n_ = 1 it turns prices
+into price changes.
+
+# Formula
+
+Diff<sub>t</sub> = p<sub>t</sub> - p<sub>t-n</sub>
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the change since the first input is returned.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+d, _ := NewDifference(1)
+d.Next(10.)
+```
+*/
+type DifferenceOf[T Float] struct {
+	lookback[T]
+}
+
+// Difference is a DifferenceOf float64 values
+type Difference = DifferenceOf[float64]
+
+// NewDifference creates a new Difference with the given number of periods
+// Example: NewDifference(1)
+func NewDifference(n int) (*Difference, error) {
+	return NewDifferenceOf[float64](n)
+}
+
+// NewDifferenceOf creates a new DifferenceOf values of type T with the given number of periods
+// Example: NewDifferenceOf[float32](1)
+func NewDifferenceOf[T Float](n int) (*DifferenceOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &DifferenceOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next Difference value
+func (d *DifferenceOf[T]) Next(input T) T {
+	past := d.push(input)
+	return input - past
+}
+
+// Peek returns the value Next would return for input without committing it
+func (d *DifferenceOf[T]) Peek(input T) T {
+	past := d.peek(input)
+	return input - past
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Difference value.
+// If no input has been committed yet it behaves like Next.
+func (d *DifferenceOf[T]) UpdateLast(input T) T {
+	past := d.replaceLast(input)
+	return input - past
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (d *DifferenceOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = d.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (d *DifferenceOf[T]) Reset() {
+	d.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (d *DifferenceOf[T]) Clone() *DifferenceOf[T] {
+	return &DifferenceOf[T]{
+		lookback: d.clone(),
+	}
+}
+
+func (d *DifferenceOf[T]) String() string {
+	return fmt.Sprintf("Diff(%d)", d.n)
+}
+
+// ComputeDifference computes a Difference with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputeDifference[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	d, err := NewDifferenceOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = d.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDifference(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *Difference
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &Difference{lookback: newLookback[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewDifference(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestDifferenceNext(t *testing.T) {
+	sd, _ := NewDifference(2)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 6.},
+		{input: 1., want: -1.},
+		{input: 4., want: -4.},
+		{input: 1., want: 0.},
+		{input: 9., want: 5.},
+		{input: 9., want: 8.},
+		{input: 5., want: -4.},
+		{input: 3., want: -6.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeDifference(t *testing.T) {
+	inputs := []float64{2., 8., 1., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 2, fillNaN: false, want: []float64{0., 6., -1., -4., 0.}},
+		"fill NaN": {n: 2, fillNaN: true, want: []float64{math.NaN(), math.NaN(), -1., -4., 0.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeDifference(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDifferenceLookback(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"warm-up":        {n: 3, inputs: []float64{5., 6., 7.}, want: 2.},
+		"oldest evicted": {n: 3, inputs: []float64{5., 6., 7., 8., 10.}, want: 4.},
+		"unchanged":      {n: 1, inputs: []float64{5., 5.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewDifference(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDifferenceString(t *testing.T) {
+	sd, _ := NewDifference(2)
+	want := "Diff(2)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
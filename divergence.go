@@ -0,0 +1,321 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+DivergenceOf reports when price and an oscillator, such as RSI or a MACD histogram, disagree at
+consecutive swing highs or swing lows.
+
+# Formula
+
+A swing high is an input greater than or equal to the _n_ inputs on either side of it, the Maximum
+of the 2n+1 inputs around it, and a swing low is the Minimum of them. Swings of price and of the
+oscillator are both confirmed _n_ inputs after them. Each swing of price is matched to the nearest swing
+of the same kind of the oscillator at most _n_ inputs away, the earlier one if two are as near, or to
+the oscillator at the same input if there is none. Once matched, at most _n_ inputs after it is
+confirmed, a swing is compared with the previous swing of the same kind, if that one is at most
+_lookback_ inputs earlier:
+
+* BullishDivergence - lower low of price, higher low of the oscillator
+* HiddenBullishDivergence - higher low of price, lower low of the oscillator
+* BearishDivergence - higher high of price, lower high of the oscillator
+* HiddenBearishDivergence - lower high of price, higher high of the oscillator
+
+Pivots returns the indexes of the two swings of price, counted from 0 for the first input.
+
+# Parameters
+
+* _n_ - number of inputs on either side of a swing (integer greater than 0)
+* _lookback_ - maximum number of inputs between two compared swings (integer greater than 0)
+
+# Example
+```
+divergence, _ := NewDivergence(5, 60)
+if divergence.Next(price, oscillator) == BullishDivergence {
+from, to := divergence.Pivots()
+}
+```
+*/
+type DivergenceOf[T Float] struct {
+	// number of inputs on either side of a swing (must be an integer greater than 0)
+	n int
+	// maximum number of inputs between two compared swings (must be an integer greater than 0)
+	lookback int
+
+	// internal parameters for calculations
+	max         *MaximumOf[T]
+	min         *MinimumOf[T]
+	oscMax      *MaximumOf[T]
+	oscMin      *MinimumOf[T]
+	prices      lookback[T]
+	oscillators lookback[T]
+	count       int
+
+	state divergenceState[T]
+
+	// state before the most recent input, used to replace it
+	previous divergenceState[T]
+}
+
+// Divergence is a DivergenceOf float64 values
+type Divergence = DivergenceOf[float64]
+
+// swing is a swing high or low of price with the value of the oscillator matched to it
+type swing[T Float] struct {
+	index      int
+	price      T
+	oscillator T
+	ok         bool
+}
+
+// pivot is a swing high or low of the oscillator
+type pivot[T Float] struct {
+	index int
+	value T
+	ok    bool
+}
+
+// divergenceSide holds the swings of one kind, highs or lows, of price and of the oscillator
+type divergenceSide[T Float] struct {
+	// last swing of price compared
+	last swing[T]
+	// swing of price waiting for the nearest swing of the oscillator, and the distance to the one
+	// matched so far, -1 if none
+	pending  swing[T]
+	distance int
+	// last swing of the oscillator
+	pivot pivot[T]
+}
+
+// divergenceState holds the swings and the last divergence reported
+type divergenceState[T Float] struct {
+	high divergenceSide[T]
+	low  divergenceSide[T]
+
+	signal   Signal
+	from, to int
+}
+
+// NewDivergence creates a new Divergence with the given number of inputs around a swing and lookback
+// Example: NewDivergence(5, 60)
+func NewDivergence(n, lookback int) (*Divergence, error) {
+	return NewDivergenceOf[float64](n, lookback)
+}
+
+// NewDivergenceOf creates a new DivergenceOf values of type T with the given number of inputs around a swing and lookback
+// Example: NewDivergenceOf[float32](5, 60)
+func NewDivergenceOf[T Float](n, lookback int) (*DivergenceOf[T], error) {
+	if n <= 0 || lookback <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	max, err := NewMaximumOf[T](2*n + 1)
+	if err != nil {
+		return nil, err
+	}
+	min, err := NewMinimumOf[T](2*n + 1)
+	if err != nil {
+		return nil, err
+	}
+	oscMax, _ := NewMaximumOf[T](2*n + 1)
+	oscMin, _ := NewMinimumOf[T](2*n + 1)
+
+	return &DivergenceOf[T]{
+		n:        n,
+		lookback: lookback,
+
+		max:         max,
+		min:         min,
+		oscMax:      oscMax,
+		oscMin:      oscMin,
+		prices:      newLookback[T](n),
+		oscillators: newLookback[T](n),
+
+		state: newDivergenceState[T](),
+	}, nil
+}
+
+func newDivergenceState[T Float]() divergenceState[T] {
+	return divergenceState[T]{from: -1, to: -1}
+}
+
+// Next takes the next price and oscillator values and returns the Signal of a divergence
+// at the swing they confirm, or NoSignal
+func (d *DivergenceOf[T]) Next(price, oscillator T) Signal {
+	d.previous = d.state
+	d.count++
+	d.state = d.detect(d.state, d.count, d.max.Next(price), d.min.Next(price),
+		d.oscMax.Next(oscillator), d.oscMin.Next(oscillator), d.prices.push(price), d.oscillators.push(oscillator))
+	return d.state.signal
+}
+
+// Peek returns the value Next would return for price and oscillator without committing them
+func (d *DivergenceOf[T]) Peek(price, oscillator T) Signal {
+	state := d.detect(d.state, d.count+1, d.max.Peek(price), d.min.Peek(price),
+		d.oscMax.Peek(oscillator), d.oscMin.Peek(oscillator), d.prices.peek(price), d.oscillators.peek(oscillator))
+	return state.signal
+}
+
+// UpdateLast replaces the most recent values with price and oscillator and returns the updated Signal.
+// If no values have been committed yet it behaves like Next.
+func (d *DivergenceOf[T]) UpdateLast(price, oscillator T) Signal {
+	if d.count == 0 {
+		return d.Next(price, oscillator)
+	}
+
+	d.state = d.detect(d.previous, d.count, d.max.UpdateLast(price), d.min.UpdateLast(price),
+		d.oscMax.UpdateLast(oscillator), d.oscMin.UpdateLast(oscillator),
+		d.prices.replaceLast(price), d.oscillators.replaceLast(oscillator))
+	return d.state.signal
+}
+
+// Pivots returns the indexes of the earlier and later swings of the last divergence reported,
+// or -1 and -1 if there was none
+func (d *DivergenceOf[T]) Pivots() (from, to int) {
+	return d.state.from, d.state.to
+}
+
+// detect returns state updated with the input n inputs before the count-th one, given the Maximum
+// and Minimum of price and of the oscillator around it and its price and oscillator values
+func (d *DivergenceOf[T]) detect(state divergenceState[T], count int, high, low, oscHigh, oscLow, price, oscillator T) divergenceState[T] {
+	state.signal = NoSignal
+	if count < 2*d.n+1 {
+		return state
+	}
+
+	index := count - 1 - d.n
+	var previous, current swing[T]
+	var ok bool
+	state.high, previous, current, ok = d.match(state.high, index, price == high, oscillator == oscHigh, price, oscillator)
+	if ok && d.comparable(previous, current) {
+		switch {
+		case current.price > previous.price && current.oscillator < previous.oscillator:
+			state.signal = BearishDivergence
+		case current.price < previous.price && current.oscillator > previous.oscillator:
+			state.signal = HiddenBearishDivergence
+		}
+		if state.signal != NoSignal {
+			state.from, state.to = previous.index, current.index
+		}
+	}
+
+	state.low, previous, current, ok = d.match(state.low, index, price == low, oscillator == oscLow, price, oscillator)
+	if ok && state.signal == NoSignal && d.comparable(previous, current) {
+		switch {
+		case current.price < previous.price && current.oscillator > previous.oscillator:
+			state.signal = BullishDivergence
+		case current.price > previous.price && current.oscillator < previous.oscillator:
+			state.signal = HiddenBullishDivergence
+		}
+		if state.signal != NoSignal {
+			state.from, state.to = previous.index, current.index
+		}
+	}
+	return state
+}
+
+// match returns side updated with the input at index, a swing of price if priceSwing and of the oscillator
+// if oscillatorSwing. Once the pending swing of price is matched to the nearest swing of the oscillator, or
+// replaced by the next swing of price, it also returns the previous swing of price and the matched one.
+func (d *DivergenceOf[T]) match(side divergenceSide[T], index int, priceSwing, oscillatorSwing bool, price, oscillator T) (divergenceSide[T], swing[T], swing[T], bool) {
+	var matched swing[T]
+	if priceSwing && side.pending.ok {
+		matched = side.pending
+	}
+	if priceSwing {
+		side.pending = swing[T]{index: index, price: price, oscillator: oscillator, ok: true}
+		side.distance = -1
+	}
+	if oscillatorSwing {
+		side.pivot = pivot[T]{index: index, value: oscillator, ok: true}
+	}
+
+	if side.pending.ok && side.pivot.ok {
+		distance := side.pending.index - side.pivot.index
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance <= d.n && (side.distance < 0 || distance < side.distance) {
+			side.pending.oscillator = side.pivot.value
+			side.distance = distance
+		}
+	}
+
+	elapsed := index - side.pending.index
+	if !matched.ok && side.pending.ok && (side.distance >= 0 && elapsed >= side.distance || elapsed >= d.n) {
+		matched = side.pending
+		side.pending = swing[T]{}
+	}
+	if !matched.ok {
+		return side, swing[T]{}, swing[T]{}, false
+	}
+
+	previous := side.last
+	side.last = matched
+	return side, previous, matched, true
+}
+
+// comparable reports whether the previous swing is recent enough to be compared with the current one
+func (d *DivergenceOf[T]) comparable(previous, current swing[T]) bool {
+	return previous.ok && current.index-previous.index <= d.lookback
+}
+
+// Compute feeds every pair of prices and oscillators through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter of prices and oscillators.
+func (d *DivergenceOf[T]) Compute(prices, oscillators []T, out []Signal) []Signal {
+	n := len(prices)
+	if len(oscillators) < n {
+		n = len(oscillators)
+	}
+
+	out = resize(out, n)
+	for i := 0; i < n; i++ {
+		out[i] = d.Next(prices[i], oscillators[i])
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (d *DivergenceOf[T]) Reset() {
+	d.max.Reset()
+	d.min.Reset()
+	d.oscMax.Reset()
+	d.oscMin.Reset()
+	d.prices.reset()
+	d.oscillators.reset()
+	d.count = 0
+	d.state = newDivergenceState[T]()
+	d.previous = divergenceState[T]{}
+}
+
+// Clone returns a deep copy of the indicator, including its Maximum and Minimum windows
+func (d *DivergenceOf[T]) Clone() *DivergenceOf[T] {
+	clone := *d
+	clone.max = d.max.Clone()
+	clone.min = d.min.Clone()
+	clone.oscMax = d.oscMax.Clone()
+	clone.oscMin = d.oscMin.Clone()
+	clone.prices = d.prices.clone()
+	clone.oscillators = d.oscillators.clone()
+	return &clone
+}
+
+func (d *DivergenceOf[T]) String() string {
+	return fmt.Sprintf("Divergence(%d,%d)", d.n, d.lookback)
+}
+
+// ComputeDivergence computes a Divergence with the given number of inputs around a swing and lookback
+// over the pairs of prices and oscillators and writes the results to out (see Compute)
+func ComputeDivergence[T Float](n, lookback int, prices, oscillators []T, out []Signal) ([]Signal, error) {
+	d, err := NewDivergenceOf[T](n, lookback)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Compute(prices, oscillators, out), nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDivergence(t *testing.T) {
+	tests := map[string]struct {
+		n        int
+		lookback int
+		wantErr  error
+	}{
+		"zero n":          {n: 0, lookback: 10, wantErr: ErrInvalidParameters},
+		"negative n":      {n: -1, lookback: 10, wantErr: ErrInvalidParameters},
+		"zero lookback":   {n: 2, lookback: 0, wantErr: ErrInvalidParameters},
+		"valid arguments": {n: 2, lookback: 10, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewDivergence(tc.n, tc.lookback)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			from, to := got.Pivots()
+			assert.Equal(t, []int{-1, -1}, []int{from, to}, "must not report pivots before a divergence")
+		})
+	}
+}
+
+func TestDivergenceNext(t *testing.T) {
+	tests := map[string]struct {
+		lookback    int
+		prices      []float64
+		oscillators []float64
+		want        []Signal
+		wantPivots  []int
+	}{
+		"regular": {
+			lookback:    10,
+			prices:      []float64{10., 12., 11., 13., 12., 9., 10., 8., 11.},
+			oscillators: []float64{50., 70., 60., 65., 55., 30., 40., 35., 45.},
+			want: []Signal{NoSignal, NoSignal, NoSignal, NoSignal, BearishDivergence,
+				NoSignal, NoSignal, NoSignal, BullishDivergence},
+			wantPivots: []int{5, 7},
+		},
+		"hidden bullish": {
+			lookback:    10,
+			prices:      []float64{10., 8., 11., 9., 12.},
+			oscillators: []float64{50., 40., 60., 30., 70.},
+			want:        []Signal{NoSignal, NoSignal, NoSignal, NoSignal, HiddenBullishDivergence},
+			wantPivots:  []int{1, 3},
+		},
+		"hidden bearish": {
+			lookback:    10,
+			prices:      []float64{10., 14., 11., 13., 9.},
+			oscillators: []float64{50., 60., 40., 70., 30.},
+			want:        []Signal{NoSignal, NoSignal, NoSignal, NoSignal, HiddenBearishDivergence},
+			wantPivots:  []int{1, 3},
+		},
+		"beyond the lookback": {
+			lookback:    1,
+			prices:      []float64{10., 12., 11., 13., 12., 9., 10., 8., 11.},
+			oscillators: []float64{50., 70., 60., 65., 55., 30., 40., 35., 45.},
+			want: []Signal{NoSignal, NoSignal, NoSignal, NoSignal, NoSignal,
+				NoSignal, NoSignal, NoSignal, NoSignal},
+			wantPivots: []int{-1, -1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			divergence, _ := NewDivergence(1, tc.lookback)
+			got := make([]Signal, len(tc.prices))
+			for i := range tc.prices {
+				got[i] = divergence.Next(tc.prices[i], tc.oscillators[i])
+			}
+			assert.Equal(t, tc.want, got)
+
+			from, to := divergence.Pivots()
+			assert.Equal(t, tc.wantPivots, []int{from, to})
+		})
+	}
+}
+
+func TestDivergenceOscillatorSwings(t *testing.T) {
+	tests := map[string]struct {
+		prices      []float64
+		oscillators []float64
+		want        []Signal
+		wantPivots  []int
+	}{
+		"oscillator peaks a bar later": {
+			prices:      []float64{10., 13., 11., 12., 11., 10., 9.},
+			oscillators: []float64{50., 60., 40., 45., 70., 50., 45.},
+			want:        []Signal{NoSignal, NoSignal, NoSignal, NoSignal, NoSignal, HiddenBearishDivergence, NoSignal},
+			wantPivots:  []int{1, 3},
+		},
+		"oscillator peaks a bar earlier": {
+			prices:      []float64{10., 12., 11., 12.5, 13., 11., 10.},
+			oscillators: []float64{50., 60., 40., 75., 55., 45., 40.},
+			want:        []Signal{NoSignal, NoSignal, NoSignal, NoSignal, NoSignal, NoSignal, NoSignal},
+			wantPivots:  []int{-1, -1},
+		},
+		"oscillator troughs a bar later": {
+			prices:      []float64{12., 9., 11., 8., 10., 11., 12.},
+			oscillators: []float64{50., 30., 45., 40., 35., 50., 60.},
+			want:        []Signal{NoSignal, NoSignal, NoSignal, NoSignal, NoSignal, BullishDivergence, NoSignal},
+			wantPivots:  []int{1, 3},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			divergence, _ := NewDivergence(1, 10)
+			got := divergence.Compute(tc.prices, tc.oscillators, nil)
+			assert.Equal(t, tc.want, got)
+
+			from, to := divergence.Pivots()
+			assert.Equal(t, tc.wantPivots, []int{from, to})
+		})
+	}
+}
+
+func TestDivergenceWiderSwings(t *testing.T) {
+	divergence, _ := NewDivergence(2, 20)
+	prices := []float64{10., 11., 12., 11., 10., 11., 13., 12., 11., 10.}
+	oscillators := []float64{40., 50., 70., 50., 40., 50., 60., 50., 40., 30.}
+	got := divergence.Compute(prices, oscillators, nil)
+	assert.Equal(t, BearishDivergence, got[8], "must confirm the swing n inputs later")
+
+	from, to := divergence.Pivots()
+	assert.Equal(t, []int{2, 6}, []int{from, to})
+}
+
+func TestDivergencePeek(t *testing.T) {
+	divergence, _ := NewDivergence(1, 10)
+	prices := []float64{10., 12., 11., 13., 12., 9., 10., 8., 11.}
+	oscillators := []float64{50., 70., 60., 65., 55., 30., 40., 35., 45.}
+	for i := range prices {
+		divergence.Peek(100., 0.) // must not be committed
+		got := divergence.Peek(prices[i], oscillators[i])
+		assert.Equal(t, got, divergence.Next(prices[i], oscillators[i]), "must return the same value as Next")
+	}
+}
+
+func TestDivergenceUpdateLast(t *testing.T) {
+	divergence, _ := NewDivergence(1, 10)
+	divergence.Compute([]float64{10., 12., 11., 13.}, []float64{50., 70., 60., 65.}, nil)
+	assert.Equal(t, BearishDivergence, divergence.Next(12., 55.))
+	assert.Equal(t, NoSignal, divergence.UpdateLast(14., 55.), "must undo the swing high")
+	assert.Equal(t, BearishDivergence, divergence.UpdateLast(12., 55.), "must redo the swing high")
+
+	from, to := divergence.Pivots()
+	assert.Equal(t, []int{1, 3}, []int{from, to})
+
+	empty, _ := NewDivergence(1, 10)
+	assert.Equal(t, NoSignal, empty.UpdateLast(10., 50.), "must behave like Next when empty")
+}
+
+func TestDivergenceCompute(t *testing.T) {
+	prices := []float64{10., 12., 11., 13., 12., 9., 10., 8., 11., 12.}
+	oscillators := []float64{50., 70., 60., 65., 55., 30., 40., 35., 45.}
+
+	stream, _ := NewDivergence(1, 10)
+	want := make([]Signal, len(oscillators))
+	for i := range oscillators {
+		want[i] = stream.Next(prices[i], oscillators[i])
+	}
+
+	batch, _ := NewDivergence(1, 10)
+	out := make([]Signal, len(prices))
+	got := batch.Compute(prices, oscillators, out)
+	assert.Equal(t, want, got, "must return the same values as Next, up to the shorter series")
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+
+	_, err := ComputeDivergence(0, 10, prices, oscillators, nil)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	got, err = ComputeDivergence(1, 10, prices, oscillators, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDivergenceClone(t *testing.T) {
+	prices := []float64{10., 12., 11., 13.}
+	oscillators := []float64{50., 70., 60., 65.}
+	divergence, _ := NewDivergence(1, 10)
+	divergence.Compute(prices, oscillators, nil)
+	want, _ := NewDivergence(1, 10)
+	want.Compute(prices, oscillators, nil)
+
+	clone := divergence.Clone()
+	assert.Equal(t, BearishDivergence, clone.Next(12., 55.), "clone must continue from the same state")
+	assert.Equal(t, want, divergence, "original must not see the clone's inputs")
+}
+
+func TestDivergenceReset(t *testing.T) {
+	divergence, _ := NewDivergence(1, 10)
+	divergence.Compute([]float64{10., 12., 11., 13., 12.}, []float64{50., 70., 60., 65., 55.}, nil)
+	divergence.Reset()
+
+	want, _ := NewDivergence(1, 10)
+	assert.Equal(t, want, divergence, "must return to a clean state")
+}
+
+func TestDivergenceString(t *testing.T) {
+	divergence, _ := NewDivergence(5, 60)
+	assert.Equal(t, "Divergence(5,60)", divergence.String())
+}
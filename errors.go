@@ -9,4 +9,6 @@ import "errors"
 
 var (
 	ErrInvalidParameters = errors.New("invalid parameter")
+	ErrDecimalOverflow   = errors.New("decimal overflow")
+	ErrDivisionByZero    = errors.New("division by zero")
 )
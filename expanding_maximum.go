@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+/*
+ExpandingMaximumOf returns the highest value since the last Reset. Unlike Maximum, its window
+never drops data, which suits running statistics such as the peak of an equity curve.
+
+# Example
+```
+max := NewExpandingMaximum()
+max.Next(10.)
+```
+*/
+type ExpandingMaximumOf[T Float] struct {
+	// internal parameters for calculations
+	count int
+	max   T
+
+	// highest value before the most recent input, for UpdateLast
+	previous T
+}
+
+// ExpandingMaximum is an ExpandingMaximumOf float64 values
+type ExpandingMaximum = ExpandingMaximumOf[float64]
+
+// NewExpandingMaximum creates a new ExpandingMaximum
+// Example: NewExpandingMaximum()
+func NewExpandingMaximum() *ExpandingMaximum {
+	return NewExpandingMaximumOf[float64]()
+}
+
+// NewExpandingMaximumOf creates a new ExpandingMaximumOf values of type T
+// Example: NewExpandingMaximumOf[float32]()
+func NewExpandingMaximumOf[T Float]() *ExpandingMaximumOf[T] {
+	return &ExpandingMaximumOf[T]{
+		max:      T(math.Inf(-1)),
+		previous: T(math.Inf(-1)),
+	}
+}
+
+// Next takes the next input and returns the next ExpandingMaximum value
+func (e *ExpandingMaximumOf[T]) Next(input T) T {
+	e.count++
+	e.previous = e.max
+	if input > e.max {
+		e.max = input
+	}
+	return e.max
+}
+
+// Peek returns the value Next would return for input without committing it
+func (e *ExpandingMaximumOf[T]) Peek(input T) T {
+	if input > e.max {
+		return input
+	}
+	return e.max
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExpandingMaximum value.
+// If no input has been committed yet it behaves like Next.
+func (e *ExpandingMaximumOf[T]) UpdateLast(input T) T {
+	if e.count == 0 {
+		return e.Next(input)
+	}
+
+	e.max = e.previous
+	if input > e.max {
+		e.max = input
+	}
+	return e.max
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (e *ExpandingMaximumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = e.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (e *ExpandingMaximumOf[T]) Reset() {
+	*e = *NewExpandingMaximumOf[T]()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (e *ExpandingMaximumOf[T]) Clone() *ExpandingMaximumOf[T] {
+	clone := *e
+	return &clone
+}
+
+func (e *ExpandingMaximumOf[T]) String() string {
+	return "ExpandingMax"
+}
+
+// ComputeExpandingMaximum computes an ExpandingMaximum over inputs and writes the results to out (see Compute)
+func ComputeExpandingMaximum[T Float](inputs, out []T) []T {
+	return NewExpandingMaximumOf[T]().Compute(inputs, out)
+}
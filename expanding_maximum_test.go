@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandingMaximumNext(t *testing.T) {
+	sd := NewExpandingMaximum()
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 8., want: 8.},
+		{input: 0., want: 8.},
+		{input: 4., want: 8.},
+		{input: 1., want: 8.},
+		{input: 9., want: 9.},
+		{input: 9., want: 9.},
+		{input: 0., want: 9.},
+		{input: 3., want: 9.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExpandingMaximum(t *testing.T) {
+	got := ComputeExpandingMaximum([]float64{2., 8., 0., 4., 1.}, nil)
+	diff := cmp.Diff([]float64{2., 8., 8., 8., 8.}, got, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestExpandingMaximumInputs(t *testing.T) {
+	tests := map[string]struct {
+		inputs []float64
+		want   float64
+	}{
+		"first value":     {inputs: []float64{4.}, want: 4.},
+		"negative values": {inputs: []float64{-3., -1., -2.}, want: -1.},
+		"NaN skipped":     {inputs: []float64{3., math.NaN(), 1.}, want: 3.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewExpandingMaximum().Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExpandingMaximumString(t *testing.T) {
+	sd := NewExpandingMaximum()
+	want := "ExpandingMax"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+ExpandingMeanOf returns the mean of every input since the last Reset. Unlike Mean, its window
+never drops data, which suits "since inception" statistics.
+
+# Formula
+
+x̄<sub>t</sub> = x̄<sub>t-1</sub> + (x<sub>t</sub> - x̄<sub>t-1</sub>) / N
+
+Where:
+
+* _x<sub>t</sub>_ - input value at a point of time _t_
+* _N_ - number of probes in observation.
+
+# Example
+```
+mean := NewExpandingMean()
+mean.Next(10.)
+```
+*/
+type ExpandingMeanOf[T Float] struct {
+	// internal parameters for calculations
+	count int
+	mean  T
+	last  T
+}
+
+// ExpandingMean is an ExpandingMeanOf float64 values
+type ExpandingMean = ExpandingMeanOf[float64]
+
+// NewExpandingMean creates a new ExpandingMean
+// Example: NewExpandingMean()
+func NewExpandingMean() *ExpandingMean {
+	return NewExpandingMeanOf[float64]()
+}
+
+// NewExpandingMeanOf creates a new ExpandingMeanOf values of type T
+// Example: NewExpandingMeanOf[float32]()
+func NewExpandingMeanOf[T Float]() *ExpandingMeanOf[T] {
+	return &ExpandingMeanOf[T]{}
+}
+
+// Next takes the next input and returns the next ExpandingMean value
+func (em *ExpandingMeanOf[T]) Next(input T) T {
+	em.count++
+	em.mean += (input - em.mean) / T(em.count)
+	em.last = input
+	return em.mean
+}
+
+// Peek returns the value Next would return for input without committing it
+func (em *ExpandingMeanOf[T]) Peek(input T) T {
+	return em.mean + (input-em.mean)/T(em.count+1)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExpandingMean value.
+// If no input has been committed yet it behaves like Next.
+func (em *ExpandingMeanOf[T]) UpdateLast(input T) T {
+	if em.count == 0 {
+		return em.Next(input)
+	}
+
+	em.mean += (input - em.last) / T(em.count)
+	em.last = input
+	return em.mean
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (em *ExpandingMeanOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = em.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (em *ExpandingMeanOf[T]) Reset() {
+	*em = ExpandingMeanOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (em *ExpandingMeanOf[T]) Clone() *ExpandingMeanOf[T] {
+	clone := *em
+	return &clone
+}
+
+func (em *ExpandingMeanOf[T]) String() string {
+	return "ExpandingMean"
+}
+
+// ComputeExpandingMean computes an ExpandingMean over inputs and writes the results to out (see Compute)
+func ComputeExpandingMean[T Float](inputs, out []T) []T {
+	return NewExpandingMeanOf[T]().Compute(inputs, out)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandingMeanNext(t *testing.T) {
+	sd := NewExpandingMean()
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 8., want: 5.},
+		{input: 0., want: 3.33333},
+		{input: 4., want: 3.5},
+		{input: 1., want: 3.},
+		{input: 9., want: 4.},
+		{input: 9., want: 4.71429},
+		{input: 0., want: 4.125},
+		{input: 3., want: 4.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExpandingMean(t *testing.T) {
+	got := ComputeExpandingMean([]float64{2., 8., 0., 4., 1.}, nil)
+	diff := cmp.Diff([]float64{2., 5., 3.33333, 3.5, 3.}, got, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestExpandingMeanInputs(t *testing.T) {
+	tests := map[string]struct {
+		inputs []float64
+		want   float64
+	}{
+		"first value":     {inputs: []float64{4.}, want: 4.},
+		"constant values": {inputs: []float64{0.1, 0.1, 0.1, 0.1}, want: 0.1},
+		"large offset":    {inputs: []float64{1e9 + 4., 1e9 + 7., 1e9 + 13., 1e9 + 16.}, want: 1e9 + 10.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewExpandingMean().Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExpandingMeanString(t *testing.T) {
+	sd := NewExpandingMean()
+	want := "ExpandingMean"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
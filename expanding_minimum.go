@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+/*
+ExpandingMinimumOf returns the lowest value since the last Reset. Unlike Minimum, its window
+never drops data, which suits running statistics such as the peak of an equity curve.
+
+# Example
+```
+min := NewExpandingMinimum()
+min.Next(10.)
+```
+*/
+type ExpandingMinimumOf[T Float] struct {
+	// internal parameters for calculations
+	count int
+	min   T
+
+	// lowest value before the most recent input, for UpdateLast
+	previous T
+}
+
+// ExpandingMinimum is an ExpandingMinimumOf float64 values
+type ExpandingMinimum = ExpandingMinimumOf[float64]
+
+// NewExpandingMinimum creates a new ExpandingMinimum
+// Example: NewExpandingMinimum()
+func NewExpandingMinimum() *ExpandingMinimum {
+	return NewExpandingMinimumOf[float64]()
+}
+
+// NewExpandingMinimumOf creates a new ExpandingMinimumOf values of type T
+// Example: NewExpandingMinimumOf[float32]()
+func NewExpandingMinimumOf[T Float]() *ExpandingMinimumOf[T] {
+	return &ExpandingMinimumOf[T]{
+		min:      T(math.Inf(1)),
+		previous: T(math.Inf(1)),
+	}
+}
+
+// Next takes the next input and returns the next ExpandingMinimum value
+func (e *ExpandingMinimumOf[T]) Next(input T) T {
+	e.count++
+	e.previous = e.min
+	if input < e.min {
+		e.min = input
+	}
+	return e.min
+}
+
+// Peek returns the value Next would return for input without committing it
+func (e *ExpandingMinimumOf[T]) Peek(input T) T {
+	if input < e.min {
+		return input
+	}
+	return e.min
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExpandingMinimum value.
+// If no input has been committed yet it behaves like Next.
+func (e *ExpandingMinimumOf[T]) UpdateLast(input T) T {
+	if e.count == 0 {
+		return e.Next(input)
+	}
+
+	e.min = e.previous
+	if input < e.min {
+		e.min = input
+	}
+	return e.min
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (e *ExpandingMinimumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = e.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (e *ExpandingMinimumOf[T]) Reset() {
+	*e = *NewExpandingMinimumOf[T]()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (e *ExpandingMinimumOf[T]) Clone() *ExpandingMinimumOf[T] {
+	clone := *e
+	return &clone
+}
+
+func (e *ExpandingMinimumOf[T]) String() string {
+	return "ExpandingMin"
+}
+
+// ComputeExpandingMinimum computes an ExpandingMinimum over inputs and writes the results to out (see Compute)
+func ComputeExpandingMinimum[T Float](inputs, out []T) []T {
+	return NewExpandingMinimumOf[T]().Compute(inputs, out)
+}
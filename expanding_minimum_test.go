@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandingMinimumNext(t *testing.T) {
+	sd := NewExpandingMinimum()
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 8., want: 2.},
+		{input: 0., want: 0.},
+		{input: 4., want: 0.},
+		{input: 1., want: 0.},
+		{input: 9., want: 0.},
+		{input: 9., want: 0.},
+		{input: 0., want: 0.},
+		{input: 3., want: 0.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExpandingMinimum(t *testing.T) {
+	got := ComputeExpandingMinimum([]float64{2., 8., 0., 4., 1.}, nil)
+	diff := cmp.Diff([]float64{2., 2., 0., 0., 0.}, got, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestExpandingMinimumInputs(t *testing.T) {
+	tests := map[string]struct {
+		inputs []float64
+		want   float64
+	}{
+		"first value":     {inputs: []float64{4.}, want: 4.},
+		"positive values": {inputs: []float64{3., 1., 2.}, want: 1.},
+		"NaN skipped":     {inputs: []float64{3., math.NaN(), 5.}, want: 3.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewExpandingMinimum().Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExpandingMinimumString(t *testing.T) {
+	sd := NewExpandingMinimum()
+	want := "ExpandingMin"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+/*
+ExpandingStandardDeviationOf returns the standard deviation of every input since the last Reset,
+computed with Welford's online algorithm. Unlike StandardDeviation, its window never drops data.
+
+# Formula
+
+See StandardDeviation documentation, with _N_ the number of inputs since the last Reset.
+
+# Example
+```
+sd := NewExpandingStandardDeviation()
+sd.Next(10.)
+```
+*/
+type ExpandingStandardDeviationOf[T Float] struct {
+	// internal parameters for calculations
+	count int
+	last  T
+
+	m  T
+	m2 T
+}
+
+// ExpandingStandardDeviation is an ExpandingStandardDeviationOf float64 values
+type ExpandingStandardDeviation = ExpandingStandardDeviationOf[float64]
+
+// NewExpandingStandardDeviation creates a new ExpandingStandardDeviation
+// Example: NewExpandingStandardDeviation()
+func NewExpandingStandardDeviation() *ExpandingStandardDeviation {
+	return NewExpandingStandardDeviationOf[float64]()
+}
+
+// NewExpandingStandardDeviationOf creates a new ExpandingStandardDeviationOf values of type T
+// Example: NewExpandingStandardDeviationOf[float32]()
+func NewExpandingStandardDeviationOf[T Float]() *ExpandingStandardDeviationOf[T] {
+	return &ExpandingStandardDeviationOf[T]{}
+}
+
+// Next takes the next input and returns the next ExpandingStandardDeviation value
+func (sd *ExpandingStandardDeviationOf[T]) Next(input T) T {
+	sd.count++
+	delta := input - sd.m
+	sd.m += delta / T(sd.count)
+	sd.m2 += delta * (input - sd.m)
+	sd.last = input
+
+	return T(math.Sqrt(float64(sd.m2 / T(sd.count))))
+}
+
+// Peek returns the value Next would return for input without committing it
+func (sd *ExpandingStandardDeviationOf[T]) Peek(input T) T {
+	clone := *sd
+	return clone.Next(input)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExpandingStandardDeviation value.
+// If no input has been committed yet it behaves like Next.
+func (sd *ExpandingStandardDeviationOf[T]) UpdateLast(input T) T {
+	if sd.count > 1 {
+		// remove the last input by inverting Next
+		n := T(sd.count)
+		sd.count--
+		m := (n*sd.m - sd.last) / T(sd.count)
+		sd.m2 -= (sd.last - m) * (sd.last - sd.m)
+		sd.m = m
+	} else {
+		sd.Reset()
+	}
+
+	return sd.Next(input)
+}
+
+// Mean returns the mean of every input since the last Reset
+func (sd *ExpandingStandardDeviationOf[T]) Mean() T {
+	return sd.m
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (sd *ExpandingStandardDeviationOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = sd.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (sd *ExpandingStandardDeviationOf[T]) Reset() {
+	*sd = ExpandingStandardDeviationOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (sd *ExpandingStandardDeviationOf[T]) Clone() *ExpandingStandardDeviationOf[T] {
+	clone := *sd
+	return &clone
+}
+
+func (sd *ExpandingStandardDeviationOf[T]) String() string {
+	return "ExpandingSD"
+}
+
+// ComputeExpandingStandardDeviation computes an ExpandingStandardDeviation over inputs and writes the results to out (see Compute)
+func ComputeExpandingStandardDeviation[T Float](inputs, out []T) []T {
+	return NewExpandingStandardDeviationOf[T]().Compute(inputs, out)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandingStandardDeviationNext(t *testing.T) {
+	sd := NewExpandingStandardDeviation()
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 3.},
+		{input: 0., want: 3.39935},
+		{input: 4., want: 2.95804},
+		{input: 1., want: 2.82843},
+		{input: 9., want: 3.41565},
+		{input: 9., want: 3.61403},
+		{input: 0., want: 3.72282},
+		{input: 3., want: 3.52767},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExpandingStandardDeviation(t *testing.T) {
+	got := ComputeExpandingStandardDeviation([]float64{2., 8., 0., 4., 1.}, nil)
+	diff := cmp.Diff([]float64{0., 3., 3.39935, 2.95804, 2.82843}, got, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestExpandingStandardDeviationInputs(t *testing.T) {
+	tests := map[string]struct {
+		inputs []float64
+		want   float64
+	}{
+		"first value":     {inputs: []float64{4.}, want: 0.},
+		"constant values": {inputs: []float64{0.1, 0.1, 0.1, 0.1}, want: 0.},
+		"large offset":    {inputs: []float64{1e9 + 4., 1e9 + 7., 1e9 + 13., 1e9 + 16.}, want: 4.74342},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewExpandingStandardDeviation().Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExpandingStandardDeviationString(t *testing.T) {
+	sd := NewExpandingStandardDeviation()
+	want := "ExpandingSD"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+// Seeding selects how the exponentially weighted indicators start before their smoothing takes over
+type Seeding int
+
+const (
+	// SeedFirst starts from the first input, the default
+	SeedFirst Seeding = iota
+	// SeedMean weighs the first n inputs equally, so the n-th value matches the simple mean,
+	// variance or covariance of the first n inputs, as TA-Lib seeds its EMA
+	SeedMean
+)
+
+// ExponentialOption configures ExponentialMovingAverage and the other exponentially weighted indicators
+type ExponentialOption func(*exponentialConfig)
+
+// WithAlpha sets the smoothing factor, the weight of the most recent input, which must be in (0, 1].
+// It replaces the default 2 / (n + 1); RiskMetrics uses 0.06 for daily volatility.
+func WithAlpha(alpha float64) ExponentialOption {
+	return func(c *exponentialConfig) {
+		c.alpha, c.hasAlpha = alpha, true
+	}
+}
+
+// WithSeeding sets how the indicator starts, SeedFirst by default
+func WithSeeding(seeding Seeding) ExponentialOption {
+	return func(c *exponentialConfig) {
+		c.seeding = seeding
+	}
+}
+
+// WithBiasCorrection makes the exponentially weighted variance and covariance unbiased, scaling them
+// by 1 / (1 - Σw<sub>i</sub><sup>2</sup>) where w<sub>i</sub> are the weights of the inputs.
+// It has no effect on ExponentialMovingAverage, and cancels out of ExponentialCorrelation.
+func WithBiasCorrection() ExponentialOption {
+	return func(c *exponentialConfig) {
+		c.unbiased = true
+	}
+}
+
+// exponentialConfig holds the options of an exponentially weighted indicator
+type exponentialConfig struct {
+	// smoothing factor, if set, replacing the default 2 / (n + 1)
+	alpha    float64
+	hasAlpha bool
+
+	seeding  Seeding
+	unbiased bool
+}
+
+func newExponentialConfig(options []ExponentialOption) (exponentialConfig, error) {
+	var config exponentialConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.hasAlpha && !(config.alpha > 0 && config.alpha <= 1) {
+		return exponentialConfig{}, ErrInvalidParameters
+	}
+	if config.seeding != SeedFirst && config.seeding != SeedMean {
+		return exponentialConfig{}, ErrInvalidParameters
+	}
+	return config, nil
+}
+
+// name returns the name of an indicator with n periods, with the smoothing factor if it was set
+func (c exponentialConfig) name(prefix string, n int) string {
+	if c.hasAlpha {
+		return fmt.Sprintf("%s(%d,%g)", prefix, n, c.alpha)
+	}
+	return fmt.Sprintf("%s(%d)", prefix, n)
+}
+
+// exponentialSmoothing gives the weight of each input of an exponentially weighted indicator:
+// 1/count for the first seed inputs, which averages them equally, and alpha afterwards
+type exponentialSmoothing[T Float] struct {
+	alpha T
+	seed  int
+
+	// number of inputs, counted up to seed+1
+	count int
+}
+
+func newExponentialSmoothing[T Float](n int, config exponentialConfig) exponentialSmoothing[T] {
+	smoothing := exponentialSmoothing[T]{
+		alpha: 2. / (T(n) + 1.),
+		seed:  1,
+	}
+	if config.hasAlpha {
+		smoothing.alpha = T(config.alpha)
+	}
+	if config.seeding == SeedMean {
+		smoothing.seed = n
+	}
+	return smoothing
+}
+
+// next counts the next input and returns its weight
+func (s *exponentialSmoothing[T]) next() T {
+	if s.count <= s.seed {
+		s.count++
+	}
+	return s.weight()
+}
+
+// weight returns the weight of the most recent input
+func (s *exponentialSmoothing[T]) weight() T {
+	if s.count <= s.seed {
+		return 1. / T(s.count)
+	}
+	return s.alpha
+}
+
+// exponentialMoments keeps exponentially weighted means and co-moments of two series.
+// The co-moments are biased: they divide by the sum of the weights.
+type exponentialMoments[T Float] struct {
+	meanX T
+	meanY T
+	cxx   T
+	cyy   T
+	cxy   T
+
+	// sum of the squares of the weights, for the bias correction
+	sumSquares T
+}
+
+// add includes the pair (x, y) with weight w, scaling the weights of the earlier pairs by 1-w
+func (em *exponentialMoments[T]) add(x, y, w T) {
+	dx := x - em.meanX
+	dy := y - em.meanY
+	em.meanX += w * dx
+	em.meanY += w * dy
+
+	em.cxx = (1 - w) * (em.cxx + w*dx*dx)
+	em.cyy = (1 - w) * (em.cyy + w*dy*dy)
+	em.cxy = (1 - w) * (em.cxy + w*dx*dy)
+	em.sumSquares = w*w + (1-w)*(1-w)*em.sumSquares
+}
+
+// correction returns the factor that makes the co-moments unbiased, or 0 while there is
+// a single input and they cannot be estimated
+func (em *exponentialMoments[T]) correction(unbiased bool) T {
+	if !unbiased {
+		return 1
+	}
+	if em.sumSquares >= 1 {
+		return 0
+	}
+	return 1 / (1 - em.sumSquares)
+}
+
+// correlation returns the correlation of the pairs, or 0 if either series is constant
+func (em *exponentialMoments[T]) correlation() T {
+	moments := coMoments[T]{cxx: em.cxx, cyy: em.cyy, cxy: em.cxy}
+	return moments.correlation()
+}
+
+// exponentialWindow feeds pairs through exponentialMoments, remembering the moments before
+// the most recent pair so that it can be replaced
+type exponentialWindow[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n      int
+	config exponentialConfig
+
+	// internal parameters for calculations
+	smoothing exponentialSmoothing[T]
+	moments   exponentialMoments[T]
+	previous  exponentialMoments[T]
+}
+
+func newExponentialWindow[T Float](n int, options []ExponentialOption) (exponentialWindow[T], error) {
+	config, err := newExponentialConfig(options)
+	if n <= 0 || err != nil {
+		return exponentialWindow[T]{}, ErrInvalidParameters
+	}
+
+	return exponentialWindow[T]{
+		n:      n,
+		config: config,
+
+		smoothing: newExponentialSmoothing[T](n, config),
+	}, nil
+}
+
+func (w *exponentialWindow[T]) push(x, y T) {
+	w.previous = w.moments
+	w.moments.add(x, y, w.smoothing.next())
+}
+
+// peek returns the moments push would produce for (x, y) without changing the window
+func (w *exponentialWindow[T]) peek(x, y T) exponentialMoments[T] {
+	smoothing, moments := w.smoothing, w.moments
+	moments.add(x, y, smoothing.next())
+	return moments
+}
+
+// replaceLast replaces the most recent pair with (x, y), pushing it if there is none
+func (w *exponentialWindow[T]) replaceLast(x, y T) {
+	if w.smoothing.count == 0 {
+		w.push(x, y)
+		return
+	}
+
+	w.moments = w.previous
+	w.moments.add(x, y, w.smoothing.weight())
+}
+
+// variance returns the variance of the first series in moments
+func (w *exponentialWindow[T]) variance(moments exponentialMoments[T]) T {
+	return moments.cxx * moments.correction(w.config.unbiased)
+}
+
+// covariance returns the covariance of the series in moments
+func (w *exponentialWindow[T]) covariance(moments exponentialMoments[T]) T {
+	return moments.cxy * moments.correction(w.config.unbiased)
+}
+
+func (w *exponentialWindow[T]) reset() {
+	w.smoothing.count = 0
+	w.moments = exponentialMoments[T]{}
+	w.previous = exponentialMoments[T]{}
+}
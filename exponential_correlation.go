@@ -0,0 +1,109 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+ExponentialCorrelationOf returns the exponentially weighted correlation of two series: their
+ExponentialCovariance divided by the product of their ExponentialStandardDeviation. The bias
+correction, if set, cancels out.
+
+# Formula
+
+ECorr<sub>t</sub> = ECov<sub>t</sub>(x, y) / √(EVar<sub>t</sub>(x)·EVar<sub>t</sub>(y))
+
+The correlation is 0 while either series is constant.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _options_ - optional ExponentialOption values, see ExponentialMovingAverage and WithBiasCorrection
+
+# Example
+```
+corr, _ := NewExponentialCorrelation(20, WithAlpha(0.06))
+corr.Next(asset, benchmark)
+```
+*/
+type ExponentialCorrelationOf[T Float] struct {
+	exponentialWindow[T]
+}
+
+// ExponentialCorrelation is an ExponentialCorrelationOf float64 values
+type ExponentialCorrelation = ExponentialCorrelationOf[float64]
+
+// NewExponentialCorrelation creates a new ExponentialCorrelation with the given number of periods and options
+// Example: NewExponentialCorrelation(20, WithAlpha(0.06))
+func NewExponentialCorrelation(n int, options ...ExponentialOption) (*ExponentialCorrelation, error) {
+	return NewExponentialCorrelationOf[float64](n, options...)
+}
+
+// NewExponentialCorrelationOf creates a new ExponentialCorrelationOf values of type T with the given number of periods and options
+// Example: NewExponentialCorrelationOf[float32](20, WithBiasCorrection())
+func NewExponentialCorrelationOf[T Float](n int, options ...ExponentialOption) (*ExponentialCorrelationOf[T], error) {
+	window, err := newExponentialWindow[T](n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExponentialCorrelationOf[T]{
+		exponentialWindow: window,
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next ExponentialCorrelation value
+func (corr *ExponentialCorrelationOf[T]) Next(x, y T) T {
+	corr.push(x, y)
+	return corr.moments.correlation()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (corr *ExponentialCorrelationOf[T]) Peek(x, y T) T {
+	moments := corr.peek(x, y)
+	return moments.correlation()
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated ExponentialCorrelation value.
+// If no pair has been committed yet it behaves like Next.
+func (corr *ExponentialCorrelationOf[T]) UpdateLast(x, y T) T {
+	corr.replaceLast(x, y)
+	return corr.moments.correlation()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (corr *ExponentialCorrelationOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(corr.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (corr *ExponentialCorrelationOf[T]) Reset() {
+	corr.reset()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (corr *ExponentialCorrelationOf[T]) Clone() *ExponentialCorrelationOf[T] {
+	clone := *corr
+	return &clone
+}
+
+func (corr *ExponentialCorrelationOf[T]) String() string {
+	return corr.config.name("ECorr", corr.n)
+}
+
+// ComputeExponentialCorrelation computes an ExponentialCorrelation with n periods and options over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before n inputs have been seen, are set to NaN.
+func ComputeExponentialCorrelation[T Float](n int, xs, ys, out []T, fillNaN bool, options ...ExponentialOption) ([]T, error) {
+	corr, err := NewExponentialCorrelationOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	out = corr.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExponentialCorrelation(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *ExponentialCorrelation
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &ExponentialCorrelation{exponentialWindow: exponentialWindow[float64]{n: 9, smoothing: exponentialSmoothing[float64]{alpha: 0.2, seed: 1}}}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewExponentialCorrelation(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestExponentialCorrelationNext(t *testing.T) {
+	corr, _ := NewExponentialCorrelation(4)
+	tests := []struct {
+		x    float64
+		y    float64
+		want float64
+	}{
+		{x: 2., y: 1., want: 0.},
+		{x: 8., y: 5., want: 1.},
+		{x: 0., y: 2., want: 0.843645},
+		{x: 4., y: 6., want: 0.673223},
+		{x: 1., y: 3., want: 0.686276},
+		{x: 9., y: 8., want: 0.923307},
+		{x: 9., y: 7., want: 0.920842},
+		{x: 0., y: 1., want: 0.966963},
+		{x: 3., y: 4., want: 0.956702},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := corr.Next(tc.x, tc.y)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExponentialCorrelation(t *testing.T) {
+	xs := []float64{2., 8., 0., 4., 1.}
+	ys := []float64{1., 5., 2., 6., 3.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 1., 0.843645, 0.673223, 0.686276}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 0.673223, 0.686276}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeExponentialCorrelation(tc.n, xs, ys, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialCorrelationInputs(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		xs   []float64
+		ys   []float64
+		want float64
+	}{
+		"constant x":   {n: 4, xs: []float64{3., 3., 3., 3.}, ys: []float64{1., 5., 2., 6.}, want: 0.},
+		"increasing":   {n: 4, xs: []float64{1., 2., 3., 4.}, ys: []float64{2., 4., 6., 8.}, want: 1.},
+		"decreasing":   {n: 4, xs: []float64{1., 2., 3., 4.}, ys: []float64{8., 6., 4., 2.}, want: -1.},
+		"large offset": {n: 4, xs: []float64{1e9 + 2., 1e9 + 8., 1e9}, ys: []float64{1e9 + 1., 1e9 + 5., 1e9 + 2.}, want: 0.843645},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewExponentialCorrelation(tc.n)
+			got := sd.Compute(tc.xs, tc.ys, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialCorrelationString(t *testing.T) {
+	corr, _ := NewExponentialCorrelation(4)
+	want := "ECorr(4)"
+	got := corr.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+ExponentialCovarianceOf returns the exponentially weighted covariance of two series, in which the
+weight of each pair decays by a factor of (1 - α) every period, like in ExponentialMovingAverage.
+
+# Formula
+
+* _ECov<sub>t</sub>_ = (1 - α)·(ECov<sub>t-1</sub> + α·(x<sub>t</sub> - x̄<sub>t-1</sub>)(y<sub>t</sub> - ȳ<sub>t-1</sub>))
+
+Where:
+
+* _x̄_, _ȳ_ - exponential moving averages of the two series
+* _α_ - smoothing factor, 2 / (n + 1) unless set with WithAlpha
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _options_ - optional ExponentialOption values, see ExponentialMovingAverage and WithBiasCorrection
+
+# Example
+```
+cov, _ := NewExponentialCovariance(20, WithAlpha(0.06))
+cov.Next(asset, benchmark)
+```
+*/
+type ExponentialCovarianceOf[T Float] struct {
+	exponentialWindow[T]
+}
+
+// ExponentialCovariance is an ExponentialCovarianceOf float64 values
+type ExponentialCovariance = ExponentialCovarianceOf[float64]
+
+// NewExponentialCovariance creates a new ExponentialCovariance with the given number of periods and options
+// Example: NewExponentialCovariance(20, WithAlpha(0.06))
+func NewExponentialCovariance(n int, options ...ExponentialOption) (*ExponentialCovariance, error) {
+	return NewExponentialCovarianceOf[float64](n, options...)
+}
+
+// NewExponentialCovarianceOf creates a new ExponentialCovarianceOf values of type T with the given number of periods and options
+// Example: NewExponentialCovarianceOf[float32](20, WithBiasCorrection())
+func NewExponentialCovarianceOf[T Float](n int, options ...ExponentialOption) (*ExponentialCovarianceOf[T], error) {
+	window, err := newExponentialWindow[T](n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExponentialCovarianceOf[T]{
+		exponentialWindow: window,
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next ExponentialCovariance value
+func (cov *ExponentialCovarianceOf[T]) Next(x, y T) T {
+	cov.push(x, y)
+	return cov.covariance(cov.moments)
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (cov *ExponentialCovarianceOf[T]) Peek(x, y T) T {
+	moments := cov.peek(x, y)
+	return cov.covariance(moments)
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated ExponentialCovariance value.
+// If no pair has been committed yet it behaves like Next.
+func (cov *ExponentialCovarianceOf[T]) UpdateLast(x, y T) T {
+	cov.replaceLast(x, y)
+	return cov.covariance(cov.moments)
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (cov *ExponentialCovarianceOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(cov.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (cov *ExponentialCovarianceOf[T]) Reset() {
+	cov.reset()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (cov *ExponentialCovarianceOf[T]) Clone() *ExponentialCovarianceOf[T] {
+	clone := *cov
+	return &clone
+}
+
+func (cov *ExponentialCovarianceOf[T]) String() string {
+	return cov.config.name("ECov", cov.n)
+}
+
+// ComputeExponentialCovariance computes an ExponentialCovariance with n periods and options over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before n inputs have been seen, are set to NaN.
+func ComputeExponentialCovariance[T Float](n int, xs, ys, out []T, fillNaN bool, options ...ExponentialOption) ([]T, error) {
+	cov, err := NewExponentialCovarianceOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	out = cov.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExponentialCovariance(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *ExponentialCovariance
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &ExponentialCovariance{exponentialWindow: exponentialWindow[float64]{n: 9, smoothing: exponentialSmoothing[float64]{alpha: 0.2, seed: 1}}}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewExponentialCovariance(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestExponentialCovarianceNext(t *testing.T) {
+	cov, _ := NewExponentialCovariance(4)
+	tests := []struct {
+		x    float64
+		y    float64
+		want float64
+	}{
+		{x: 2., y: 1., want: 0.},
+		{x: 8., y: 5., want: 5.76},
+		{x: 0., y: 2., want: 4.0896},
+		{x: 4., y: 6., want: 3.64186},
+		{x: 1., y: 3., want: 2.61283},
+		{x: 9., y: 8., want: 8.80916},
+		{x: 9., y: 7., want: 6.92912},
+		{x: 0., y: 1., want: 12.03},
+		{x: 3., y: 4., want: 7.21474},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := cov.Next(tc.x, tc.y)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExponentialCovariance(t *testing.T) {
+	xs := []float64{2., 8., 0., 4., 1.}
+	ys := []float64{1., 5., 2., 6., 3.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 5.76, 4.0896, 3.64186, 2.61283}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 3.64186, 2.61283}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeExponentialCovariance(tc.n, xs, ys, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialCovarianceInputs(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		xs   []float64
+		ys   []float64
+		want float64
+	}{
+		"constant x":    {n: 4, xs: []float64{3., 3., 3., 3.}, ys: []float64{1., 5., 2., 6.}, want: 0.},
+		"large offset":  {n: 4, xs: []float64{1e9 + 2., 1e9 + 8., 1e9}, ys: []float64{1e9 + 1., 1e9 + 5., 1e9 + 2.}, want: 4.0896},
+		"single period": {n: 1, xs: []float64{2., 8.}, ys: []float64{1., 5.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewExponentialCovariance(tc.n)
+			got := sd.Compute(tc.xs, tc.ys, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialCovarianceString(t *testing.T) {
+	cov, _ := NewExponentialCovariance(4)
+	want := "ECov(4)"
+	got := cov.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+/*
+ExponentialStandardDeviationOf returns the exponentially weighted standard deviation of the inputs,
+the square root of ExponentialVariance. Fed with returns and WithAlpha(0.06), it is the RiskMetrics
+EWMA volatility estimate, which responds to shocks quicker than StandardDeviation.
+
+# Formula
+
+ESD<sub>t</sub> = √EVar<sub>t</sub>
+
+See ExponentialVariance documentation.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _options_ - optional ExponentialOption values, see ExponentialMovingAverage and WithBiasCorrection
+
+# Example
+```
+vol, _ := NewExponentialStandardDeviation(20, WithAlpha(0.06))
+vol.Next(0.01)
+```
+*/
+type ExponentialStandardDeviationOf[T Float] struct {
+	exponentialWindow[T]
+}
+
+// ExponentialStandardDeviation is an ExponentialStandardDeviationOf float64 values
+type ExponentialStandardDeviation = ExponentialStandardDeviationOf[float64]
+
+// NewExponentialStandardDeviation creates a new ExponentialStandardDeviation with the given number of periods and options
+// Example: NewExponentialStandardDeviation(20, WithAlpha(0.06))
+func NewExponentialStandardDeviation(n int, options ...ExponentialOption) (*ExponentialStandardDeviation, error) {
+	return NewExponentialStandardDeviationOf[float64](n, options...)
+}
+
+// NewExponentialStandardDeviationOf creates a new ExponentialStandardDeviationOf values of type T with the given number of periods and options
+// Example: NewExponentialStandardDeviationOf[float32](20, WithBiasCorrection())
+func NewExponentialStandardDeviationOf[T Float](n int, options ...ExponentialOption) (*ExponentialStandardDeviationOf[T], error) {
+	window, err := newExponentialWindow[T](n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExponentialStandardDeviationOf[T]{
+		exponentialWindow: window,
+	}, nil
+}
+
+// Next takes the next input and returns the next ExponentialStandardDeviation value
+func (sd *ExponentialStandardDeviationOf[T]) Next(input T) T {
+	sd.push(input, input)
+	return T(math.Sqrt(float64(sd.variance(sd.moments))))
+}
+
+// Peek returns the value Next would return for input without committing it
+func (sd *ExponentialStandardDeviationOf[T]) Peek(input T) T {
+	moments := sd.peek(input, input)
+	return T(math.Sqrt(float64(sd.variance(moments))))
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExponentialStandardDeviation value.
+// If no input has been committed yet it behaves like Next.
+func (sd *ExponentialStandardDeviationOf[T]) UpdateLast(input T) T {
+	sd.replaceLast(input, input)
+	return T(math.Sqrt(float64(sd.variance(sd.moments))))
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (sd *ExponentialStandardDeviationOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = sd.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (sd *ExponentialStandardDeviationOf[T]) Reset() {
+	sd.reset()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (sd *ExponentialStandardDeviationOf[T]) Clone() *ExponentialStandardDeviationOf[T] {
+	clone := *sd
+	return &clone
+}
+
+func (sd *ExponentialStandardDeviationOf[T]) String() string {
+	return sd.config.name("ESD", sd.n)
+}
+
+// ComputeExponentialStandardDeviation computes an ExponentialStandardDeviation with n periods and options over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before n inputs have been seen, are set to NaN.
+func ComputeExponentialStandardDeviation[T Float](n int, inputs, out []T, fillNaN bool, options ...ExponentialOption) ([]T, error) {
+	sd, err := NewExponentialStandardDeviationOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	out = sd.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExponentialStandardDeviation(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *ExponentialStandardDeviation
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &ExponentialStandardDeviation{exponentialWindow: exponentialWindow[float64]{n: 9, smoothing: exponentialSmoothing[float64]{alpha: 0.2, seed: 1}}}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewExponentialStandardDeviation(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestExponentialStandardDeviationNext(t *testing.T) {
+	sd, _ := NewExponentialStandardDeviation(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 2.93939},
+		{input: 0., want: 3.13535},
+		{input: 4., want: 2.51836},
+		{input: 1., want: 2.22487},
+		{input: 9., want: 3.70273},
+		{input: 9., want: 3.47744},
+		{input: 0., want: 4.20521},
+		{input: 3., want: 3.29077},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialStandardDeviationOptions(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	tests := map[string]struct {
+		options []ExponentialOption
+		want    []float64
+	}{
+		"alpha":           {options: []ExponentialOption{WithAlpha(0.06)}, want: []float64{0., 1.42492, 1.49087, 1.50611, 1.49376, 2.16111, 2.5814, 2.60428, 2.52519, 2.5005, 2.60488, 2.58037}},
+		"seed mean":       {options: []ExponentialOption{WithSeeding(SeedMean)}, want: []float64{0., 3., 3.39935, 2.95804, 2.59808, 3.76696, 3.48775, 4.23603, 3.3173, 2.65983, 2.48647, 2.85256}},
+		"bias correction": {options: []ExponentialOption{WithBiasCorrection()}, want: []float64{0., 4.24264, 3.88057, 2.97826, 2.59091, 4.28853, 4.01978, 4.85767, 3.8004, 3.0522, 2.86352, 3.28618}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, err := NewExponentialStandardDeviation(4, tc.options...)
+			assert.NoError(t, err)
+			diff := cmp.Diff(tc.want, sd.Compute(inputs, nil), approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExponentialStandardDeviation(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 2.93939, 3.13535, 2.51836, 2.22487}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 2.51836, 2.22487}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeExponentialStandardDeviation(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialStandardDeviationInputs(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"constant values": {n: 4, inputs: []float64{5., 5., 5., 5.}, want: 0.},
+		"large offset":    {n: 4, inputs: []float64{1e9 + 2., 1e9 + 8., 1e9}, want: 3.13535},
+		"single period":   {n: 1, inputs: []float64{2., 8.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewExponentialStandardDeviation(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialStandardDeviationString(t *testing.T) {
+	sd, _ := NewExponentialStandardDeviation(4)
+	want := "ESD(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+ExponentialVarianceOf returns the exponentially weighted variance of the inputs, in which the weight of
+each input decays by a factor of (1 - α) every period, like in ExponentialMovingAverage. It reacts to
+shocks quicker than the equally weighted variance of a window.
+
+# Formula
+
+* _EMA<sub>t</sub>_ = EMA<sub>t-1</sub> + α·(p<sub>t</sub> - EMA<sub>t-1</sub>)
+* _EVar<sub>t</sub>_ = (1 - α)·(EVar<sub>t-1</sub> + α·(p<sub>t</sub> - EMA<sub>t-1</sub>)<sup>2</sup>)
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+* _α_ - smoothing factor, 2 / (n + 1) unless set with WithAlpha
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _options_ - optional ExponentialOption values, see ExponentialMovingAverage and WithBiasCorrection
+
+# Example
+```
+v, _ := NewExponentialVariance(20, WithAlpha(0.06))
+v.Next(0.01)
+```
+*/
+type ExponentialVarianceOf[T Float] struct {
+	exponentialWindow[T]
+}
+
+// ExponentialVariance is an ExponentialVarianceOf float64 values
+type ExponentialVariance = ExponentialVarianceOf[float64]
+
+// NewExponentialVariance creates a new ExponentialVariance with the given number of periods and options
+// Example: NewExponentialVariance(20, WithAlpha(0.06))
+func NewExponentialVariance(n int, options ...ExponentialOption) (*ExponentialVariance, error) {
+	return NewExponentialVarianceOf[float64](n, options...)
+}
+
+// NewExponentialVarianceOf creates a new ExponentialVarianceOf values of type T with the given number of periods and options
+// Example: NewExponentialVarianceOf[float32](20, WithBiasCorrection())
+func NewExponentialVarianceOf[T Float](n int, options ...ExponentialOption) (*ExponentialVarianceOf[T], error) {
+	window, err := newExponentialWindow[T](n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExponentialVarianceOf[T]{
+		exponentialWindow: window,
+	}, nil
+}
+
+// Next takes the next input and returns the next ExponentialVariance value
+func (v *ExponentialVarianceOf[T]) Next(input T) T {
+	v.push(input, input)
+	return v.variance(v.moments)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (v *ExponentialVarianceOf[T]) Peek(input T) T {
+	moments := v.peek(input, input)
+	return v.variance(moments)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExponentialVariance value.
+// If no input has been committed yet it behaves like Next.
+func (v *ExponentialVarianceOf[T]) UpdateLast(input T) T {
+	v.replaceLast(input, input)
+	return v.variance(v.moments)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (v *ExponentialVarianceOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = v.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (v *ExponentialVarianceOf[T]) Reset() {
+	v.reset()
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (v *ExponentialVarianceOf[T]) Clone() *ExponentialVarianceOf[T] {
+	clone := *v
+	return &clone
+}
+
+func (v *ExponentialVarianceOf[T]) String() string {
+	return v.config.name("EVar", v.n)
+}
+
+// ComputeExponentialVariance computes an ExponentialVariance with n periods and options over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before n inputs have been seen, are set to NaN.
+func ComputeExponentialVariance[T Float](n int, inputs, out []T, fillNaN bool, options ...ExponentialOption) ([]T, error) {
+	v, err := NewExponentialVarianceOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	out = v.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
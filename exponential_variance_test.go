@@ -0,0 +1,146 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExponentialVariance(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *ExponentialVariance
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &ExponentialVariance{exponentialWindow: exponentialWindow[float64]{n: 9, smoothing: exponentialSmoothing[float64]{alpha: 0.2, seed: 1}}}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewExponentialVariance(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestExponentialVarianceNext(t *testing.T) {
+	sd, _ := NewExponentialVariance(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 8.64},
+		{input: 0., want: 9.8304},
+		{input: 4., want: 6.34214},
+		{input: 1., want: 4.95005},
+		{input: 9., want: 13.7102},
+		{input: 9., want: 12.0926},
+		{input: 0., want: 17.6838},
+		{input: 3., want: 10.8292},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialVarianceOptions(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	tests := map[string]struct {
+		options []ExponentialOption
+		want    []float64
+	}{
+		"alpha":           {options: []ExponentialOption{WithAlpha(0.06)}, want: []float64{0., 2.0304, 2.2227, 2.26836, 2.23132, 4.67038, 6.66361, 6.78227, 6.3766, 6.25252, 6.78542, 6.65833}},
+		"seed mean":       {options: []ExponentialOption{WithSeeding(SeedMean)}, want: []float64{0., 9., 11.5556, 8.75, 6.75, 14.19, 12.1644, 17.944, 11.0045, 7.0747, 6.18253, 8.13708}},
+		"bias correction": {options: []ExponentialOption{WithBiasCorrection()}, want: []float64{0., 18., 15.0588, 8.87003, 6.71282, 18.3915, 16.1586, 23.5969, 14.443, 9.3159, 8.19976, 10.799}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, err := NewExponentialVariance(4, tc.options...)
+			assert.NoError(t, err)
+			diff := cmp.Diff(tc.want, sd.Compute(inputs, nil), approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeExponentialVariance(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 8.64, 9.8304, 6.34214, 4.95005}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 6.34214, 4.95005}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeExponentialVariance(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialVarianceInputs(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"constant values": {n: 4, inputs: []float64{5., 5., 5., 5.}, want: 0.},
+		"large offset":    {n: 4, inputs: []float64{1e9 + 2., 1e9 + 8., 1e9}, want: 9.8304},
+		"single period":   {n: 1, inputs: []float64{2., 8.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewExponentialVariance(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialVarianceString(t *testing.T) {
+	sd, _ := NewExponentialVariance(4)
+	want := "EVar(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
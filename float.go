@@ -0,0 +1,11 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+// Float is the set of floating point types the indicators can be computed on
+type Float interface {
+	~float32 | ~float64
+}
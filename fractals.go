@@ -0,0 +1,188 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+FractalsOf returns Bill Williams' fractals: bars whose high is above the highs of the _n_ bars on either side,
+an up fractal, or whose low is below their lows, a down fractal. A fractal is confirmed _n_ bars after it,
+so the values returned for a bar belong to the bar _n_ bars before it.
+
+# Formula
+
+Up<sub>t-n</sub> = H<sub>t-n</sub> if H<sub>t-n</sub> > max(H<sub>t-2n</sub> … H<sub>t-n-1</sub>) and H<sub>t-n</sub> > max(H<sub>t-n+1</sub> … H<sub>t</sub>)
+
+Down<sub>t-n</sub> = L<sub>t-n</sub> if L<sub>t-n</sub> < min(L<sub>t-2n</sub> … L<sub>t-n-1</sub>) and L<sub>t-n</sub> < min(L<sub>t-n+1</sub> … L<sub>t</sub>)
+
+Where:
+
+* _H_, _L_ - high and low of a bar
+
+Otherwise, and until 2n+1 bars have been seen, the value is NaN.
+
+# Parameters
+
+* _n_ - number of bars on either side of a fractal (integer greater than 0, usually 2)
+
+# Example
+```
+fractals, _ := NewFractals(2)
+up, down := fractals.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type FractalsOf[T Float] struct {
+	// number of bars on either side of a fractal (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	highsBefore *MaximumOf[T]
+	highsAfter  *MaximumOf[T]
+	lowsBefore  *MinimumOf[T]
+	lowsAfter   *MinimumOf[T]
+
+	// highs and lows n and n+1 bars ago
+	highs, earlierHighs lookback[T]
+	lows, earlierLows   lookback[T]
+	count               int
+}
+
+// Fractals is a FractalsOf float64 values
+type Fractals = FractalsOf[float64]
+
+// NewFractals creates a new Fractals with the given number of bars on either side
+// Example: NewFractals(2)
+func NewFractals(n int) (*Fractals, error) {
+	return NewFractalsOf[float64](n)
+}
+
+// NewFractalsOf creates a new FractalsOf values of type T with the given number of bars on either side
+// Example: NewFractalsOf[float32](2)
+func NewFractalsOf[T Float](n int) (*FractalsOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	highsBefore, _ := NewMaximumOf[T](n)
+	highsAfter, _ := NewMaximumOf[T](n)
+	lowsBefore, _ := NewMinimumOf[T](n)
+	lowsAfter, _ := NewMinimumOf[T](n)
+	return &FractalsOf[T]{
+		n: n,
+
+		highsBefore: highsBefore,
+		highsAfter:  highsAfter,
+		lowsBefore:  lowsBefore,
+		lowsAfter:   lowsAfter,
+
+		highs:        newLookback[T](n),
+		earlierHighs: newLookback[T](n + 1),
+		lows:         newLookback[T](n),
+		earlierLows:  newLookback[T](n + 1),
+	}, nil
+}
+
+// Next takes the next bar and returns the high and the low of the bar n bars before it
+// if they are up and down fractals, or NaN
+func (f *FractalsOf[T]) Next(bar BarOf[T]) (up, down T) {
+	f.count++
+	return f.fractals(f.count,
+		f.highs.push(bar.High), f.highsBefore.Next(f.earlierHighs.push(bar.High)), f.highsAfter.Next(bar.High),
+		f.lows.push(bar.Low), f.lowsBefore.Next(f.earlierLows.push(bar.Low)), f.lowsAfter.Next(bar.Low))
+}
+
+// Peek returns the values Next would return for bar without committing it
+func (f *FractalsOf[T]) Peek(bar BarOf[T]) (up, down T) {
+	return f.fractals(f.count+1,
+		f.highs.peek(bar.High), f.highsBefore.Peek(f.earlierHighs.peek(bar.High)), f.highsAfter.Peek(bar.High),
+		f.lows.peek(bar.Low), f.lowsBefore.Peek(f.earlierLows.peek(bar.Low)), f.lowsAfter.Peek(bar.Low))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated values. If no bar has been committed yet it behaves like Next.
+func (f *FractalsOf[T]) UpdateLast(bar BarOf[T]) (up, down T) {
+	if f.count == 0 {
+		return f.Next(bar)
+	}
+
+	return f.fractals(f.count,
+		f.highs.replaceLast(bar.High), f.highsBefore.UpdateLast(f.earlierHighs.replaceLast(bar.High)), f.highsAfter.UpdateLast(bar.High),
+		f.lows.replaceLast(bar.Low), f.lowsBefore.UpdateLast(f.earlierLows.replaceLast(bar.Low)), f.lowsAfter.UpdateLast(bar.Low))
+}
+
+// fractals compares the high and low of the bar n bars before the count-th one with the extremes
+// of the bars on either side of it
+func (f *FractalsOf[T]) fractals(count int, high, highBefore, highAfter, low, lowBefore, lowAfter T) (up, down T) {
+	up, down = T(math.NaN()), T(math.NaN())
+	if count < 2*f.n+1 {
+		return up, down
+	}
+
+	if high > highBefore && high > highAfter {
+		up = high
+	}
+	if low < lowBefore && low < lowAfter {
+		down = low
+	}
+	return up, down
+}
+
+// Compute feeds every bar of bars through Next and writes the results to up and down, which are reused
+// when they have enough capacity. It returns them resliced to the length of bars.
+func (f *FractalsOf[T]) Compute(bars []BarOf[T], up, down []T) ([]T, []T) {
+	up = resize(up, len(bars))
+	down = resize(down, len(bars))
+	for i, bar := range bars {
+		up[i], down[i] = f.Next(bar)
+	}
+	return up, down
+}
+
+// Reset resets the indicators to a clean state
+func (f *FractalsOf[T]) Reset() {
+	f.highsBefore.Reset()
+	f.highsAfter.Reset()
+	f.lowsBefore.Reset()
+	f.lowsAfter.Reset()
+	f.highs.reset()
+	f.earlierHighs.reset()
+	f.lows.reset()
+	f.earlierLows.reset()
+	f.count = 0
+}
+
+// Clone returns a deep copy of the indicator, including its Maximum and Minimum windows
+func (f *FractalsOf[T]) Clone() *FractalsOf[T] {
+	clone := *f
+	clone.highsBefore = f.highsBefore.Clone()
+	clone.highsAfter = f.highsAfter.Clone()
+	clone.lowsBefore = f.lowsBefore.Clone()
+	clone.lowsAfter = f.lowsAfter.Clone()
+	clone.highs = f.highs.clone()
+	clone.earlierHighs = f.earlierHighs.clone()
+	clone.lows = f.lows.clone()
+	clone.earlierLows = f.earlierLows.clone()
+	return &clone
+}
+
+func (f *FractalsOf[T]) String() string {
+	return fmt.Sprintf("Fractals(%d)", f.n)
+}
+
+// ComputeFractals computes a Fractals with n bars on either side over bars and writes the results
+// to up and down (see Compute)
+func ComputeFractals[T Float](n int, bars []BarOf[T], up, down []T) ([]T, []T, error) {
+	f, err := NewFractalsOf[T](n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	up, down = f.Compute(bars, up, down)
+	return up, down, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// highLowBars returns bars with the given highs and lows, closing half way between them
+func highLowBars(highs, lows []float64) []Bar {
+	bars := make([]Bar, len(highs))
+	for i := range highs {
+		mid := (highs[i] + lows[i]) / 2
+		bars[i] = Bar{Time: at(float64(i)), Open: mid, High: highs[i], Low: lows[i], Close: mid}
+	}
+	return bars
+}
+
+var fractalBars = highLowBars(
+	[]float64{10., 11., 13., 12., 11., 12., 14., 13., 12., 11., 12.},
+	[]float64{9., 10., 12., 10., 9., 10., 12., 11., 10., 9., 10.},
+)
+
+func TestNewFractals(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		wantErr error
+	}{
+		"negative n": {input: -1, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, wantErr: ErrInvalidParameters},
+		"positive n": {input: 2, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewFractals(tc.input)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestFractalsNext(t *testing.T) {
+	nan := math.NaN()
+	fractals, _ := NewFractals(2)
+	wantUp := []float64{nan, nan, nan, nan, 13., nan, nan, nan, 14., nan, nan}
+	wantDown := []float64{nan, nan, nan, nan, nan, nan, 9., nan, nan, nan, nan}
+
+	gotUp := make([]float64, len(fractalBars))
+	gotDown := make([]float64, len(fractalBars))
+	for i, bar := range fractalBars {
+		gotUp[i], gotDown[i] = fractals.Next(bar)
+	}
+	if diff := cmp.Diff(wantUp, gotUp, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	if diff := cmp.Diff(wantDown, gotDown, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestFractalsTies(t *testing.T) {
+	fractals, _ := NewFractals(1)
+	bars := highLowBars([]float64{10., 12., 12., 11.}, []float64{9., 8., 8., 9.})
+	up, down := fractals.Compute(bars, nil, nil)
+	for i := range bars {
+		assert.True(t, math.IsNaN(up[i]), "must not confirm a high equal to a neighbour")
+		assert.True(t, math.IsNaN(down[i]), "must not confirm a low equal to a neighbour")
+	}
+}
+
+func TestFractalsPeek(t *testing.T) {
+	fractals, _ := NewFractals(2)
+	for _, bar := range fractalBars {
+		fractals.Peek(Bar{High: 100., Low: 0.}) // must not be committed
+		up, down := fractals.Peek(bar)
+		wantUp, wantDown := fractals.Next(bar)
+		if diff := cmp.Diff([]float64{wantUp, wantDown}, []float64{up, down}, nanComparer); diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+}
+
+func TestFractalsUpdateLast(t *testing.T) {
+	fractals, _ := NewFractals(2)
+	fractals.Compute(fractalBars[:4], nil, nil)
+	up, _ := fractals.Next(fractalBars[4])
+	assert.Equal(t, 13., up)
+	up, _ = fractals.UpdateLast(Bar{High: 14., Low: 10.})
+	assert.True(t, math.IsNaN(up), "must undo the fractal")
+	up, _ = fractals.UpdateLast(fractalBars[4])
+	assert.Equal(t, 13., up, "must redo the fractal")
+
+	empty, _ := NewFractals(2)
+	up, down := empty.UpdateLast(fractalBars[0])
+	assert.True(t, math.IsNaN(up) && math.IsNaN(down), "must behave like Next when empty")
+}
+
+func TestFractalsCompute(t *testing.T) {
+	stream, _ := NewFractals(2)
+	wantUp := make([]float64, len(fractalBars))
+	wantDown := make([]float64, len(fractalBars))
+	for i, bar := range fractalBars {
+		wantUp[i], wantDown[i] = stream.Next(bar)
+	}
+
+	batch, _ := NewFractals(2)
+	up := make([]float64, len(fractalBars))
+	gotUp, gotDown := batch.Compute(fractalBars, up, nil)
+	if diff := cmp.Diff(wantUp, gotUp, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	if diff := cmp.Diff(wantDown, gotDown, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Same(t, &up[0], &gotUp[0], "must reuse the output buffer")
+
+	_, _, err := ComputeFractals(0, fractalBars, nil, nil)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	gotUp, _, err = ComputeFractals(2, fractalBars, nil, nil)
+	assert.NoError(t, err)
+	if diff := cmp.Diff(wantUp, gotUp, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestFractalsClone(t *testing.T) {
+	fractals, _ := NewFractals(2)
+	fractals.Compute(fractalBars[:8], nil, nil)
+	want, _ := NewFractals(2)
+	want.Compute(fractalBars[:8], nil, nil)
+
+	clone := fractals.Clone()
+	up, _ := clone.Next(fractalBars[8])
+	assert.Equal(t, 14., up, "clone must continue from the same state")
+	assert.Equal(t, want, fractals, "original must not see the clone's inputs")
+}
+
+func TestFractalsReset(t *testing.T) {
+	fractals, _ := NewFractals(2)
+	fractals.Compute(fractalBars, nil, nil)
+	fractals.Reset()
+
+	want, _ := NewFractals(2)
+	assert.Equal(t, want, fractals, "must return to a clean state")
+}
+
+func TestFractalsString(t *testing.T) {
+	fractals, _ := NewFractals(2)
+	assert.Equal(t, "Fractals(2)", fractals.String())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+GarmanKlassOf returns the Garman-Klass volatility of the last _n_ bars, estimated from their open, high,
+low and close prices. It is more efficient than Parkinson, but it also assumes no drift and no opening jumps.
+
+# Formula
+
+σ<sup>2</sup> = Σ (½·ln(H<sub>i</sub> / L<sub>i</sub>)<sup>2</sup> - (2·ln 2 - 1)·ln(C<sub>i</sub> / O<sub>i</sub>)<sup>2</sup>) / N
+
+Where:
+
+* _O<sub>i</sub>_, _H<sub>i</sub>_, _L<sub>i</sub>_, _C<sub>i</sub>_ - open, high, low and close of the bar
+* _N_ - number of bars in observation.
+
+The result is the volatility of a single period, see HistoricalVolatility to annualise it.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+gk, _ := NewGarmanKlass(20)
+gk.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type GarmanKlassOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	ma *MovingAverageOf[T]
+}
+
+// GarmanKlass is a GarmanKlassOf float64 values
+type GarmanKlass = GarmanKlassOf[float64]
+
+// NewGarmanKlass creates a new GarmanKlass with the given number of periods
+// Example: NewGarmanKlass(20)
+func NewGarmanKlass(n int) (*GarmanKlass, error) {
+	return NewGarmanKlassOf[float64](n)
+}
+
+// NewGarmanKlassOf creates a new GarmanKlassOf values of type T with the given number of periods
+// Example: NewGarmanKlassOf[float32](20)
+func NewGarmanKlassOf[T Float](n int) (*GarmanKlassOf[T], error) {
+	ma, err := NewMovingAverageOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GarmanKlassOf[T]{
+		n: n,
+
+		ma: ma,
+	}, nil
+}
+
+// Next takes the next bar and returns the next GarmanKlass value
+func (gk *GarmanKlassOf[T]) Next(bar BarOf[T]) T {
+	return volatility(gk.ma.Next(garmanKlassTerm(bar)))
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (gk *GarmanKlassOf[T]) Peek(bar BarOf[T]) T {
+	return volatility(gk.ma.Peek(garmanKlassTerm(bar)))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated GarmanKlass value. If no bar has been committed yet it behaves like Next.
+func (gk *GarmanKlassOf[T]) UpdateLast(bar BarOf[T]) T {
+	return volatility(gk.ma.UpdateLast(garmanKlassTerm(bar)))
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (gk *GarmanKlassOf[T]) Compute(bars []BarOf[T], out []T) []T {
+	return computeBars(gk.Next, bars, out)
+}
+
+// Reset resets the indicators to a clean state
+func (gk *GarmanKlassOf[T]) Reset() {
+	gk.ma.Reset()
+}
+
+// Clone returns a deep copy of the indicator, including its MovingAverage
+func (gk *GarmanKlassOf[T]) Clone() *GarmanKlassOf[T] {
+	clone := *gk
+	clone.ma = gk.ma.Clone()
+	return &clone
+}
+
+func (gk *GarmanKlassOf[T]) String() string {
+	return fmt.Sprintf("GarmanKlass(%d)", gk.n)
+}
+
+// ComputeGarmanKlass computes a GarmanKlass with n periods over bars and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeGarmanKlass[T Float](n int, bars []BarOf[T], out []T, fillNaN bool) ([]T, error) {
+	gk, err := NewGarmanKlassOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = gk.Compute(bars, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// garmanKlassTerm returns the contribution of bar to the Garman-Klass variance
+func garmanKlassTerm[T Float](bar BarOf[T]) T {
+	hl := logRatio(bar.High, bar.Low)
+	co := logRatio(bar.Close, bar.Open)
+	return hl*hl/2 - T(2*math.Ln2-1)*co*co
+}
@@ -1,8 +1,15 @@
 module github.com/binhnguyenduc/tago
 
-go 1.13
+go 1.18
 
 require (
 	github.com/google/go-cmp v0.4.1
 	github.com/stretchr/testify v1.5.1
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+/*
+HeikinAshiOf turns bars into Heikin-Ashi bars, which average each bar with the previous Heikin-Ashi bar to
+smooth out the noise of a trend. It emits one bar per input bar, with the time and volume of the input.
+
+# Formula
+
+C<sub>HA</sub> = (O + H + L + C) / 4
+
+O<sub>HA</sub> = (O<sub>HA,-1</sub> + C<sub>HA,-1</sub>) / 2
+
+H<sub>HA</sub> = max(H, O<sub>HA</sub>, C<sub>HA</sub>)
+
+L<sub>HA</sub> = min(L, O<sub>HA</sub>, C<sub>HA</sub>)
+
+Where:
+
+* _O_, _H_, _L_, _C_ - open, high, low and close of the bar
+* _O<sub>HA,-1</sub>_, _C<sub>HA,-1</sub>_ - open and close of the previous Heikin-Ashi bar, the open of
+the first Heikin-Ashi bar is (O + C) / 2
+
+# Example
+```
+ha := NewHeikinAshi()
+bars := ha.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type HeikinAshiOf[T Float] struct {
+	// internal parameters for calculations
+	last  BarOf[T]
+	count int
+
+	// previous Heikin-Ashi bar, for UpdateLast
+	previous BarOf[T]
+
+	out []BarOf[T]
+}
+
+// HeikinAshi is a HeikinAshiOf float64 values
+type HeikinAshi = HeikinAshiOf[float64]
+
+// NewHeikinAshi creates a new HeikinAshi
+func NewHeikinAshi() *HeikinAshi {
+	return NewHeikinAshiOf[float64]()
+}
+
+// NewHeikinAshiOf creates a new HeikinAshiOf values of type T
+func NewHeikinAshiOf[T Float]() *HeikinAshiOf[T] {
+	return &HeikinAshiOf[T]{}
+}
+
+// Next takes the next bar and returns its Heikin-Ashi bar. The returned slice is reused by the next call.
+func (ha *HeikinAshiOf[T]) Next(bar BarOf[T]) []BarOf[T] {
+	ha.previous = ha.last
+	ha.last = heikinAshi(bar, ha.previous, ha.count > 0)
+	ha.count++
+
+	ha.out = append(ha.out[:0], ha.last)
+	return ha.out
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress, and returns its
+// updated Heikin-Ashi bar. If no bar has been committed yet it behaves like Next.
+func (ha *HeikinAshiOf[T]) UpdateLast(bar BarOf[T]) BarOf[T] {
+	if ha.count == 0 {
+		return ha.Next(bar)[0]
+	}
+
+	ha.last = heikinAshi(bar, ha.previous, ha.count > 1)
+	return ha.last
+}
+
+// Reset resets the transformer to a clean state
+func (ha *HeikinAshiOf[T]) Reset() {
+	ha.last = BarOf[T]{}
+	ha.count = 0
+	ha.previous = BarOf[T]{}
+}
+
+// Clone returns a copy of the transformer that can be advanced independently
+func (ha *HeikinAshiOf[T]) Clone() *HeikinAshiOf[T] {
+	clone := *ha
+	clone.out = nil
+	return &clone
+}
+
+func (ha *HeikinAshiOf[T]) String() string {
+	return "HeikinAshi"
+}
+
+// heikinAshi returns the Heikin-Ashi bar of bar following the Heikin-Ashi bar previous, if there is one
+func heikinAshi[T Float](bar, previous BarOf[T], hasPrevious bool) BarOf[T] {
+	open := (bar.Open + bar.Close) / 2
+	if hasPrevious {
+		open = (previous.Open + previous.Close) / 2
+	}
+	close := (bar.Open + bar.High + bar.Low + bar.Close) / 4
+
+	high, low := bar.High, bar.Low
+	for _, v := range []T{open, close} {
+		if v > high {
+			high = v
+		}
+		if v < low {
+			low = v
+		}
+	}
+
+	return BarOf[T]{
+		Time:   bar.Time,
+		Open:   open,
+		High:   high,
+		Low:    low,
+		Close:  close,
+		Volume: bar.Volume,
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeikinAshiNext(t *testing.T) {
+	ha := NewHeikinAshi()
+	tests := []struct {
+		input Bar
+		want  []Bar
+	}{
+		{
+			input: Bar{Time: at(0), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 100.},
+			want:  []Bar{{Time: at(0), Open: 10.25, High: 11., Low: 9.5, Close: 10.25, Volume: 100.}},
+		},
+		{
+			input: Bar{Time: at(60), Open: 10.6, High: 11.2, Low: 10.1, Close: 11., Volume: 200.},
+			want:  []Bar{{Time: at(60), Open: 10.25, High: 11.2, Low: 10.1, Close: 10.725, Volume: 200.}},
+		},
+		{
+			input: Bar{Time: at(120), Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6, Volume: 150.},
+			want:  []Bar{{Time: at(120), Open: 10.4875, High: 11.5, Low: 10.4, Close: 10.925, Volume: 150.}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := ha.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestHeikinAshiUpdateLast(t *testing.T) {
+	ha := NewHeikinAshi()
+	want := Bar{Time: at(0), Open: 10.25, High: 11., Low: 9.5, Close: 10.25}
+	got := ha.UpdateLast(Bar{Time: at(0), Open: 10., High: 11., Low: 9.5, Close: 10.5})
+	assert.Equal(t, want, got, "must behave like Next when empty")
+
+	ha.Next(Bar{Time: at(60), Open: 10.6, High: 11.2, Low: 10.1, Close: 11.})
+	want = Bar{Time: at(60), Open: 10.25, High: 11.2, Low: 10.1, Close: 10.975}
+	got = ha.UpdateLast(Bar{Time: at(60), Open: 10.6, High: 11.2, Low: 10.1, Close: 12.})
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	want = Bar{Time: at(120), Open: 10.6125, High: 11.5, Low: 10.4, Close: 10.925}
+	got = ha.Next(Bar{Time: at(120), Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6})[0]
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestHeikinAshiClone(t *testing.T) {
+	bars := []Bar{
+		{Time: at(0), Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		{Time: at(60), Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		{Time: at(120), Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+	}
+	ha := NewHeikinAshi()
+	ha.Next(bars[0])
+	clone := ha.Clone()
+
+	want := TransformBars[float64](ha, bars[1:], nil)
+	got := TransformBars[float64](clone, bars[1:], nil)
+	assert.Equal(t, want, got, "clone must continue from the same state")
+}
+
+func TestHeikinAshiReset(t *testing.T) {
+	ha := NewHeikinAshi()
+	ha.Next(Bar{Time: at(0), Open: 10., High: 11., Low: 9.5, Close: 10.5})
+	ha.Next(Bar{Time: at(60), Open: 10.6, High: 11.2, Low: 10.1, Close: 11.})
+	ha.Reset()
+	ha.out = nil
+
+	assert.Equal(t, NewHeikinAshi(), ha, "must return to a clean state")
+}
+
+func TestHeikinAshiString(t *testing.T) {
+	assert.Equal(t, "HeikinAshi", NewHeikinAshi().String())
+}
@@ -9,6 +9,7 @@ import (
 	"math"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 const (
@@ -23,3 +24,80 @@ var floatComparer = cmp.Comparer(func(x, y float64) bool {
 	}
 	return (diff / mean) < tolerance
 })
+
+// nanComparer compares float slices within tolerance, treating NaN values as equal
+var nanComparer = cmp.Options{cmpopts.EquateNaNs(), cmpopts.EquateApprox(tolerance, 0)}
+
+// approxComparer compares floats within tolerance, or within a small margin of zero
+var approxComparer = cmpopts.EquateApprox(tolerance, 1e-9)
+
+// isFinite reports whether x is neither NaN nor infinite
+func isFinite[T Float](x T) bool {
+	return !math.IsNaN(float64(x)) && !math.IsInf(float64(x), 0)
+}
+
+// cloneSlice returns a copy of data with the same length and capacity
+func cloneSlice[T any](data []T) []T {
+	if data == nil {
+		return nil
+	}
+	clone := make([]T, len(data), cap(data))
+	copy(clone, data)
+	return clone
+}
+
+// resize returns out resliced to length n, allocating a new slice only if its capacity is too small
+func resize[E any](out []E, n int) []E {
+	if cap(out) < n {
+		return make([]E, n)
+	}
+	return out[:n]
+}
+
+// fillWarmup sets the first n values of out to NaN
+func fillWarmup[T Float](out []T, n int) {
+	for i := 0; i < n && i < len(out); i++ {
+		out[i] = T(math.NaN())
+	}
+}
+
+// windowSum returns the sum of the count most recent values of the ring buffer data, the last at index, taking last
+// as the value at index. It accumulates in float64 so that running sums of float32 values resynchronised from it
+// stay accurate.
+func windowSum[T Float](data []T, index, count int, last T) T {
+	sum := 0.
+	for k := 0; k < count; k++ {
+		sum += float64(windowValue(data, index, k, last))
+	}
+	return T(sum)
+}
+
+// windowMoments returns the mean of the count most recent values of the ring buffer data, the last at index,
+// taking last as the value at index, and the sum of their squared deviations from it, computed in float64 with
+// two passes
+func windowMoments[T Float](data []T, index, count int, last T) (mean, m2 T) {
+	if count == 0 {
+		return 0, 0
+	}
+
+	sum := 0.
+	for k := 0; k < count; k++ {
+		sum += float64(windowValue(data, index, k, last))
+	}
+	m := sum / float64(count)
+
+	sum = 0.
+	for k := 0; k < count; k++ {
+		d := float64(windowValue(data, index, k, last)) - m
+		sum += d * d
+	}
+	return T(m), T(sum)
+}
+
+// windowValue returns the value k periods before the one at index of the ring buffer data, or last for k = 0
+func windowValue[T Float](data []T, index, k int, last T) T {
+	if k == 0 {
+		return last
+	}
+	return data[(index-k+len(data))%len(data)]
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// benchmarkInputs returns a deterministic price series for benchmarks
+func benchmarkInputs() []float64 {
+	inputs := make([]float64, 10000)
+	for i := range inputs {
+		inputs[i] = 100. + 10.*math.Sin(float64(i)/50.) + float64(i%7)
+	}
+	return inputs
+}
+
+// driftInputs returns a long deterministic series of prices around 1e4, on which running sums that are
+// never recomputed drift far from the values of a fresh window, in float32 in particular
+func driftInputs[T Float](n int) []T {
+	inputs := make([]T, n)
+	for i := range inputs {
+		inputs[i] = T(1e4 + 5.*math.Sin(float64(i)/7.) + float64(i*7919%101)/25.)
+	}
+	return inputs
+}
+
+// testLongStream feeds driftInputs to x, an indicator of float32 values over a window of n, and checks that it
+// ends within epsilon, relatively, of fresh, an indicator of float64 values fed only the last n inputs
+func testLongStream[X interface{ Next(input float32) float32 }](t *testing.T, x X, fresh Indicator, n int, epsilon float64) {
+	inputs := driftInputs[float32](3000007)
+	var got float32
+	for _, input := range inputs {
+		got = x.Next(input)
+	}
+
+	var want float64
+	for _, input := range inputs[len(inputs)-n:] {
+		want = fresh.Next(float64(input))
+	}
+	assert.InEpsilon(t, want, float64(got), epsilon, "must match a fresh window after a long stream")
+}
+
+// testPairLongStream is testLongStream for indicators over two series, fed driftInputs and the same inputs
+// one period later
+func testPairLongStream[X interface{ Next(x, y float32) float32 }](t *testing.T, x X, fresh interface{ Next(x, y float64) float64 }, n int, epsilon float64) {
+	inputs := driftInputs[float32](3000008)
+	xs, ys := inputs[1:], inputs[:len(inputs)-1]
+	var got float32
+	for i := range xs {
+		got = x.Next(xs[i], ys[i])
+	}
+
+	var want float64
+	for i := len(xs) - n; i < len(xs); i++ {
+		want = fresh.Next(float64(xs[i]), float64(ys[i]))
+	}
+	assert.InEpsilon(t, want, float64(got), epsilon, "must match a fresh window after a long stream")
+}
+
+// testTimedLongStream is testLongStream for the time-windowed indicators, fed driftInputs one second apart
+// through a window of n seconds
+func testTimedLongStream[X interface {
+	Next(input TimedValueOf[float32]) float32
+}](t *testing.T, x X, fresh Indicator, n int, epsilon float64) {
+	inputs := driftInputs[float32](3000007)
+	var got float32
+	for i, input := range inputs {
+		got = x.Next(TimedValueOf[float32]{Time: at(float64(i)), Value: input})
+	}
+
+	var want float64
+	for _, input := range inputs[len(inputs)-n:] {
+		want = fresh.Next(float64(input))
+	}
+	assert.InEpsilon(t, want, float64(got), epsilon, "must match a fresh window after a long stream")
+}
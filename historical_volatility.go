@@ -0,0 +1,174 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+HistoricalVolatilityOf returns the annualised close-to-close volatility of the last _n_ log returns of its
+inputs: their sample standard deviation scaled by the square root of the number of periods in a year.
+
+# Formula
+
+HV = √(P · Σ(r<sub>i</sub> - r̄)<sup>2</sup> / (N - 1)), r<sub>i</sub> = ln(p<sub>i</sub> / p<sub>i-1</sub>)
+
+Where:
+
+* _p<sub>i</sub>_ - input value, usually the close
+* _P_ - number of periods per year
+* _N_ - number of returns in observation.
+
+The volatility is 0 until there are two returns.
+
+# Parameters
+
+* _n_ - number of returns (integer greater than 0)
+* _periodsPerYear_ - number of periods in a year, such as 252 for daily or 52 for weekly inputs (greater than 0)
+
+# Example
+```
+hv, _ := NewHistoricalVolatility(20, 252)
+hv.Next(10.)
+```
+*/
+type HistoricalVolatilityOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n              int
+	periodsPerYear T
+
+	// internal parameters for calculations
+	sd *StandardDeviationOf[T]
+
+	// number of inputs, counted up to 2, the most recent input and the input before it
+	count    int
+	last     T
+	previous T
+}
+
+// HistoricalVolatility is a HistoricalVolatilityOf float64 values
+type HistoricalVolatility = HistoricalVolatilityOf[float64]
+
+// NewHistoricalVolatility creates a new HistoricalVolatility with the given number of returns and periods per year
+// Example: NewHistoricalVolatility(20, 252)
+func NewHistoricalVolatility(n int, periodsPerYear float64) (*HistoricalVolatility, error) {
+	return NewHistoricalVolatilityOf(n, periodsPerYear)
+}
+
+// NewHistoricalVolatilityOf creates a new HistoricalVolatilityOf values of type T with the given number of returns and periods per year
+// Example: NewHistoricalVolatilityOf[float32](20, 252)
+func NewHistoricalVolatilityOf[T Float](n int, periodsPerYear T) (*HistoricalVolatilityOf[T], error) {
+	if n <= 0 || !(periodsPerYear > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	sd, err := NewStandardDeviationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoricalVolatilityOf[T]{
+		n:              n,
+		periodsPerYear: periodsPerYear,
+
+		sd: sd,
+	}, nil
+}
+
+// Next takes the next input and returns the next HistoricalVolatility value
+func (hv *HistoricalVolatilityOf[T]) Next(input T) T {
+	if hv.count < 2 {
+		hv.count++
+	}
+	hv.previous, hv.last = hv.last, input
+	if hv.count < 2 {
+		return 0
+	}
+
+	return hv.annualise(hv.sd.Next(logRatio(input, hv.previous)), hv.sd.count)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (hv *HistoricalVolatilityOf[T]) Peek(input T) T {
+	if hv.count == 0 {
+		return 0
+	}
+
+	count := hv.sd.count
+	if count < hv.n {
+		count++
+	}
+	return hv.annualise(hv.sd.Peek(logRatio(input, hv.last)), count)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated HistoricalVolatility value.
+// If no input has been committed yet it behaves like Next.
+func (hv *HistoricalVolatilityOf[T]) UpdateLast(input T) T {
+	if hv.count == 0 {
+		return hv.Next(input)
+	}
+	if hv.count == 1 {
+		hv.last = input
+		return 0
+	}
+
+	hv.last = input
+	return hv.annualise(hv.sd.UpdateLast(logRatio(input, hv.previous)), hv.sd.count)
+}
+
+// annualise turns the population standard deviation of count returns into the annualised volatility
+func (hv *HistoricalVolatilityOf[T]) annualise(sd T, count int) T {
+	if count < 2 {
+		return 0
+	}
+	return sd * T(math.Sqrt(float64(hv.periodsPerYear*T(count)/T(count-1))))
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (hv *HistoricalVolatilityOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = hv.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (hv *HistoricalVolatilityOf[T]) Reset() {
+	hv.sd.Reset()
+
+	hv.count = 0
+	hv.last = 0
+	hv.previous = 0
+}
+
+// Clone returns a deep copy of the indicator, including its StandardDeviation
+func (hv *HistoricalVolatilityOf[T]) Clone() *HistoricalVolatilityOf[T] {
+	clone := *hv
+	clone.sd = hv.sd.Clone()
+	return &clone
+}
+
+func (hv *HistoricalVolatilityOf[T]) String() string {
+	return fmt.Sprintf("HV(%d,%g)", hv.n, float64(hv.periodsPerYear))
+}
+
+// ComputeHistoricalVolatility computes a HistoricalVolatility with n returns and periodsPerYear over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before there are n returns, are set to NaN.
+func ComputeHistoricalVolatility[T Float](n int, periodsPerYear T, inputs, out []T, fillNaN bool) ([]T, error) {
+	hv, err := NewHistoricalVolatilityOf(n, periodsPerYear)
+	if err != nil {
+		return nil, err
+	}
+
+	out = hv.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHistoricalVolatility(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *HistoricalVolatility
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &HistoricalVolatility{n: 9, periodsPerYear: 252., sd: &StandardDeviation{n: 9, data: make([]float64, 9)}}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewHistoricalVolatility(tc.input, 252.)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestHistoricalVolatilityNext(t *testing.T) {
+	sd, _ := NewHistoricalVolatility(4, 252.)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 10., want: 0.},
+		{input: 10.5, want: 0.},
+		{input: 10.2, want: 0.873053},
+		{input: 10.8, want: 0.754123},
+		{input: 10.6, want: 0.709244},
+		{input: 11.1, want: 0.698547},
+		{input: 11.4, want: 0.531206},
+		{input: 11., want: 0.606328},
+		{input: 11.6, want: 0.641575},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeHistoricalVolatility(t *testing.T) {
+	inputs := []float64{10., 10.5, 10.2, 10.8, 10.6}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 0., 0.873053, 0.754123, 0.709244}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.709244}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeHistoricalVolatility(tc.n, 252., inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestHistoricalVolatilityWindows(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"constant prices": {n: 4, inputs: []float64{10., 10., 10., 10., 10.}, want: 0.},
+		"constant growth": {n: 3, inputs: []float64{1., 2., 4., 8.}, want: 0.},
+		"scaled prices":   {n: 4, inputs: []float64{100., 105., 102.}, want: 0.873053},
+		"single return":   {n: 1, inputs: []float64{10., 11., 12.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewHistoricalVolatility(tc.n, 252.)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestHistoricalVolatilityString(t *testing.T) {
+	sd, _ := NewHistoricalVolatility(4, 252.)
+	want := "HV(4,252)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestNewHistoricalVolatilityPeriods(t *testing.T) {
+	for _, periodsPerYear := range []float64{0., -252., math.NaN()} {
+		got, err := NewHistoricalVolatility(4, periodsPerYear)
+		assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+		assert.Nil(t, got)
+	}
+}
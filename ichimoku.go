@@ -0,0 +1,244 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+// DisplacedOf is a value of type T computed at one bar that belongs to another, Offset bars after it,
+// or before it if Offset is negative
+type DisplacedOf[T Float] struct {
+	Value  T
+	Offset int
+}
+
+// Displaced is a DisplacedOf float64 values
+type Displaced = DisplacedOf[float64]
+
+// IchimokuLinesOf holds the lines of an Ichimoku computed at a bar, of values of type T
+type IchimokuLinesOf[T Float] struct {
+	// Tenkan and Kijun belong to the bar they are computed at
+	Tenkan T
+	Kijun  T
+
+	// SenkouA and SenkouB form the cloud displacement bars ahead
+	SenkouA DisplacedOf[T]
+	SenkouB DisplacedOf[T]
+
+	// Chikou is the close of the bar, displacement bars back
+	Chikou DisplacedOf[T]
+}
+
+// IchimokuLines is an IchimokuLinesOf float64 values
+type IchimokuLines = IchimokuLinesOf[float64]
+
+/*
+IchimokuOf returns the lines of Ichimoku Kinko Hyo: the midpoints of the highest high and lowest low of three
+windows, Tenkan-sen and Kijun-sen at the current bar and Senkou Span A and B displaced forward into the cloud,
+and Chikou Span, the close displaced backward. The displaced lines are tagged with the offset of the bar they
+belong to, and Cloud returns the spans that belong to the current bar, computed _displacement_ bars ago.
+
+# Formula
+
+* _Tenkan_ = (max(H, t) + min(L, t)) / 2
+* _Kijun_ = (max(H, k) + min(L, k)) / 2
+* _Senkou A_ = (Tenkan + Kijun) / 2, at offset +d
+* _Senkou B_ = (max(H, s) + min(L, s)) / 2, at offset +d
+* _Chikou_ = C, at offset -d
+
+Where:
+
+* _max(H, n)_, _min(L, n)_ - Maximum of the highs and Minimum of the lows of the last _n_ bars
+* _t_, _k_, _s_ - number of periods of Tenkan, Kijun and Senkou B
+* _d_ - displacement; charting packages that count the current bar, such as TradingView, displace by d - 1
+
+Until the windows are full the lines are midpoints of the bars seen so far.
+
+# Parameters
+
+* _tenkan_ - number of periods of Tenkan-sen (integer greater than 0, usually 9)
+* _kijun_ - number of periods of Kijun-sen (integer greater than 0, usually 26)
+* _senkouB_ - number of periods of Senkou Span B (integer greater than 0, usually 52)
+* _displacement_ - number of bars the spans are displaced by (integer greater than 0, usually 26)
+
+# Example
+```
+ichimoku, _ := NewIchimoku(9, 26, 52, 26)
+lines := ichimoku.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+a, b := ichimoku.Cloud()
+```
+*/
+type IchimokuOf[T Float] struct {
+	// number of periods of the lines (must be integers greater than 0)
+	tenkan       int
+	kijun        int
+	senkouB      int
+	displacement int
+
+	// internal parameters for calculations
+	tenkanHigh *MaximumOf[T]
+	tenkanLow  *MinimumOf[T]
+	kijunHigh  *MaximumOf[T]
+	kijunLow   *MinimumOf[T]
+	senkouHigh *MaximumOf[T]
+	senkouLow  *MinimumOf[T]
+	count      int
+	cloudA     lookback[T]
+	cloudB     lookback[T]
+	currentA   T
+	currentB   T
+}
+
+// Ichimoku is an IchimokuOf float64 values
+type Ichimoku = IchimokuOf[float64]
+
+// NewIchimoku creates a new Ichimoku with the given numbers of periods and displacement
+// Example: NewIchimoku(9, 26, 52, 26)
+func NewIchimoku(tenkan, kijun, senkouB, displacement int) (*Ichimoku, error) {
+	return NewIchimokuOf[float64](tenkan, kijun, senkouB, displacement)
+}
+
+// NewIchimokuOf creates a new IchimokuOf values of type T with the given numbers of periods and displacement
+// Example: NewIchimokuOf[float32](9, 26, 52, 26)
+func NewIchimokuOf[T Float](tenkan, kijun, senkouB, displacement int) (*IchimokuOf[T], error) {
+	if tenkan <= 0 || kijun <= 0 || senkouB <= 0 || displacement <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	ichimoku := &IchimokuOf[T]{
+		tenkan:       tenkan,
+		kijun:        kijun,
+		senkouB:      senkouB,
+		displacement: displacement,
+
+		cloudA: newLookback[T](displacement),
+		cloudB: newLookback[T](displacement),
+	}
+	ichimoku.tenkanHigh, _ = NewMaximumOf[T](tenkan)
+	ichimoku.tenkanLow, _ = NewMinimumOf[T](tenkan)
+	ichimoku.kijunHigh, _ = NewMaximumOf[T](kijun)
+	ichimoku.kijunLow, _ = NewMinimumOf[T](kijun)
+	ichimoku.senkouHigh, _ = NewMaximumOf[T](senkouB)
+	ichimoku.senkouLow, _ = NewMinimumOf[T](senkouB)
+	return ichimoku, nil
+}
+
+// Next takes the next bar and returns the lines computed at it
+func (ich *IchimokuOf[T]) Next(bar BarOf[T]) IchimokuLinesOf[T] {
+	ich.count++
+	lines := ich.lines(bar,
+		ich.tenkanHigh.Next(bar.High), ich.tenkanLow.Next(bar.Low),
+		ich.kijunHigh.Next(bar.High), ich.kijunLow.Next(bar.Low),
+		ich.senkouHigh.Next(bar.High), ich.senkouLow.Next(bar.Low))
+
+	ich.currentA = ich.cloudA.push(lines.SenkouA.Value)
+	ich.currentB = ich.cloudB.push(lines.SenkouB.Value)
+	return lines
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (ich *IchimokuOf[T]) Peek(bar BarOf[T]) IchimokuLinesOf[T] {
+	return ich.lines(bar,
+		ich.tenkanHigh.Peek(bar.High), ich.tenkanLow.Peek(bar.Low),
+		ich.kijunHigh.Peek(bar.High), ich.kijunLow.Peek(bar.Low),
+		ich.senkouHigh.Peek(bar.High), ich.senkouLow.Peek(bar.Low))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated lines. If no bar has been committed yet it behaves like Next.
+func (ich *IchimokuOf[T]) UpdateLast(bar BarOf[T]) IchimokuLinesOf[T] {
+	if ich.count == 0 {
+		return ich.Next(bar)
+	}
+
+	lines := ich.lines(bar,
+		ich.tenkanHigh.UpdateLast(bar.High), ich.tenkanLow.UpdateLast(bar.Low),
+		ich.kijunHigh.UpdateLast(bar.High), ich.kijunLow.UpdateLast(bar.Low),
+		ich.senkouHigh.UpdateLast(bar.High), ich.senkouLow.UpdateLast(bar.Low))
+
+	ich.currentA = ich.cloudA.replaceLast(lines.SenkouA.Value)
+	ich.currentB = ich.cloudB.replaceLast(lines.SenkouB.Value)
+	return lines
+}
+
+// lines returns the lines of bar given the extremes of its windows
+func (ich *IchimokuOf[T]) lines(bar BarOf[T], tenkanHigh, tenkanLow, kijunHigh, kijunLow, senkouHigh, senkouLow T) IchimokuLinesOf[T] {
+	tenkan := (tenkanHigh + tenkanLow) / 2
+	kijun := (kijunHigh + kijunLow) / 2
+	return IchimokuLinesOf[T]{
+		Tenkan: tenkan,
+		Kijun:  kijun,
+
+		SenkouA: DisplacedOf[T]{Value: (tenkan + kijun) / 2, Offset: ich.displacement},
+		SenkouB: DisplacedOf[T]{Value: (senkouHigh + senkouLow) / 2, Offset: ich.displacement},
+
+		Chikou: DisplacedOf[T]{Value: bar.Close, Offset: -ich.displacement},
+	}
+}
+
+// Cloud returns Senkou Span A and B at the last bar, those computed displacement bars before it,
+// or NaN until there are
+func (ich *IchimokuOf[T]) Cloud() (a, b T) {
+	if ich.count <= ich.displacement {
+		return T(math.NaN()), T(math.NaN())
+	}
+	return ich.currentA, ich.currentB
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (ich *IchimokuOf[T]) Compute(bars []BarOf[T], out []IchimokuLinesOf[T]) []IchimokuLinesOf[T] {
+	out = resize(out, len(bars))
+	for i, bar := range bars {
+		out[i] = ich.Next(bar)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (ich *IchimokuOf[T]) Reset() {
+	ich.tenkanHigh.Reset()
+	ich.tenkanLow.Reset()
+	ich.kijunHigh.Reset()
+	ich.kijunLow.Reset()
+	ich.senkouHigh.Reset()
+	ich.senkouLow.Reset()
+	ich.count = 0
+	ich.cloudA.reset()
+	ich.cloudB.reset()
+	ich.currentA, ich.currentB = 0, 0
+}
+
+// Clone returns a deep copy of the indicator, including its Maximum and Minimum windows
+func (ich *IchimokuOf[T]) Clone() *IchimokuOf[T] {
+	clone := *ich
+	clone.tenkanHigh = ich.tenkanHigh.Clone()
+	clone.tenkanLow = ich.tenkanLow.Clone()
+	clone.kijunHigh = ich.kijunHigh.Clone()
+	clone.kijunLow = ich.kijunLow.Clone()
+	clone.senkouHigh = ich.senkouHigh.Clone()
+	clone.senkouLow = ich.senkouLow.Clone()
+	clone.cloudA = ich.cloudA.clone()
+	clone.cloudB = ich.cloudB.clone()
+	return &clone
+}
+
+func (ich *IchimokuOf[T]) String() string {
+	return fmt.Sprintf("Ichimoku(%d,%d,%d,%d)", ich.tenkan, ich.kijun, ich.senkouB, ich.displacement)
+}
+
+// ComputeIchimoku computes an Ichimoku with the given numbers of periods and displacement over bars
+// and writes the results to out (see Compute)
+func ComputeIchimoku[T Float](tenkan, kijun, senkouB, displacement int, bars []BarOf[T], out []IchimokuLinesOf[T]) ([]IchimokuLinesOf[T], error) {
+	ich, err := NewIchimokuOf[T](tenkan, kijun, senkouB, displacement)
+	if err != nil {
+		return nil, err
+	}
+
+	return ich.Compute(bars, out), nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+var ichimokuBars = highLowBars(
+	[]float64{10., 12., 11., 13., 14., 12.},
+	[]float64{8., 9., 9., 10., 12., 10.},
+)
+
+// ichimokuLines returns the lines with the given values, displaced by d bars
+func ichimokuLines(d int, tenkan, kijun, senkouA, senkouB, chikou float64) IchimokuLines {
+	return IchimokuLines{
+		Tenkan:  tenkan,
+		Kijun:   kijun,
+		SenkouA: Displaced{Value: senkouA, Offset: d},
+		SenkouB: Displaced{Value: senkouB, Offset: d},
+		Chikou:  Displaced{Value: chikou, Offset: -d},
+	}
+}
+
+var wantIchimokuLines = []IchimokuLines{
+	ichimokuLines(2, 9., 9., 9., 9., 9.),
+	ichimokuLines(2, 10., 10., 10., 10., 10.5),
+	ichimokuLines(2, 10.5, 10., 10.25, 10., 10.),
+	ichimokuLines(2, 11., 11., 11., 10.5, 11.5),
+	ichimokuLines(2, 12., 11.5, 11.75, 11.5, 13.),
+	ichimokuLines(2, 12., 12., 12., 11.5, 11.),
+}
+
+func TestNewIchimoku(t *testing.T) {
+	tests := map[string]struct {
+		tenkan, kijun, senkouB, displacement int
+		wantErr                              error
+	}{
+		"zero tenkan":           {tenkan: 0, kijun: 26, senkouB: 52, displacement: 26, wantErr: ErrInvalidParameters},
+		"negative kijun":        {tenkan: 9, kijun: -1, senkouB: 52, displacement: 26, wantErr: ErrInvalidParameters},
+		"zero senkou B":         {tenkan: 9, kijun: 26, senkouB: 0, displacement: 26, wantErr: ErrInvalidParameters},
+		"zero displacement":     {tenkan: 9, kijun: 26, senkouB: 52, displacement: 0, wantErr: ErrInvalidParameters},
+		"positive periods":      {tenkan: 9, kijun: 26, senkouB: 52, displacement: 26, wantErr: nil},
+		"unordered periods":     {tenkan: 52, kijun: 26, senkouB: 9, displacement: 1, wantErr: nil},
+		"displacement of 1 bar": {tenkan: 1, kijun: 1, senkouB: 1, displacement: 1, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewIchimoku(tc.tenkan, tc.kijun, tc.senkouB, tc.displacement)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestIchimokuNext(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	got := make([]IchimokuLines, len(ichimokuBars))
+	for i, bar := range ichimokuBars {
+		got[i] = ichimoku.Next(bar)
+	}
+	if diff := cmp.Diff(wantIchimokuLines, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestIchimokuCloud(t *testing.T) {
+	nan := math.NaN()
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	wantA := []float64{nan, nan, 9., 10., 10.25, 11.}
+	wantB := []float64{nan, nan, 9., 10., 10., 10.5}
+
+	gotA := make([]float64, len(ichimokuBars))
+	gotB := make([]float64, len(ichimokuBars))
+	for i, bar := range ichimokuBars {
+		ichimoku.Next(bar)
+		gotA[i], gotB[i] = ichimoku.Cloud()
+	}
+	if diff := cmp.Diff(wantA, gotA, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	if diff := cmp.Diff(wantB, gotB, nanComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// the spans computed at a bar are the cloud displacement bars later
+	for i, lines := range wantIchimokuLines {
+		if j := i + lines.SenkouA.Offset; j < len(ichimokuBars) {
+			assert.Equal(t, lines.SenkouA.Value, gotA[j], "must place Senkou A at its offset")
+			assert.Equal(t, lines.SenkouB.Value, gotB[j], "must place Senkou B at its offset")
+		}
+	}
+}
+
+func TestIchimokuPeek(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	for _, bar := range ichimokuBars {
+		ichimoku.Peek(Bar{High: 100., Low: 0., Close: 50.}) // must not be committed
+		got := ichimoku.Peek(bar)
+		want := ichimoku.Next(bar)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIchimokuUpdateLast(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	ichimoku.Compute(ichimokuBars[:4], nil)
+	wantA, wantB := ichimoku.Cloud()
+
+	got := ichimoku.UpdateLast(Bar{High: 20., Low: 10., Close: 15.})
+	assert.Equal(t, ichimokuLines(2, 14.5, 14.5, 14.5, 14., 15.), got)
+	got = ichimoku.UpdateLast(ichimokuBars[3])
+	assert.Equal(t, wantIchimokuLines[3], got, "must replace the last bar")
+	gotA, gotB := ichimoku.Cloud()
+	assert.Equal(t, []float64{wantA, wantB}, []float64{gotA, gotB}, "must keep the cloud of the last bar")
+
+	empty, _ := NewIchimoku(2, 3, 4, 2)
+	assert.Equal(t, wantIchimokuLines[0], empty.UpdateLast(ichimokuBars[0]), "must behave like Next when empty")
+}
+
+func TestIchimokuCompute(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	out := make([]IchimokuLines, len(ichimokuBars))
+	got := ichimoku.Compute(ichimokuBars, out)
+	if diff := cmp.Diff(wantIchimokuLines, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+
+	_, err := ComputeIchimoku(2, 3, 4, 0, ichimokuBars, nil)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+	got, err = ComputeIchimoku(2, 3, 4, 2, ichimokuBars, nil)
+	assert.NoError(t, err)
+	if diff := cmp.Diff(wantIchimokuLines, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestIchimokuClone(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	ichimoku.Compute(ichimokuBars[:4], nil)
+	want, _ := NewIchimoku(2, 3, 4, 2)
+	want.Compute(ichimokuBars[:4], nil)
+
+	clone := ichimoku.Clone()
+	assert.Equal(t, wantIchimokuLines[4], clone.Next(ichimokuBars[4]), "clone must continue from the same state")
+	assert.Equal(t, want, ichimoku, "original must not see the clone's inputs")
+}
+
+func TestIchimokuReset(t *testing.T) {
+	ichimoku, _ := NewIchimoku(2, 3, 4, 2)
+	ichimoku.Compute(ichimokuBars, nil)
+	ichimoku.Reset()
+
+	want, _ := NewIchimoku(2, 3, 4, 2)
+	assert.Equal(t, want, ichimoku, "must return to a clean state")
+}
+
+func TestIchimokuString(t *testing.T) {
+	ichimoku, _ := NewIchimoku(9, 26, 52, 26)
+	assert.Equal(t, "Ichimoku(9,26,52,26)", ichimoku.String())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+// IndicatorOf is the shape shared by the indicators that take a single input of type T
+type IndicatorOf[T Float] interface {
+	// Next takes the next input and returns the next value
+	Next(input T) T
+	// Reset resets the indicator to a clean state
+	Reset()
+	String() string
+}
+
+// Indicator is an IndicatorOf float64 values
+type Indicator = IndicatorOf[float64]
+
+// PairIndicatorOf is the shape shared by the indicators that take two inputs of type T per period,
+// such as the statistics relating two series
+type PairIndicatorOf[T Float] interface {
+	// Next takes the next pair of inputs and returns the next value
+	Next(x, y T) T
+	// Reset resets the indicator to a clean state
+	Reset()
+	String() string
+}
+
+// PairIndicator is a PairIndicatorOf float64 values
+type PairIndicator = PairIndicatorOf[float64]
+
+// BarIndicatorOf is the shape shared by the indicators that take a bar of values of type T per period
+type BarIndicatorOf[T Float] interface {
+	// Next takes the next bar and returns the next value
+	Next(bar BarOf[T]) T
+	// Reset resets the indicator to a clean state
+	Reset()
+	String() string
+}
+
+// BarIndicator is a BarIndicatorOf float64 values
+type BarIndicator = BarIndicatorOf[float64]
+
+// BarTransformerOf is the shape shared by the transformers that turn a stream of bars of values of type T
+// into another, such as HeikinAshi or Renko, so that they can sit in front of any indicator
+type BarTransformerOf[T Float] interface {
+	// Next takes the next bar and returns the bars it completes, zero or more
+	Next(bar BarOf[T]) []BarOf[T]
+	// Reset resets the transformer to a clean state
+	Reset()
+	String() string
+}
+
+// BarTransformer is a BarTransformerOf float64 values
+type BarTransformer = BarTransformerOf[float64]
+
+// TimedIndicatorOf is the shape shared by the indicators that take timestamped inputs of type T
+// and keep a window of the values observed within a duration
+type TimedIndicatorOf[T Float] interface {
+	// Next takes the next input and returns the next value
+	Next(input TimedValueOf[T]) T
+	// Reset resets the indicator to a clean state
+	Reset()
+	String() string
+}
+
+// TimedIndicator is a TimedIndicatorOf float64 values
+type TimedIndicator = TimedIndicatorOf[float64]
+
+var (
+	_ Indicator = (*MovingAverage)(nil)
+	_ Indicator = (*ExponentialMovingAverage)(nil)
+	_ Indicator = (*Mean)(nil)
+	_ Indicator = (*Median)(nil)
+	_ Indicator = (*Maximum)(nil)
+	_ Indicator = (*Minimum)(nil)
+	_ Indicator = (*StandardDeviation)(nil)
+	_ Indicator = (*Skewness)(nil)
+	_ Indicator = (*Kurtosis)(nil)
+	_ Indicator = (*MedianAbsoluteDeviation)(nil)
+	_ Indicator = (*InterquartileRange)(nil)
+	_ Indicator = (*ZScore)(nil)
+	_ Indicator = (*LinearRegression)(nil)
+	_ Indicator = (*PercentRank)(nil)
+	_ Indicator = (*RollingRank)(nil)
+	_ Indicator = (*RollingMode)(nil)
+	_ Indicator = (*RollingSum)(nil)
+	_ Indicator = (*RollingProduct)(nil)
+	_ Indicator = (*ExpandingMean)(nil)
+	_ Indicator = (*ExpandingStandardDeviation)(nil)
+	_ Indicator = (*ExpandingMaximum)(nil)
+	_ Indicator = (*ExpandingMinimum)(nil)
+	_ Indicator = (*ExponentialVariance)(nil)
+	_ Indicator = (*ExponentialStandardDeviation)(nil)
+	_ Indicator = (*HistoricalVolatility)(nil)
+	_ Indicator = (*Lag)(nil)
+	_ Indicator = (*Difference)(nil)
+	_ Indicator = (*Momentum)(nil)
+	_ Indicator = (*RateOfChange)(nil)
+	_ Indicator = (*PercentChange)(nil)
+	_ Indicator = (*LogReturn)(nil)
+
+	_ PairIndicator = (*Covariance)(nil)
+	_ PairIndicator = (*PearsonCorrelation)(nil)
+	_ PairIndicator = (*Beta)(nil)
+	_ PairIndicator = (*RSquared)(nil)
+	_ PairIndicator = (*SpearmanCorrelation)(nil)
+	_ PairIndicator = (*ExponentialCovariance)(nil)
+	_ PairIndicator = (*ExponentialCorrelation)(nil)
+
+	_ BarIndicator = (*Parkinson)(nil)
+	_ BarIndicator = (*GarmanKlass)(nil)
+	_ BarIndicator = (*RogersSatchell)(nil)
+	_ BarIndicator = (*YangZhang)(nil)
+	_ BarIndicator = (*AverageTrueRange)(nil)
+
+	_ BarTransformer = (*HeikinAshi)(nil)
+	_ BarTransformer = (*Renko)(nil)
+	_ BarTransformer = (*RangeBars)(nil)
+	_ BarTransformer = (*PointAndFigure)(nil)
+
+	_ TimedIndicator = (*TimedMovingAverage)(nil)
+	_ TimedIndicator = (*TimedStandardDeviation)(nil)
+	_ TimedIndicator = (*TimedMedian)(nil)
+	_ TimedIndicator = (*TimedMaximum)(nil)
+	_ TimedIndicator = (*TimedMinimum)(nil)
+)
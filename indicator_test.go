@@ -0,0 +1,286 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// streamer is the shape of the indicators checked by testStreamer: they take inputs of type I,
+// support provisional updates and batch computation, and Clone returns an X
+type streamer[I, X any] interface {
+	Next(input I) float64
+	Peek(input I) float64
+	UpdateLast(input I) float64
+	Compute(inputs []I, out []float64) []float64
+	Clone() X
+	Reset()
+}
+
+// testStreamer checks the behaviour every streaming indicator shares over inputs: Peek and Compute agree with Next,
+// UpdateLast replaces the provisional input updates[i] with inputs[i], Clone is independent of the original
+// and Reset returns to the state of newX
+func testStreamer[I any, X streamer[I, X]](t *testing.T, newX func() X, inputs, updates []I) {
+	stream := newX()
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	tests := map[string]func(t *testing.T){
+		"Peek": func(t *testing.T) {
+			x := newX()
+			got := make([]float64, len(inputs))
+			for i, input := range inputs {
+				x.Peek(updates[i]) // must not be committed
+				got[i] = x.Peek(input)
+				x.Next(input)
+			}
+			if diff := cmp.Diff(want, got, nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+		},
+		"UpdateLast": func(t *testing.T) {
+			x := newX()
+			got := make([]float64, len(inputs))
+			for i, input := range inputs {
+				x.Next(updates[i])
+				got[i] = x.UpdateLast(input)
+			}
+			if diff := cmp.Diff(want, got, nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+
+			empty, next := newX(), newX()
+			if diff := cmp.Diff(next.Next(inputs[0]), empty.UpdateLast(inputs[0]), nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+			assert.Equal(t, next, empty, "must behave like Next when empty")
+		},
+		"Compute": func(t *testing.T) {
+			x := newX()
+			out := make([]float64, len(inputs))
+			got := x.Compute(inputs, out)
+			if diff := cmp.Diff(want, got, nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+			assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+			assert.Equal(t, stream, x, "must leave the indicator in the same state as Next")
+		},
+		"Clone": func(t *testing.T) {
+			half := len(inputs) / 2
+			x, original := newX(), newX()
+			x.Compute(inputs[:half], nil)
+			original.Compute(inputs[:half], nil)
+
+			got := x.Clone().Compute(inputs[half:], nil)
+			if diff := cmp.Diff(want[half:], got, nanComparer); diff != "" {
+				t.Fatalf("clone must continue from the same state: %s", diff)
+			}
+			assert.Equal(t, original, x, "original must not see the clone's inputs")
+		},
+		"Reset": func(t *testing.T) {
+			x := newX()
+			x.Compute(inputs, nil)
+			x.Reset()
+			assert.Equal(t, newX(), x, "must return to a clean state")
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, test)
+	}
+}
+
+// indicatorInputs are the inputs the single input indicators are checked over, with values repeated
+// to exercise ties
+var indicatorInputs = []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1., 1., 6.}
+
+// reversed returns a copy of inputs in reverse order, to use as provisional updates
+func reversed[I any](inputs []I) []I {
+	out := make([]I, len(inputs))
+	for i, input := range inputs {
+		out[len(inputs)-1-i] = input
+	}
+	return out
+}
+
+// indicator returns a test of the behaviour shared by the single input indicators that newX creates
+func indicator[X streamer[float64, X]](newX func() (X, error)) func(t *testing.T) {
+	return indicatorOver(indicatorInputs, newX)
+}
+
+// priceInputs are the inputs the indicators of log returns are checked over, which must be positive
+var priceInputs = []float64{12., 18., 10., 14., 11., 19., 19., 10., 13., 15., 17., 11., 11., 16.}
+
+// indicatorOver returns a test of the behaviour shared by the indicators that newX creates over inputs,
+// provisionally updated with the inputs in reverse order
+func indicatorOver[I any, X streamer[I, X]](inputs []I, newX func() (X, error)) func(t *testing.T) {
+	return func(t *testing.T) {
+		testStreamer(t, func() X {
+			x, err := newX()
+			assert.NoError(t, err)
+			return x
+		}, inputs, reversed(inputs))
+	}
+}
+
+// pair is an input of the indicators over two series
+type pair struct {
+	x, y float64
+}
+
+// pairStreamer is the shape of the indicators over two series
+type pairStreamer[X any] interface {
+	Next(x, y float64) float64
+	Peek(x, y float64) float64
+	UpdateLast(x, y float64) float64
+	Compute(xs, ys, out []float64) []float64
+	Clone() X
+	Reset()
+}
+
+// pairs adapts an indicator over two series to take pairs, so that testStreamer can check it
+type pairs[X pairStreamer[X]] struct {
+	x X
+}
+
+func (p pairs[X]) Next(input pair) float64       { return p.x.Next(input.x, input.y) }
+func (p pairs[X]) Peek(input pair) float64       { return p.x.Peek(input.x, input.y) }
+func (p pairs[X]) UpdateLast(input pair) float64 { return p.x.UpdateLast(input.x, input.y) }
+func (p pairs[X]) Clone() pairs[X]               { return pairs[X]{x: p.x.Clone()} }
+func (p pairs[X]) Reset()                        { p.x.Reset() }
+
+func (p pairs[X]) Compute(inputs []pair, out []float64) []float64 {
+	xs, ys := make([]float64, len(inputs)), make([]float64, len(inputs))
+	for i, input := range inputs {
+		xs[i], ys[i] = input.x, input.y
+	}
+	return p.x.Compute(xs, ys, out)
+}
+
+// pairInputs are the inputs the indicators over two series are checked over, with values repeated
+// in both series to exercise ties
+var pairInputs = []pair{
+	{2., 1.}, {8., 5.}, {0., 2.}, {4., 6.}, {1., 3.}, {9., 8.}, {9., 7.},
+	{0., 1.}, {3., 4.}, {5., 4.}, {7., 9.}, {1., 0.}, {1., 2.}, {6., 6.},
+}
+
+// pairIndicator returns a test of the behaviour shared by the indicators over two series that newX creates
+func pairIndicator[X pairStreamer[X]](newX func() (X, error)) func(t *testing.T) {
+	return indicatorOver(pairInputs, func() (pairs[X], error) {
+		x, err := newX()
+		return pairs[X]{x: x}, err
+	})
+}
+
+// barInputs are the bars the bar indicators are checked over
+var barInputs = []Bar{
+	{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+	{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+	{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+	{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+	{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+	{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4},
+	{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1},
+	{Open: 12., High: 12.2, Low: 11., Close: 11.2},
+	{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7},
+}
+
+// barIndicator returns a test of the behaviour shared by the bar indicators that newX creates
+func barIndicator[X streamer[Bar, X]](newX func() (X, error)) func(t *testing.T) {
+	return indicatorOver(barInputs, newX)
+}
+
+// timedInputs are the inputs the time-windowed indicators are checked over, with values leaving the window,
+// duplicate times and a time earlier than the most recent one
+var timedInputs = []TimedValue{
+	{Time: at(0.), Value: 2.},
+	{Time: at(1.), Value: 8.},
+	{Time: at(1.), Value: 0.},
+	{Time: at(2.), Value: 4.},
+	{Time: at(5.), Value: 1.},
+	{Time: at(5.), Value: 9.},
+	{Time: at(6.), Value: 9.},
+	{Time: at(4.), Value: 0.},
+	{Time: at(7.), Value: 3.},
+	{Time: at(12.), Value: 5.},
+	{Time: at(12.5), Value: 7.},
+}
+
+// timedIndicator returns a test of the behaviour shared by the time-windowed indicators that newX creates,
+// provisionally updated with the values of timedInputs in reverse order at the time of the input they precede,
+// as values that left the window for a later update are not restored
+func timedIndicator[X streamer[TimedValue, X]](newX func() (X, error)) func(t *testing.T) {
+	updates := reversed(timedInputs)
+	for i := range updates {
+		updates[i].Time = timedInputs[i].Time
+	}
+
+	return func(t *testing.T) {
+		testStreamer(t, func() X {
+			x, err := newX()
+			assert.NoError(t, err)
+			return x
+		}, timedInputs, updates)
+	}
+}
+
+func TestIndicators(t *testing.T) {
+	tests := map[string]func(t *testing.T){
+		"Skewness":                   indicator(func() (*Skewness, error) { return NewSkewness(4) }),
+		"Kurtosis":                   indicator(func() (*Kurtosis, error) { return NewKurtosis(4) }),
+		"MedianAbsoluteDeviation":    indicator(func() (*MedianAbsoluteDeviation, error) { return NewMedianAbsoluteDeviation(4) }),
+		"InterquartileRange":         indicator(func() (*InterquartileRange, error) { return NewInterquartileRange(5) }),
+		"ZScore":                     indicator(func() (*ZScore, error) { return NewZScore(4) }),
+		"PercentRank":                indicator(func() (*PercentRank, error) { return NewPercentRank(4) }),
+		"RollingRank":                indicator(func() (*RollingRank, error) { return NewRollingRank(4) }),
+		"RollingMode":                indicator(func() (*RollingMode, error) { return NewRollingMode(4) }),
+		"RollingSum":                 indicator(func() (*RollingSum, error) { return NewRollingSum(4) }),
+		"RollingProduct":             indicator(func() (*RollingProduct, error) { return NewRollingProduct(4) }),
+		"ExpandingMean":              indicator(func() (*ExpandingMean, error) { return NewExpandingMean(), nil }),
+		"ExpandingStandardDeviation": indicator(func() (*ExpandingStandardDeviation, error) { return NewExpandingStandardDeviation(), nil }),
+		"ExpandingMaximum":           indicator(func() (*ExpandingMaximum, error) { return NewExpandingMaximum(), nil }),
+		"ExpandingMinimum":           indicator(func() (*ExpandingMinimum, error) { return NewExpandingMinimum(), nil }),
+		"ExponentialVariance":        indicator(func() (*ExponentialVariance, error) { return NewExponentialVariance(4) }),
+		"Lag":                        indicator(func() (*Lag, error) { return NewLag(2) }),
+		"Difference":                 indicator(func() (*Difference, error) { return NewDifference(2) }),
+		"Momentum":                   indicatorOver(priceInputs, func() (*Momentum, error) { return NewMomentum(2) }),
+		"RateOfChange":               indicatorOver(priceInputs, func() (*RateOfChange, error) { return NewRateOfChange(2) }),
+		"PercentChange":              indicatorOver(priceInputs, func() (*PercentChange, error) { return NewPercentChange(2) }),
+		"LogReturn":                  indicatorOver(priceInputs, func() (*LogReturn, error) { return NewLogReturn(2) }),
+		"HistoricalVolatility":       indicatorOver(priceInputs, func() (*HistoricalVolatility, error) { return NewHistoricalVolatility(4, 252.) }),
+		"ExponentialStandardDeviation": indicator(func() (*ExponentialStandardDeviation, error) {
+			return NewExponentialStandardDeviation(4, WithBiasCorrection())
+		}),
+
+		"Covariance":             pairIndicator(func() (*Covariance, error) { return NewCovariance(4) }),
+		"PearsonCorrelation":     pairIndicator(func() (*PearsonCorrelation, error) { return NewPearsonCorrelation(4) }),
+		"Beta":                   pairIndicator(func() (*Beta, error) { return NewBeta(4) }),
+		"RSquared":               pairIndicator(func() (*RSquared, error) { return NewRSquared(4) }),
+		"SpearmanCorrelation":    pairIndicator(func() (*SpearmanCorrelation, error) { return NewSpearmanCorrelation(4) }),
+		"ExponentialCovariance":  pairIndicator(func() (*ExponentialCovariance, error) { return NewExponentialCovariance(4, WithSeeding(SeedMean)) }),
+		"Parkinson":              barIndicator(func() (*Parkinson, error) { return NewParkinson(4) }),
+		"GarmanKlass":            barIndicator(func() (*GarmanKlass, error) { return NewGarmanKlass(4) }),
+		"RogersSatchell":         barIndicator(func() (*RogersSatchell, error) { return NewRogersSatchell(4) }),
+		"YangZhang":              barIndicator(func() (*YangZhang, error) { return NewYangZhang(4) }),
+		"ExponentialCorrelation": pairIndicator(func() (*ExponentialCorrelation, error) { return NewExponentialCorrelation(4) }),
+
+		"TimedMovingAverage":     timedIndicator(func() (*TimedMovingAverage, error) { return NewTimedMovingAverage(3 * time.Second) }),
+		"TimedStandardDeviation": timedIndicator(func() (*TimedStandardDeviation, error) { return NewTimedStandardDeviation(3 * time.Second) }),
+		"TimedMedian":            timedIndicator(func() (*TimedMedian, error) { return NewTimedMedian(3 * time.Second) }),
+		"TimedMaximum":           timedIndicator(func() (*TimedMaximum, error) { return NewTimedMaximum(3 * time.Second) }),
+		"TimedMinimum":           timedIndicator(func() (*TimedMinimum, error) { return NewTimedMinimum(3 * time.Second) }),
+	}
+
+	for name, test := range tests {
+		t.Run(name, test)
+	}
+}
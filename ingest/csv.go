@@ -0,0 +1,226 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+// Header tells whether the first row of a CSV is a header
+type Header int
+
+const (
+	// HeaderDetect treats the first row as a header unless one of its fields is a number
+	HeaderDetect Header = iota
+	// HeaderPresent treats the first row as a header
+	HeaderPresent
+	// HeaderAbsent treats the first row as data
+	HeaderAbsent
+)
+
+// CSVConfig configures a CSVReader. The zero value reads comma separated values with a detected header,
+// the usual column names and DefaultTimeLayouts in UTC.
+type CSVConfig struct {
+	// Comma is the field delimiter, ',' if 0
+	Comma rune
+	// Comment, if not 0, starts lines that are skipped
+	Comment rune
+	Header  Header
+	Columns Columns
+	// TimeLayouts are tried in order to parse the time column, DefaultTimeLayouts if empty
+	TimeLayouts []string
+	// Location is the time zone of the times without one, UTC if nil
+	Location *time.Location
+}
+
+/*
+CSVReader reads bars from a CSV, one row at a time.
+
+Without a header the columns are taken by position, as close alone for a single column, time and close for two
+columns, open, high, low, close and volume for five columns and time, open, high, low, close and volume for six
+columns, unless Columns maps them by index.
+*/
+type CSVReader struct {
+	r      *csv.Reader
+	config CSVConfig
+	fields []field
+	times  timeParser
+
+	// index of the column of every field, -1 if missing, resolved from the first row
+	indexes [6]int
+	started bool
+	// error of resolving the columns, returned by every Read
+	err     error
+	header  []string
+	pending []string
+	record  []string
+	values  values
+}
+
+// NewCSVReader creates a new CSVReader reading from r
+func NewCSVReader(r io.Reader, config CSVConfig) *CSVReader {
+	cr := csv.NewReader(r)
+	if config.Comma != 0 {
+		cr.Comma = config.Comma
+	}
+	cr.Comment = config.Comment
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	return &CSVReader{
+		r:      cr,
+		config: config,
+		fields: config.Columns.fields(),
+		times:  newTimeParser(config.TimeLayouts, config.Location),
+	}
+}
+
+// Read reads the next bar. It returns io.EOF after the last bar and a *RowError for a malformed row, after
+// which it can be called again. Other errors are final, and an error reading the first row or resolving the
+// columns from it, such as a missing column in the header, is returned again by every later Read.
+func (r *CSVReader) Read() (tago.Bar, error) {
+	if !r.started {
+		r.started = true
+		r.err = r.start()
+	}
+	if r.err != nil {
+		return tago.Bar{}, r.err
+	}
+
+	record := r.pending
+	r.pending = nil
+	if record == nil {
+		var err error
+		record, err = r.r.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return tago.Bar{}, &RowError{Line: parseErr.Line, Err: parseErr.Err}
+			}
+			return tago.Bar{}, err
+		}
+	}
+	r.record = record
+
+	line, _ := r.r.FieldPos(0)
+	for i, index := range r.indexes {
+		r.values[i].present = index >= 0 && index < len(record)
+		if r.values[i].present {
+			r.values[i].raw = record[index]
+		}
+	}
+	return r.values.bar(r.fields, r.times, line)
+}
+
+// start reads the first row and resolves the columns from it
+func (r *CSVReader) start() error {
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+
+	header := r.config.Header == HeaderPresent ||
+		r.config.Header == HeaderDetect && !hasNumber(record)
+	if header {
+		r.header = append([]string(nil), record...)
+	} else {
+		r.pending = record
+	}
+
+	for i, f := range r.fields {
+		r.indexes[i] = -1
+		if header {
+			r.indexes[i] = find(record, f.names)
+		}
+		if r.indexes[i] < 0 && len(f.names) == 1 {
+			if index, err := strconv.Atoi(f.names[0]); err == nil && index >= 0 {
+				r.indexes[i] = index
+			}
+		}
+	}
+	if !header && r.config.Columns == (Columns{}) {
+		r.indexes = positions(len(record))
+	}
+
+	for i, f := range r.fields {
+		if r.indexes[i] < 0 && (f.required || f.mapped) {
+			return fmt.Errorf("%w %s", ErrMissingColumn, strings.Join(f.names, ", "))
+		}
+	}
+	return nil
+}
+
+// Header returns the names of the columns, or nil if the CSV has no header. It is known after the first Read.
+func (r *CSVReader) Header() []string {
+	return r.header
+}
+
+// Record returns the fields of the row most recently read, which are only valid until the next Read
+func (r *CSVReader) Record() []string {
+	return r.record
+}
+
+// Columns returns the columns the fields of the bars are read from, named as in the header or by index
+// without one, and empty for the missing fields. It is known after the first Read.
+func (r *CSVReader) Columns() Columns {
+	var names [6]string
+	for i, index := range r.indexes {
+		switch {
+		case !r.started || r.err != nil || index < 0:
+		case r.header != nil && index < len(r.header):
+			names[i] = strings.TrimSpace(r.header[index])
+		default:
+			names[i] = strconv.Itoa(index)
+		}
+	}
+	return Columns{Time: names[0], Open: names[1], High: names[2], Low: names[3], Close: names[4], Volume: names[5]}
+}
+
+// find returns the index of the first column of header named by one of names ignoring case, or -1
+func find(header []string, names []string) int {
+	for _, name := range names {
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// positions returns the indexes of the fields of a CSV without a header by its number of columns
+func positions(columns int) [6]int {
+	switch columns {
+	case 1:
+		return [6]int{-1, -1, -1, -1, 0, -1}
+	case 2:
+		return [6]int{0, -1, -1, -1, 1, -1}
+	case 5:
+		return [6]int{-1, 0, 1, 2, 3, 4}
+	case 6:
+		return [6]int{0, 1, 2, 3, 4, 5}
+	}
+	return [6]int{-1, -1, -1, -1, -1, -1}
+}
+
+// hasNumber reports whether a field of record is a number
+func hasNumber(record []string) bool {
+	for _, field := range record {
+		if _, err := parseNumber(field); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package ingest
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// readAll reads every bar of r, collecting the errors of the malformed rows
+func readAll(t *testing.T, r Reader) ([]tago.Bar, []error) {
+	var bars []tago.Bar
+	var errs []error
+	for {
+		bar, err := r.Read()
+		if err == io.EOF {
+			return bars, errs
+		}
+		var rowErr *RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		bars = append(bars, bar)
+	}
+}
+
+func day(date int) time.Time {
+	return time.Date(2020, 1, date, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCSVReader(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	tests := map[string]struct {
+		input  string
+		config CSVConfig
+		want   []tago.Bar
+	}{
+		"header": {
+			input: "Date,Open,High,Low,Close,Volume\n2020-01-02,10,11,9.5,10.5,1200\n2020-01-03, 10.6, 11.2, 10.1, 11, 900\n",
+			want: []tago.Bar{
+				{Time: day(2), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 1200.},
+				{Time: day(3), Open: 10.6, High: 11.2, Low: 10.1, Close: 11., Volume: 900.},
+			},
+		},
+		"reordered header": {
+			input: "close,volume,timestamp\n10.5,1200,2020-01-02T00:00:00Z\n",
+			want:  []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5, Volume: 1200.}},
+		},
+		"mapping": {
+			input: "Datum;Eröffnung;Hoch;Tief;Schluss;Umsatz\n02.01.2020 09:00;10;11;9.5;10.5;1200\n",
+			config: CSVConfig{
+				Comma:       ';',
+				Columns:     Columns{Time: "datum", Open: "Eröffnung", High: "Hoch", Low: "Tief", Close: "Schluss", Volume: "Umsatz"},
+				TimeLayouts: []string{"02.01.2006 15:04"},
+				Location:    berlin,
+			},
+			want: []tago.Bar{{Time: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 1200.}},
+		},
+		"tabs": {
+			input:  "time\tclose\n1577923200\t10.5\n",
+			config: CSVConfig{Comma: '\t'},
+			want:   []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5}},
+		},
+		"prices": {
+			input: "10\n10.5\n",
+			want: []tago.Bar{
+				{Open: 10., High: 10., Low: 10., Close: 10.},
+				{Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5},
+			},
+		},
+		"positions": {
+			input: "1577923200000,10,11,9.5,10.5,1200\n",
+			config: CSVConfig{
+				TimeLayouts: []string{LayoutUnixMilli},
+			},
+			want: []tago.Bar{{Time: day(2), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 1200.}},
+		},
+		"indexes": {
+			input: "AAPL,2020-01-02,10.5,1200\n",
+			config: CSVConfig{
+				Header:  HeaderAbsent,
+				Columns: Columns{Time: "1", Close: "2", Volume: "3"},
+			},
+			want: []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5, Volume: 1200.}},
+		},
+		"forced header": {
+			input:  "1,2\n2020-01-02,10.5\n",
+			config: CSVConfig{Header: HeaderPresent, Columns: Columns{Time: "1", Close: "2"}},
+			want:   []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5}},
+		},
+		"comments": {
+			input:  "# exported prices\ndate,close\n# holiday\n2020-01-02,10.5\n",
+			config: CSVConfig{Comment: '#'},
+			want:   []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5}},
+		},
+		"empty": {
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, errs := readAll(t, NewCSVReader(strings.NewReader(tc.input), tc.config))
+			assert.Empty(t, errs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCSVReaderMalformedRows(t *testing.T) {
+	input := "date,close,volume\n" +
+		"2020-01-02,10.5,1200\n" +
+		"2020-01-03,n/a,900\n" +
+		"2020-01-06\n" +
+		"01/07/2020,11,800\n" +
+		"2020-01-08,\"11.5,700\n"
+	r := NewCSVReader(strings.NewReader(input), CSVConfig{})
+
+	got, errs := readAll(t, r)
+	want := []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5, Volume: 1200.}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	wantErrs := []string{
+		`line 3: column close: invalid number "n/a"`,
+		`line 4: column close: missing column`,
+		`line 5: column time: invalid time "01/07/2020"`,
+	}
+	assert.Len(t, errs, 4)
+	for i, want := range wantErrs {
+		assert.EqualError(t, errs[i], want)
+	}
+	var rowErr *RowError
+	assert.True(t, errors.As(errs[3], &rowErr))
+	assert.Equal(t, 6, rowErr.Line, "must report the line of the unterminated quote")
+	assert.True(t, errors.Is(errs[0], ErrInvalidNumber))
+	assert.True(t, errors.Is(errs[1], ErrMissingColumn))
+	assert.True(t, errors.Is(errs[2], ErrInvalidTime))
+}
+
+func TestCSVReaderMissingColumns(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		config  CSVConfig
+		wantErr string
+	}{
+		"no close":      {input: "date,open\n2020-01-02,10\n", wantErr: "missing column close, c, price, value"},
+		"mapped volume": {input: "date,close\n2020-01-02,10\n", config: CSVConfig{Columns: Columns{Volume: "qty"}}, wantErr: "missing column qty"},
+		"positions":     {input: "2020-01-02,10,11\n", wantErr: "missing column close, c, price, value"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewCSVReader(strings.NewReader(tc.input), tc.config)
+			_, err := r.Read()
+			assert.EqualError(t, err, tc.wantErr)
+			assert.True(t, errors.Is(err, ErrMissingColumn))
+
+			_, err = r.Read()
+			assert.EqualError(t, err, tc.wantErr, "must return the same error on every later Read")
+			assert.Equal(t, Columns{}, r.Columns(), "must not resolve any column")
+		})
+	}
+}
+
+func TestCSVReaderColumns(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		config      CSVConfig
+		wantHeader  []string
+		wantRecord  []string
+		wantColumns Columns
+	}{
+		"header": {
+			input:       "Date, Close, Volume\n2020-01-02,10.5,1200\n",
+			wantHeader:  []string{"Date", "Close", "Volume"},
+			wantRecord:  []string{"2020-01-02", "10.5", "1200"},
+			wantColumns: Columns{Time: "Date", Close: "Close", Volume: "Volume"},
+		},
+		"mapped": {
+			input:       "day,adj close\n2020-01-02,10.5\n",
+			config:      CSVConfig{Columns: Columns{Close: "adj close"}},
+			wantHeader:  []string{"day", "adj close"},
+			wantRecord:  []string{"2020-01-02", "10.5"},
+			wantColumns: Columns{Close: "adj close"},
+		},
+		"positions": {
+			input:       "2020-01-02,10.5\n",
+			wantHeader:  nil,
+			wantRecord:  []string{"2020-01-02", "10.5"},
+			wantColumns: Columns{Time: "0", Close: "1"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewCSVReader(strings.NewReader(tc.input), tc.config)
+			assert.Equal(t, Columns{}, r.Columns(), "must not resolve the columns before the first Read")
+
+			_, err := r.Read()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantHeader, r.Header())
+			assert.Equal(t, tc.wantRecord, r.Record())
+			assert.Equal(t, tc.wantColumns, r.Columns())
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+/*
+Package ingest reads OHLCV bars from CSV and JSON lines into tago.Bar values.
+
+The readers stream their input through an io.Reader one row at a time, so memory does not grow with the
+size of the input, and report malformed rows as a RowError carrying the line number. Reading can carry on
+with the next row after a RowError, so a caller can either stop at the first malformed row or skip and
+log them.
+
+# Example
+```
+r := ingest.NewCSVReader(file, ingest.CSVConfig{Comma: ';'})
+bar, err := r.Read()
+```
+*/
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+// Reader reads bars one at a time. Read returns io.EOF after the last bar, and a *RowError for a malformed
+// row after which it can be called again to read the next row.
+type Reader interface {
+	Read() (tago.Bar, error)
+}
+
+var (
+	_ Reader = (*CSVReader)(nil)
+	_ Reader = (*JSONReader)(nil)
+)
+
+var (
+	ErrMissingColumn = errors.New("missing column")
+	ErrInvalidNumber = errors.New("invalid number")
+	ErrInvalidTime   = errors.New("invalid time")
+)
+
+// RowError reports a malformed row of the input
+type RowError struct {
+	// Line is the 1-based line number of the row
+	Line int
+	// Column is the column of the malformed value, if any
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: column %s: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Columns maps the fields of a bar to the columns of the input, by name ignoring case or, for a CSV without
+// a header, by zero-based index such as "4". An empty mapping uses the usual names: time, date, timestamp or
+// datetime, open or o, high or h, low or l, close, c, price or value and volume or v.
+// Only the close is required, along with the columns that are mapped: a missing open, high or low is set to the close, a missing volume to 0 and
+// a missing time to the zero time.
+type Columns struct {
+	Time   string
+	Open   string
+	High   string
+	Low    string
+	Close  string
+	Volume string
+}
+
+// field is a field of a bar and the names of the columns it can be read from
+type field struct {
+	name     string
+	names    []string
+	required bool
+	// mapped by Columns rather than by the usual names
+	mapped bool
+}
+
+// fields returns the fields of a bar in the order time, open, high, low, close, volume
+func (c Columns) fields() []field {
+	fields := []field{
+		{name: "time", names: []string{"time", "date", "timestamp", "datetime"}},
+		{name: "open", names: []string{"open", "o"}},
+		{name: "high", names: []string{"high", "h"}},
+		{name: "low", names: []string{"low", "l"}},
+		{name: "close", names: []string{"close", "c", "price", "value"}, required: true},
+		{name: "volume", names: []string{"volume", "v"}},
+	}
+	for i, name := range []string{c.Time, c.Open, c.High, c.Low, c.Close, c.Volume} {
+		if name != "" {
+			fields[i].names = []string{name}
+			fields[i].mapped = true
+		}
+	}
+	return fields
+}
+
+// Time layouts of the Unix time in seconds and milliseconds, for CSVConfig.TimeLayouts and JSONConfig.TimeLayouts
+const (
+	LayoutUnix      = "unix"
+	LayoutUnixMilli = "unixms"
+)
+
+// DefaultTimeLayouts are the layouts tried in order when none are configured
+var DefaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	LayoutUnix,
+}
+
+// timeParser parses times with the first matching layout, in location for layouts without a time zone
+type timeParser struct {
+	layouts  []string
+	location *time.Location
+}
+
+func newTimeParser(layouts []string, location *time.Location) timeParser {
+	if len(layouts) == 0 {
+		layouts = DefaultTimeLayouts
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return timeParser{layouts: layouts, location: location}
+}
+
+func (p timeParser) parse(s string) (time.Time, error) {
+	for _, layout := range p.layouts {
+		switch layout {
+		case LayoutUnix, LayoutUnixMilli:
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			if layout == LayoutUnixMilli {
+				v /= 1e3
+			}
+			whole, fraction := math.Modf(v)
+			return time.Unix(int64(whole), int64(math.Round(fraction*1e9))).In(p.location), nil
+		default:
+			if t, err := time.ParseInLocation(layout, s, p.location); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w %q", ErrInvalidTime, s)
+}
+
+// parseNumber parses a number, ignoring surrounding spaces
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidNumber, s)
+	}
+	return v, nil
+}
+
+// values holds the fields of a bar read from a row, by the order of Columns.fields
+type values [6]struct {
+	raw     string
+	present bool
+}
+
+// bar builds the bar from the values of a row, reporting malformed values as a RowError on line
+func (v *values) bar(fields []field, times timeParser, line int) (tago.Bar, error) {
+	var bar tago.Bar
+	var numbers [6]float64
+	for i := range v {
+		if !v[i].present {
+			if fields[i].required || fields[i].mapped {
+				return bar, &RowError{Line: line, Column: fields[i].name, Err: ErrMissingColumn}
+			}
+			continue
+		}
+
+		var err error
+		if i == 0 {
+			bar.Time, err = times.parse(strings.TrimSpace(v[i].raw))
+		} else {
+			numbers[i], err = parseNumber(v[i].raw)
+		}
+		if err != nil {
+			return bar, &RowError{Line: line, Column: fields[i].name, Err: err}
+		}
+	}
+
+	bar.Close = numbers[4]
+	bar.Open, bar.High, bar.Low = bar.Close, bar.Close, bar.Close
+	if v[1].present {
+		bar.Open = numbers[1]
+	}
+	if v[2].present {
+		bar.High = numbers[2]
+	}
+	if v[3].present {
+		bar.Low = numbers[3]
+	}
+	bar.Volume = numbers[5]
+	return bar, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package ingest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeParser(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*3600)
+	tests := map[string]struct {
+		layouts  []string
+		location *time.Location
+		input    string
+		want     time.Time
+		wantErr  string
+	}{
+		"RFC 3339":          {input: "2020-01-02T09:30:00-05:00", want: time.Date(2020, 1, 2, 14, 30, 0, 0, time.UTC)},
+		"date and time":     {input: "2020-01-02 09:30:00", want: time.Date(2020, 1, 2, 9, 30, 0, 0, time.UTC)},
+		"location":          {input: "2020-01-02 09:30:00", location: newYork, want: time.Date(2020, 1, 2, 14, 30, 0, 0, time.UTC)},
+		"unix":              {input: "1577957400.5", want: time.Date(2020, 1, 2, 9, 30, 0, 5e8, time.UTC)},
+		"unix milliseconds": {layouts: []string{LayoutUnixMilli}, input: "1577957400500", want: time.Date(2020, 1, 2, 9, 30, 0, 5e8, time.UTC)},
+		"custom layout":     {layouts: []string{"20060102"}, input: "20200102", want: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"no match":          {layouts: []string{"20060102"}, input: "2020-01-02", wantErr: `invalid time "2020-01-02"`},
+		"not finite":        {input: "NaN", wantErr: `invalid time "NaN"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := newTimeParser(tc.layouts, tc.location).parse(tc.input)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				assert.True(t, errors.Is(err, ErrInvalidTime))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tc.want.Equal(got), "got %v, want %v", got, tc.want)
+		})
+	}
+}
+
+func TestRowError(t *testing.T) {
+	err := &RowError{Line: 3, Column: "close", Err: ErrInvalidNumber}
+	assert.EqualError(t, err, "line 3: column close: invalid number")
+	assert.True(t, errors.Is(err, ErrInvalidNumber))
+	assert.EqualError(t, &RowError{Line: 4, Err: ErrMissingColumn}, "line 4: missing column")
+}
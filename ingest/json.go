@@ -0,0 +1,142 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+// MaxLineSize is the default limit of the length of a line of JSON
+const MaxLineSize = 1 << 20
+
+// JSONConfig configures a JSONReader. The zero value reads the usual keys and DefaultTimeLayouts in UTC.
+type JSONConfig struct {
+	// Columns maps the fields of a bar to the keys of the objects
+	Columns Columns
+	// TimeLayouts are tried in order to parse a time given as a string, DefaultTimeLayouts if empty.
+	// A time given as a number is parsed with LayoutUnix or LayoutUnixMilli, the first one in the layouts.
+	TimeLayouts []string
+	// Location is the time zone of the times without one, UTC if nil
+	Location *time.Location
+	// MaxLineSize limits the length of a line, MaxLineSize if 0
+	MaxLineSize int
+}
+
+// JSONReader reads bars from JSON lines, one object per line. Numbers can be given as JSON numbers or
+// strings, and blank lines are skipped.
+type JSONReader struct {
+	scanner *bufio.Scanner
+	fields  []field
+	times   timeParser
+	numbers timeParser
+	line    int
+
+	object map[string]json.RawMessage
+	values values
+}
+
+// NewJSONReader creates a new JSONReader reading from r
+func NewJSONReader(r io.Reader, config JSONConfig) *JSONReader {
+	size := config.MaxLineSize
+	if size <= 0 {
+		size = MaxLineSize
+	}
+	initial := 4096
+	if size < initial {
+		// the limit is the larger of size and the capacity of the buffer
+		initial = size
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, initial), size)
+
+	times := newTimeParser(config.TimeLayouts, config.Location)
+	numbers := newTimeParser([]string{LayoutUnix}, config.Location)
+	for _, layout := range times.layouts {
+		if layout == LayoutUnix || layout == LayoutUnixMilli {
+			numbers.layouts = []string{layout}
+			break
+		}
+	}
+
+	return &JSONReader{
+		scanner: scanner,
+		fields:  config.Columns.fields(),
+		times:   times,
+		numbers: numbers,
+		object:  make(map[string]json.RawMessage),
+	}
+}
+
+// Read reads the next bar. It returns io.EOF after the last bar and a *RowError for a malformed line, after
+// which it can be called again. Other errors, such as a line longer than the limit, are final.
+func (r *JSONReader) Read() (tago.Bar, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		for key := range r.object {
+			delete(r.object, key)
+		}
+		if err := json.Unmarshal(line, &r.object); err != nil {
+			return tago.Bar{}, &RowError{Line: r.line, Err: err}
+		}
+		return r.bar()
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return tago.Bar{}, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	return tago.Bar{}, io.EOF
+}
+
+// bar builds the bar from the object of the current line
+func (r *JSONReader) bar() (tago.Bar, error) {
+	times := r.times
+	for i, f := range r.fields {
+		raw, ok := r.lookup(f.names)
+		r.values[i].present = ok
+		if !ok {
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			// a number, kept as written
+			s = string(raw)
+			if i == 0 {
+				times = r.numbers
+			}
+		}
+		r.values[i].raw = s
+	}
+	return r.values.bar(r.fields, times, r.line)
+}
+
+// lookup returns the value of the first key named by one of names ignoring case
+func (r *JSONReader) lookup(names []string) (json.RawMessage, bool) {
+	for _, name := range names {
+		if raw, ok := r.object[name]; ok && string(raw) != "null" {
+			return raw, true
+		}
+		for key, raw := range r.object {
+			if strings.EqualFold(key, name) && string(raw) != "null" {
+				return raw, true
+			}
+		}
+	}
+	return nil, false
+}
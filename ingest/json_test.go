@@ -0,0 +1,88 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package ingest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONReader(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		config JSONConfig
+		want   []tago.Bar
+	}{
+		"numbers": {
+			input: `{"time":"2020-01-02","open":10,"high":11,"low":9.5,"close":10.5,"volume":1200}` + "\n" +
+				"\n" +
+				`{"Time":"2020-01-03","Open":"10.6","High":"11.2","Low":"10.1","Close":"11","Volume":"900"}` + "\n",
+			want: []tago.Bar{
+				{Time: day(2), Open: 10., High: 11., Low: 9.5, Close: 10.5, Volume: 1200.},
+				{Time: day(3), Open: 10.6, High: 11.2, Low: 10.1, Close: 11., Volume: 900.},
+			},
+		},
+		"unix": {
+			input: `{"t":1577923200,"c":10.5}` + "\n",
+			config: JSONConfig{
+				Columns: Columns{Time: "t"},
+			},
+			want: []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5}},
+		},
+		"unix milliseconds": {
+			input: `{"ts":1577923200000,"price":10.5,"qty":3}`,
+			config: JSONConfig{
+				Columns:     Columns{Time: "ts", Volume: "qty"},
+				TimeLayouts: []string{time.RFC3339, LayoutUnixMilli},
+			},
+			want: []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5, Volume: 3.}},
+		},
+		"null": {
+			input: `{"date":"2020-01-02","open":null,"close":10.5}`,
+			want:  []tago.Bar{{Time: day(2), Open: 10.5, High: 10.5, Low: 10.5, Close: 10.5}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, errs := readAll(t, NewJSONReader(strings.NewReader(tc.input), tc.config))
+			assert.Empty(t, errs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestJSONReaderMalformedLines(t *testing.T) {
+	input := `{"date":"2020-01-02","close":10.5}` + "\n" +
+		`{"date":"2020-01-03","close":` + "\n" +
+		`{"date":"2020-01-06","close":true}` + "\n" +
+		`{"date":"2020-01-07"}` + "\n" +
+		`{"date":"2020-01-08","close":11}` + "\n"
+
+	got, errs := readAll(t, NewJSONReader(strings.NewReader(input), JSONConfig{}))
+	assert.Len(t, got, 2)
+	assert.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), "line 2: ")
+	assert.EqualError(t, errs[1], `line 3: column close: invalid number "true"`)
+	assert.EqualError(t, errs[2], `line 4: column close: missing column`)
+}
+
+func TestJSONReaderLineTooLong(t *testing.T) {
+	input := `{"close":10.5}` + "\n" + `{"close":10.5,"note":"` + strings.Repeat("x", 100) + `"}` + "\n"
+	r := NewJSONReader(strings.NewReader(input), JSONConfig{MaxLineSize: 64})
+
+	_, err := r.Read()
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.EqualError(t, err, "line 2: bufio.Scanner: token too long")
+}
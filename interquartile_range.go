@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+InterquartileRangeOf returns the interquartile range (IQR) of the last n values, the spread of the
+middle half of the window. The window is kept sorted as values enter and leave it.
+
+# Formula
+
+IQR = Q<sub>3</sub> - Q<sub>1</sub>
+
+Where:
+
+* _Q<sub>1</sub>_, _Q<sub>3</sub>_ - 25% and 75% quantiles, interpolated linearly between the closest ranks
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+iqr, _ := NewInterquartileRange(20)
+iqr.Next(10.)
+```
+*/
+type InterquartileRangeOf[T Float] struct {
+	sortedWindow[T]
+}
+
+// InterquartileRange is an InterquartileRangeOf float64 values
+type InterquartileRange = InterquartileRangeOf[float64]
+
+// NewInterquartileRange creates a new InterquartileRange with the given number of periods
+// Example: NewInterquartileRange(20)
+func NewInterquartileRange(n int) (*InterquartileRange, error) {
+	return NewInterquartileRangeOf[float64](n)
+}
+
+// NewInterquartileRangeOf creates a new InterquartileRangeOf values of type T with the given number of periods
+// Example: NewInterquartileRangeOf[float32](20)
+func NewInterquartileRangeOf[T Float](n int) (*InterquartileRangeOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &InterquartileRangeOf[T]{
+		sortedWindow: newSortedWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next InterquartileRange value
+func (iqr *InterquartileRangeOf[T]) Next(input T) T {
+	iqr.push(input)
+	return interquartileRange(iqr.sorted)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (iqr *InterquartileRangeOf[T]) Peek(input T) T {
+	return iqr.peek(input, interquartileRange[T])
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated InterquartileRange value.
+// If no input has been committed yet it behaves like Next.
+func (iqr *InterquartileRangeOf[T]) UpdateLast(input T) T {
+	iqr.replaceLast(input)
+	return interquartileRange(iqr.sorted)
+}
+
+func interquartileRange[T Float](sorted []T) T {
+	return quantile(sorted, 0.75) - quantile(sorted, 0.25)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (iqr *InterquartileRangeOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = iqr.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (iqr *InterquartileRangeOf[T]) Reset() {
+	iqr.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (iqr *InterquartileRangeOf[T]) Clone() *InterquartileRangeOf[T] {
+	return &InterquartileRangeOf[T]{
+		sortedWindow: iqr.clone(),
+	}
+}
+
+func (iqr *InterquartileRangeOf[T]) String() string {
+	return fmt.Sprintf("IQR(%d)", iqr.n)
+}
+
+// ComputeInterquartileRange computes an InterquartileRange with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeInterquartileRange[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	iqr, err := NewInterquartileRangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = iqr.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
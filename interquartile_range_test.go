@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInterquartileRange(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *InterquartileRange
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &InterquartileRange{sortedWindow: newSortedWindow[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewInterquartileRange(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestInterquartileRangeNext(t *testing.T) {
+	sd, _ := NewInterquartileRange(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 3.},
+		{input: 0., want: 4.},
+		{input: 4., want: 3.5},
+		{input: 1., want: 4.25},
+		{input: 9., want: 4.5},
+		{input: 9., want: 5.75},
+		{input: 0., want: 8.25},
+		{input: 3., want: 6.75},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeInterquartileRange(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 3., 4., 3.5, 4.25}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 3.5, 4.25}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeInterquartileRange(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestInterquartileRangeWindows(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"odd window":      {n: 5, inputs: []float64{5., 1., 4., 2., 3.}, want: 2.},
+		"even window":     {n: 4, inputs: []float64{4., 1., 3., 2.}, want: 1.5},
+		"single value":    {n: 1, inputs: []float64{4., 9.}, want: 0.},
+		"outlier":         {n: 5, inputs: []float64{1., 2., 3., 4., 100.}, want: 2.},
+		"ties":            {n: 5, inputs: []float64{3., 3., 8., 3., 3.}, want: 0.},
+		"constant window": {n: 3, inputs: []float64{1., 9., 4., 4., 4.}, want: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewInterquartileRange(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestInterquartileRangeString(t *testing.T) {
+	sd, _ := NewInterquartileRange(4)
+	want := "IQR(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
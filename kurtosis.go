@@ -0,0 +1,120 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+KurtosisOf returns the excess kurtosis of the last n values, a measure of how heavy the tails of their
+distribution are compared to a normal distribution. The fourth and second central moments are updated
+online as values enter and leave the window, and recomputed from it once every n updates so that rounding
+errors do not accumulate over long streams.
+
+# Formula
+
+g<sub>2</sub> = m<sub>4</sub> / m<sub>2</sub><sup>2</sup> - 3
+
+Where:
+
+* _m<sub>k</sub>_ - k-th central moment of the observation: Σ(x<sub>i</sub> - x̄)<sup>k</sup> / N
+* _N_ - number of probes in observation.
+
+The kurtosis of a window whose values are all equal is 0.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+k, _ := NewKurtosis(20)
+k.Next(10.)
+```
+*/
+type KurtosisOf[T Float] struct {
+	momentWindow[T]
+}
+
+// Kurtosis is a KurtosisOf float64 values
+type Kurtosis = KurtosisOf[float64]
+
+// NewKurtosis creates a new Kurtosis with the given number of periods
+// Example: NewKurtosis(20)
+func NewKurtosis(n int) (*Kurtosis, error) {
+	return NewKurtosisOf[float64](n)
+}
+
+// NewKurtosisOf creates a new KurtosisOf values of type T with the given number of periods
+// Example: NewKurtosisOf[float32](20)
+func NewKurtosisOf[T Float](n int) (*KurtosisOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &KurtosisOf[T]{
+		momentWindow: newMomentWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next Kurtosis value
+func (k *KurtosisOf[T]) Next(input T) T {
+	k.push(input)
+	return k.moments.kurtosis()
+}
+
+// Peek returns the value Next would return for input without committing it
+func (k *KurtosisOf[T]) Peek(input T) T {
+	moments := k.peek(input)
+	return moments.kurtosis()
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Kurtosis value.
+// If no input has been committed yet it behaves like Next.
+func (k *KurtosisOf[T]) UpdateLast(input T) T {
+	k.replaceLast(input)
+	return k.moments.kurtosis()
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (k *KurtosisOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = k.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (k *KurtosisOf[T]) Reset() {
+	k.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (k *KurtosisOf[T]) Clone() *KurtosisOf[T] {
+	return &KurtosisOf[T]{
+		momentWindow: k.clone(),
+	}
+}
+
+func (k *KurtosisOf[T]) String() string {
+	return fmt.Sprintf("Kurt(%d)", k.n)
+}
+
+// ComputeKurtosis computes a Kurtosis with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeKurtosis[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	k, err := NewKurtosisOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = k.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
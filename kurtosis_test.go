@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKurtosis(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *Kurtosis
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &Kurtosis{momentWindow: newMomentWindow[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewKurtosis(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestKurtosisNext(t *testing.T) {
+	sd, _ := NewKurtosis(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: -2.},
+		{input: 0., want: -1.5},
+		{input: 4., want: -1.15429},
+		{input: 1., want: -1.27759},
+		{input: 9., want: -1.16035},
+		{input: 9., want: -1.64277},
+		{input: 0., want: -1.97265},
+		{input: 3., want: -1.72016},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeKurtosis(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., -2., -1.5, -1.15429, -1.27759}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), -1.15429, -1.27759}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeKurtosis(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestKurtosisConstantWindow(t *testing.T) {
+	sd, _ := NewKurtosis(4)
+	sd.Compute([]float64{1.3, 5.7, 2.1, 8.9, 0.7, 7., 7., 7.}, nil)
+
+	// the moments updated incrementally keep a rounding residue after the varied values leave the window,
+	// so compare exactly
+	assert.Equal(t, 0., sd.Peek(7.), "must return 0 for a window of equal values")
+	assert.Equal(t, 0., sd.Next(7.), "must return 0 for a window of equal values")
+	sd.Next(3.)
+	assert.Equal(t, 0., sd.UpdateLast(7.), "must return 0 for a window of equal values")
+}
+
+func TestKurtosisOfFloat32LongStream(t *testing.T) {
+	sd, _ := NewKurtosisOf[float32](10)
+	fresh, _ := NewKurtosis(10)
+	testLongStream(t, sd, fresh, 10, 5e-2)
+}
+
+func TestKurtosisString(t *testing.T) {
+	sd, _ := NewKurtosis(4)
+	want := "Kurt(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
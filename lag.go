@@ -0,0 +1,114 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+LagOf returns the input _n_ periods before the current one. It delays a series, as the first
+stage of indicators that compare the current input with a past one.
+
+# Formula
+
+Lag<sub>t</sub> = p<sub>t-n</sub>
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the first input is returned.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+lag, _ := NewLag(1)
+lag.Next(10.)
+```
+*/
+type LagOf[T Float] struct {
+	lookback[T]
+}
+
+// Lag is a LagOf float64 values
+type Lag = LagOf[float64]
+
+// NewLag creates a new Lag with the given number of periods
+// Example: NewLag(1)
+func NewLag(n int) (*Lag, error) {
+	return NewLagOf[float64](n)
+}
+
+// NewLagOf creates a new LagOf values of type T with the given number of periods
+// Example: NewLagOf[float32](1)
+func NewLagOf[T Float](n int) (*LagOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &LagOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next Lag value
+func (lag *LagOf[T]) Next(input T) T {
+	return lag.push(input)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (lag *LagOf[T]) Peek(input T) T {
+	return lag.peek(input)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Lag value.
+// If no input has been committed yet it behaves like Next.
+func (lag *LagOf[T]) UpdateLast(input T) T {
+	return lag.replaceLast(input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (lag *LagOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = lag.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (lag *LagOf[T]) Reset() {
+	lag.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (lag *LagOf[T]) Clone() *LagOf[T] {
+	return &LagOf[T]{
+		lookback: lag.clone(),
+	}
+}
+
+func (lag *LagOf[T]) String() string {
+	return fmt.Sprintf("Lag(%d)", lag.n)
+}
+
+// ComputeLag computes a Lag with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputeLag[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	lag, err := NewLagOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = lag.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
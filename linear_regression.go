@@ -0,0 +1,350 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+LinearRegressionOf fits a least-squares line over the last _n_ inputs and returns its value at the
+most recent input (the end point of the line). The inputs are placed at x = 0, 1, ..., N-1 from the
+oldest to the most recent, and the mean of the inputs and the sums of squares and products of their
+deviations the fit needs are updated in constant time as inputs enter and leave the window, and recomputed
+from it once every n updates so that rounding errors do not accumulate over long streams. Slope, Intercept, Forecast, Angle, RSquared and StandardError describe the current fit.
+
+# Formula
+
+* _slope_ = (N·Σxy - Σx·Σy) / (N·Σx<sup>2</sup> - (Σx)<sup>2</sup>)
+* _intercept_ = (Σy - slope·Σx) / N
+* _LinReg<sub>t</sub>_ = intercept + slope·(N - 1)
+* _TSF<sub>t</sub>_ = intercept + slope·N, the time series forecast of the next input
+
+Where:
+
+* _x_ - position of the input in the window, 0 being the oldest
+* _y_ - input value
+* _N_ - number of probes in observation.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+lr, _ := NewLinearRegression(14)
+lr.Next(10.)
+lr.Slope()
+```
+*/
+type LinearRegressionOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index int
+	fit   leastSquares[T]
+	// number of updates since fit was last recomputed from data
+	updates int
+
+	// slice of data needed for calculation
+	data []T
+}
+
+// LinearRegression is a LinearRegressionOf float64 values
+type LinearRegression = LinearRegressionOf[float64]
+
+// NewLinearRegression creates a new LinearRegression with the given number of periods
+// Example: NewLinearRegression(14)
+func NewLinearRegression(n int) (*LinearRegression, error) {
+	return NewLinearRegressionOf[float64](n)
+}
+
+// NewLinearRegressionOf creates a new LinearRegressionOf values of type T with the given number of periods
+// Example: NewLinearRegressionOf[float32](14)
+func NewLinearRegressionOf[T Float](n int) (*LinearRegressionOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &LinearRegressionOf[T]{
+		n: n,
+
+		index: 0,
+
+		data: make([]T, n),
+	}, nil
+}
+
+// Next takes the next input and returns the next LinearRegression value
+func (lr *LinearRegressionOf[T]) Next(input T) T {
+	lr.fit = lr.advance(input)
+
+	lr.index = (lr.index + 1) % lr.n
+	lr.data[lr.index] = input
+	lr.resync()
+	return lr.fit.endPoint()
+}
+
+// Peek returns the value Next would return for input without committing it
+func (lr *LinearRegressionOf[T]) Peek(input T) T {
+	return lr.peek(input).endPoint()
+}
+
+// peek returns the fit Next would produce for input
+func (lr *LinearRegressionOf[T]) peek(input T) leastSquares[T] {
+	fit := lr.advance(input)
+	if lr.updates+1 >= lr.n {
+		return windowFit(lr.data, (lr.index+1)%lr.n, fit.count, input)
+	}
+	return fit
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated LinearRegression value.
+// If no input has been committed yet it behaves like Next.
+func (lr *LinearRegressionOf[T]) UpdateLast(input T) T {
+	if lr.fit.count == 0 {
+		return lr.Next(input)
+	}
+
+	lr.fit.replaceLast(lr.data[lr.index], input)
+	lr.data[lr.index] = input
+	lr.resync()
+	return lr.fit.endPoint()
+}
+
+// advance returns the fit of the window after input is added and, once the window is full, the oldest
+// input is dropped
+func (lr *LinearRegressionOf[T]) advance(input T) leastSquares[T] {
+	fit := lr.fit
+	if fit.count == lr.n {
+		fit.removeFirst(lr.data[(lr.index+1)%lr.n])
+	}
+	fit.add(input)
+	return fit
+}
+
+// resync recomputes fit from data once every n updates, so that its rounding errors do not accumulate
+// over long streams
+func (lr *LinearRegressionOf[T]) resync() {
+	lr.updates++
+	if lr.updates >= lr.n {
+		lr.fit = windowFit(lr.data, lr.index, lr.fit.count, lr.data[lr.index])
+		lr.updates = 0
+	}
+}
+
+// Slope returns the slope of the current fit, the change of the line per period
+func (lr *LinearRegressionOf[T]) Slope() T {
+	slope, _ := lr.fit.line()
+	return slope
+}
+
+// Intercept returns the value of the current fit at the oldest input of the window
+func (lr *LinearRegressionOf[T]) Intercept() T {
+	_, intercept := lr.fit.line()
+	return intercept
+}
+
+// Forecast returns the time series forecast (TSF): the value of the current fit one period after
+// the most recent input
+func (lr *LinearRegressionOf[T]) Forecast() T {
+	slope, intercept := lr.fit.line()
+	return intercept + slope*T(lr.fit.count)
+}
+
+// Angle returns the angle of the current fit in degrees, the arctangent of its slope
+func (lr *LinearRegressionOf[T]) Angle() T {
+	return T(math.Atan(float64(lr.Slope())) * 180 / math.Pi)
+}
+
+// RSquared returns the coefficient of determination of the current fit, between 0 and 1
+func (lr *LinearRegressionOf[T]) RSquared() T {
+	return lr.fit.rSquared()
+}
+
+// StandardError returns the standard error of the estimate of the current fit,
+// the typical distance of the inputs from the line
+func (lr *LinearRegressionOf[T]) StandardError() T {
+	return lr.fit.standardError()
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (lr *LinearRegressionOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = lr.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (lr *LinearRegressionOf[T]) Reset() {
+	lr.index = 0
+	lr.fit = leastSquares[T]{}
+	lr.updates = 0
+
+	lr.data = make([]T, lr.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (lr *LinearRegressionOf[T]) Clone() *LinearRegressionOf[T] {
+	clone := *lr
+	clone.data = cloneSlice(lr.data)
+	return &clone
+}
+
+func (lr *LinearRegressionOf[T]) String() string {
+	return fmt.Sprintf("LinReg(%d)", lr.n)
+}
+
+// ComputeLinearRegression computes a LinearRegression with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeLinearRegression[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	lr, err := NewLinearRegressionOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = lr.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// leastSquares keeps the mean of count values placed at x = 0, 1, ..., count-1 and the sums of squares
+// and products of their deviations from the means, the centered sums a line is fitted from
+type leastSquares[T Float] struct {
+	count int
+
+	meanY T
+	cyy   T
+	cxy   T
+}
+
+// add includes y at x = count
+func (ls *leastSquares[T]) add(y T) {
+	ls.count++
+	m := T(ls.count)
+
+	// y is m/2 to the right of the mean of x before it is added
+	dy := y - ls.meanY
+	ls.meanY += dy / m
+	ls.cyy += dy * (y - ls.meanY)
+	ls.cxy += m / 2 * (y - ls.meanY)
+}
+
+// removeFirst excludes y, the value at x = 0, and moves every other value one position closer to 0,
+// which leaves the centered sums unchanged
+func (ls *leastSquares[T]) removeFirst(y T) {
+	if ls.count <= 1 {
+		*ls = leastSquares[T]{}
+		return
+	}
+
+	m := T(ls.count)
+	ls.count--
+	meanY := (m*ls.meanY - y) / T(ls.count)
+
+	// y is m/2 to the left of the mean of x after it is removed
+	ls.cyy -= (y - meanY) * (y - ls.meanY)
+	ls.cxy += m / 2 * (y - ls.meanY)
+	ls.meanY = meanY
+}
+
+// replaceLast replaces old, the value at x = count-1, with y
+func (ls *leastSquares[T]) replaceLast(old, y T) {
+	m := T(ls.count)
+	meanY := ls.meanY
+
+	// the last value is (count-1)/2 to the right of the mean of x
+	delta := y - old
+	ls.meanY += delta / m
+	ls.cyy += delta * (y - ls.meanY + old - meanY)
+	ls.cxy += (m - 1) / 2 * delta
+}
+
+// centered returns the sums of squares and products of the deviations from the means
+func (ls leastSquares[T]) centered() (cxx, cyy, cxy T) {
+	m := T(ls.count)
+	return m * (m*m - 1) / 12, ls.cyy, ls.cxy
+}
+
+// line returns the slope and intercept of the fit; the line is flat through the mean
+// when there are fewer than two values
+func (ls leastSquares[T]) line() (slope, intercept T) {
+	if ls.count == 0 {
+		return 0, 0
+	}
+
+	if ls.count > 1 {
+		cxx, _, cxy := ls.centered()
+		slope = cxy / cxx
+	}
+	return slope, ls.meanY - slope*T(ls.count-1)/2
+}
+
+// endPoint returns the value of the fit at the most recent value
+func (ls leastSquares[T]) endPoint() T {
+	slope, intercept := ls.line()
+	return intercept + slope*T(ls.count-1)
+}
+
+func (ls leastSquares[T]) rSquared() T {
+	if ls.count < 2 {
+		return 0
+	}
+
+	cxx, cyy, cxy := ls.centered()
+	if cyy <= 0 {
+		return 0
+	}
+	r2 := cxy * cxy / (cxx * cyy)
+	if r2 > 1 {
+		return 1
+	}
+	return r2
+}
+
+func (ls leastSquares[T]) standardError() T {
+	if ls.count < 3 {
+		return 0
+	}
+
+	cxx, cyy, cxy := ls.centered()
+	sse := cyy - cxy*cxy/cxx
+	if sse <= 0 {
+		return 0
+	}
+	return T(math.Sqrt(float64(sse / T(ls.count-2))))
+}
+
+// windowFit returns the fit of the count most recent values of the ring buffer data, the last at index,
+// taking last as the value at index, computed in float64 with two passes
+func windowFit[T Float](data []T, index, count int, last T) leastSquares[T] {
+	if count == 0 {
+		return leastSquares[T]{}
+	}
+
+	sum := 0.
+	for k := 0; k < count; k++ {
+		sum += float64(windowValue(data, index, k, last))
+	}
+	meanY := sum / float64(count)
+
+	// the value k periods before the last is at x = count-1-k
+	meanX := float64(count-1) / 2
+	cyy, cxy := 0., 0.
+	for k := 0; k < count; k++ {
+		dy := float64(windowValue(data, index, k, last)) - meanY
+		cyy += dy * dy
+		cxy += (float64(count-1-k) - meanX) * dy
+	}
+	return leastSquares[T]{count: count, meanY: T(meanY), cyy: T(cyy), cxy: T(cxy)}
+}
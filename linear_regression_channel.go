@@ -0,0 +1,131 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+LinearRegressionChannelOf returns the Linear Regression Channel of the last _n_ inputs: the end point
+of the least-squares line through the window, and two bands 'k' standard errors of the estimate away
+from it in both directions. Where BollingerBands surround the average of the window, the channel
+follows its trend.
+
+# Formula
+
+See LinearRegression documentation.
+
+* _LRC<sub>Middle Band</sub>_ - LinearRegression end point
+* _LRC<sub>Upper Band</sub>_ = LinearRegression + k * standard error of the estimate
+* _LRC<sub>Lower Band</sub>_ = LinearRegression - k * standard error of the estimate
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+* _k_ - multiplier of the standard error (greater than 0, usually 2.0)
+
+# Example
+```
+lrc, _ := NewLinearRegressionChannel(20, 2.)
+upper, middle, lower := lrc.Next(10.)
+```
+*/
+type LinearRegressionChannelOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+	k T
+
+	// internal parameters for calculations
+	lr *LinearRegressionOf[T]
+}
+
+// LinearRegressionChannel is a LinearRegressionChannelOf float64 values
+type LinearRegressionChannel = LinearRegressionChannelOf[float64]
+
+// NewLinearRegressionChannel creates a new LinearRegressionChannel with the given number of periods
+// and standard error multiplier
+// Example: NewLinearRegressionChannel(20, 2.)
+func NewLinearRegressionChannel(n int, k float64) (*LinearRegressionChannel, error) {
+	return NewLinearRegressionChannelOf(n, k)
+}
+
+// NewLinearRegressionChannelOf creates a new LinearRegressionChannelOf values of type T with the given
+// number of periods and standard error multiplier
+// Example: NewLinearRegressionChannelOf[float32](20, 2.)
+func NewLinearRegressionChannelOf[T Float](n int, k T) (*LinearRegressionChannelOf[T], error) {
+	if n <= 0 || !(k > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	lr, err := NewLinearRegressionOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+	return &LinearRegressionChannelOf[T]{
+		n: n,
+		k: k,
+
+		lr: lr,
+	}, nil
+}
+
+// Next takes the next input and returns the next upper, middle and lower LinearRegressionChannel values
+func (lrc *LinearRegressionChannelOf[T]) Next(input T) (upper, middle, lower T) {
+	lrc.lr.Next(input)
+	return lrc.bands(lrc.lr.fit)
+}
+
+// Peek returns the values Next would return for input without committing it
+func (lrc *LinearRegressionChannelOf[T]) Peek(input T) (upper, middle, lower T) {
+	return lrc.bands(lrc.lr.peek(input))
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated LinearRegressionChannel values.
+// If no input has been committed yet it behaves like Next.
+func (lrc *LinearRegressionChannelOf[T]) UpdateLast(input T) (upper, middle, lower T) {
+	lrc.lr.UpdateLast(input)
+	return lrc.bands(lrc.lr.fit)
+}
+
+// bands returns the channel around the end point of fit
+func (lrc *LinearRegressionChannelOf[T]) bands(fit leastSquares[T]) (upper, middle, lower T) {
+	middle = fit.endPoint()
+	width := lrc.k * fit.standardError()
+	return middle + width, middle, middle - width
+}
+
+// LinearRegression returns the LinearRegression the channel is built on, for its slope, forecast
+// and other statistics of the current fit
+func (lrc *LinearRegressionChannelOf[T]) LinearRegression() *LinearRegressionOf[T] {
+	return lrc.lr
+}
+
+// Compute feeds every value of inputs through Next and writes the bands to upper, middle and lower,
+// which are reused when they have enough capacity. It returns them resliced to the length of inputs.
+func (lrc *LinearRegressionChannelOf[T]) Compute(inputs, upper, middle, lower []T) ([]T, []T, []T) {
+	upper = resize(upper, len(inputs))
+	middle = resize(middle, len(inputs))
+	lower = resize(lower, len(inputs))
+	for i, input := range inputs {
+		upper[i], middle[i], lower[i] = lrc.Next(input)
+	}
+	return upper, middle, lower
+}
+
+// Reset resets the indicators to a clean state
+func (lrc *LinearRegressionChannelOf[T]) Reset() {
+	lrc.lr.Reset()
+}
+
+// Clone returns a deep copy of the indicator, including its LinearRegression
+func (lrc *LinearRegressionChannelOf[T]) Clone() *LinearRegressionChannelOf[T] {
+	clone := *lrc
+	clone.lr = lrc.lr.Clone()
+	return &clone
+}
+
+func (lrc *LinearRegressionChannelOf[T]) String() string {
+	return fmt.Sprintf("LRC(%d,%g)", lrc.n, float64(lrc.k))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLinearRegressionChannel(t *testing.T) {
+	lr, _ := NewLinearRegression(9)
+	tests := map[string]struct {
+		n       int
+		k       float64
+		want    *LinearRegressionChannel
+		wantErr error
+	}{
+		"negative n": {n: -3, k: 2., want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {n: 0, k: 2., want: nil, wantErr: ErrInvalidParameters},
+		"zero k":     {n: 9, k: 0., want: nil, wantErr: ErrInvalidParameters},
+		"NaN k":      {n: 9, k: math.NaN(), want: nil, wantErr: ErrInvalidParameters},
+		"positive":   {n: 9, k: 2., want: &LinearRegressionChannel{n: 9, k: 2., lr: lr}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewLinearRegressionChannel(tc.n, tc.k)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestLinearRegressionChannelNext(t *testing.T) {
+	lrc, _ := NewLinearRegressionChannel(4, 2.)
+	tests := []struct {
+		input  float64
+		upper  float64
+		middle float64
+		lower  float64
+	}{
+		{input: 2., upper: 2., middle: 2., lower: 2.},
+		{input: 8., upper: 8., middle: 8., lower: 8.},
+		{input: 0., upper: 13.7643, middle: 2.33333, lower: -9.09762},
+		{input: 4., upper: 11.5427, middle: 3.2, lower: -5.14266},
+		{input: 1., upper: 7.67137, middle: 0.7, lower: -6.27137},
+		{input: 9., upper: 13.4561, middle: 7.1, lower: 0.743901},
+		{input: 9., upper: 15.5718, middle: 9.2, lower: 2.82819},
+		{input: 0., upper: 16.325, middle: 4.3, lower: -7.72497},
+		{input: 3., upper: 8.17137, middle: 1.2, lower: -5.77137},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			upper, middle, lower := lrc.Next(tc.input)
+			diff := cmp.Diff([]float64{tc.upper, tc.middle, tc.lower}, []float64{upper, middle, lower}, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLinearRegressionChannelPeek(t *testing.T) {
+	lrc, _ := NewLinearRegressionChannel(4, 2.)
+	want, _ := NewLinearRegressionChannel(4, 2.)
+	for _, input := range []float64{2., 8., 0., 4., 1., 9., 9., 0., 3.} {
+		lrc.Peek(1000.) // must not be committed
+		upper, middle, lower := lrc.Peek(input)
+		wantUpper, wantMiddle, wantLower := want.Next(input)
+		assert.Equal(t, []float64{wantUpper, wantMiddle, wantLower}, []float64{upper, middle, lower}, "must return the same values as Next")
+
+		lrc.Next(input)
+		assert.Equal(t, want, lrc, "must not change the state")
+	}
+}
+
+func TestLinearRegressionChannelUpdateLast(t *testing.T) {
+	lrc, _ := NewLinearRegressionChannel(4, 2.)
+	want, _ := NewLinearRegressionChannel(4, 2.)
+	for _, input := range []float64{2., 8., 0., 4., 1.} {
+		lrc.Next(input)
+		want.Next(input)
+	}
+
+	lrc.Next(100.)
+	upper, middle, lower := lrc.UpdateLast(9.)
+	wantUpper, wantMiddle, wantLower := want.Next(9.)
+	diff := cmp.Diff([]float64{wantUpper, wantMiddle, wantLower}, []float64{upper, middle, lower}, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestLinearRegressionChannelCompute(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+
+	stream, _ := NewLinearRegressionChannel(4, 2.)
+	wantUpper := make([]float64, len(inputs))
+	wantMiddle := make([]float64, len(inputs))
+	wantLower := make([]float64, len(inputs))
+	for i, input := range inputs {
+		wantUpper[i], wantMiddle[i], wantLower[i] = stream.Next(input)
+	}
+
+	batch, _ := NewLinearRegressionChannel(4, 2.)
+	middle := make([]float64, len(inputs))
+	gotUpper, gotMiddle, gotLower := batch.Compute(inputs, nil, middle, nil)
+	assert.Equal(t, wantUpper, gotUpper, "must return the same upper band as Next")
+	assert.Equal(t, wantMiddle, gotMiddle, "must return the same middle band as Next")
+	assert.Equal(t, wantLower, gotLower, "must return the same lower band as Next")
+	assert.Same(t, &middle[0], &gotMiddle[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestLinearRegressionChannelClone(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	lrc, _ := NewLinearRegressionChannel(4, 2.)
+	lrc.Compute(inputs, nil, nil, nil)
+	want, _ := NewLinearRegressionChannel(4, 2.)
+	want.Compute(inputs, nil, nil, nil)
+
+	clone := lrc.Clone()
+	wantUpper, wantMiddle, wantLower := want.Peek(100.)
+	upper, middle, lower := clone.Next(100.)
+	assert.Equal(t, []float64{wantUpper, wantMiddle, wantLower}, []float64{upper, middle, lower}, "clone must continue from the same state")
+	assert.Equal(t, want, lrc, "original must not see the clone's inputs")
+}
+
+func TestLinearRegressionChannelReset(t *testing.T) {
+	lrc, _ := NewLinearRegressionChannel(4, 2.)
+	lrc.Compute([]float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}, nil, nil, nil)
+	lrc.Reset()
+
+	want, _ := NewLinearRegressionChannel(4, 2.)
+	assert.Equal(t, want, lrc, "must return to a clean state")
+}
+
+func TestLinearRegressionChannelString(t *testing.T) {
+	lrc, _ := NewLinearRegressionChannel(4, 2.5)
+	want := "LRC(4,2.5)"
+	got := lrc.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
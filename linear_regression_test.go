@@ -0,0 +1,264 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLinearRegression(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *LinearRegression
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &LinearRegression{n: 9, data: make([]float64, 9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewLinearRegression(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestLinearRegressionNext(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 8., want: 8.},
+		{input: 0., want: 2.33333},
+		{input: 4., want: 3.2},
+		{input: 1., want: 0.7},
+		{input: 9., want: 7.1},
+		{input: 9., want: 9.2},
+		{input: 0., want: 4.3},
+		{input: 3., want: 1.2},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLinearRegressionPeek(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 8., want: 8.},
+		{input: 0., want: 2.33333},
+		{input: 4., want: 3.2},
+		{input: 1., want: 0.7},
+		{input: 9., want: 7.1},
+		{input: 9., want: 9.2},
+		{input: 0., want: 4.3},
+		{input: 3., want: 1.2},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLinearRegressionUpdateLast(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 2., update: 4., want: 4.},
+		{input: 8., update: 1., want: 1.},
+		{input: 0., update: 0., want: -0.333333},
+		{input: 4., update: 7., want: 4.2},
+		{input: 1., update: -3., want: 0.5},
+		{input: 9., update: 5., want: 3.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLinearRegressionUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	diff := cmp.Diff(5., sd.UpdateLast(5.), approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(7., sd.Next(7.), approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestLinearRegressionCompute(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+
+	stream, _ := NewLinearRegression(4)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewLinearRegression(4)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeLinearRegression(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	_, err := ComputeLinearRegression(0, inputs, nil, false)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+
+	stream, _ := NewLinearRegression(4)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+	for i := 0; i < 3; i++ {
+		want[i] = math.NaN()
+	}
+
+	got, err := ComputeLinearRegression(4, inputs, nil, true)
+	assert.NoError(t, err)
+	diff := cmp.Diff(want, got, nanComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestLinearRegressionClone(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	sd, _ := NewLinearRegression(4)
+	sd.Compute(inputs, nil)
+	want, _ := NewLinearRegression(4)
+	want.Compute(inputs, nil)
+
+	clone := sd.Clone()
+	assert.Equal(t, want.Peek(100.), clone.Next(100.), "clone must continue from the same state")
+	assert.Equal(t, want, sd, "original must not see the clone's inputs")
+}
+
+func TestLinearRegressionReset(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	sd.Compute([]float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}, nil)
+	sd.Reset()
+
+	want, _ := NewLinearRegression(4)
+	assert.Equal(t, want, sd, "must return to a clean state")
+}
+
+func TestLinearRegressionStatistics(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	tests := []struct {
+		input     float64
+		slope     float64
+		intercept float64
+		forecast  float64
+		angle     float64
+		rSquared  float64
+		stdErr    float64
+	}{
+		{input: 2., slope: 0., intercept: 2., forecast: 2., angle: 0., rSquared: 0., stdErr: 0.},
+		{input: 8., slope: 6., intercept: 2., forecast: 14., angle: 80.5377, rSquared: 1., stdErr: 0.},
+		{input: 0., slope: -1., intercept: 4.33333, forecast: 1.33333, angle: -45., rSquared: 0.0576923, stdErr: 5.71548},
+		{input: 4., slope: -0.2, intercept: 3.8, forecast: 3., angle: -11.3099, rSquared: 0.00571429, stdErr: 4.17133},
+		{input: 1., slope: -1.7, intercept: 5.8, forecast: -1., angle: -59.5345, rSquared: 0.372903, stdErr: 3.48569},
+		{input: 9., slope: 2.4, intercept: -0.1, forecast: 9.5, angle: 67.3801, rSquared: 0.587755, stdErr: 3.17805},
+		{input: 9., slope: 2.3, intercept: 2.3, forecast: 11.5, angle: 66.5014, rSquared: 0.565775, stdErr: 3.18591},
+		{input: 0., slope: -0.3, intercept: 5.2, forecast: 4., angle: -16.6992, rSquared: 0.00618557, stdErr: 6.01249},
+		{input: 3., slope: -2.7, intercept: 9.3, forecast: -1.5, angle: -69.6769, rSquared: 0.6, stdErr: 3.48569},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			want := []float64{tc.slope, tc.intercept, tc.forecast, tc.angle, tc.rSquared, tc.stdErr}
+			got := []float64{sd.Slope(), sd.Intercept(), sd.Forecast(), sd.Angle(), sd.RSquared(), sd.StandardError()}
+			diff := cmp.Diff(want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLinearRegressionOfFloat32LongStream(t *testing.T) {
+	inputs := driftInputs[float32](3000007)
+	sd, _ := NewLinearRegressionOf[float32](10)
+	for i, input := range inputs {
+		sd.Next(input + 1.)
+		if i%2 == 0 {
+			sd.UpdateLast(input)
+		} else {
+			sd.UpdateLast(input + 1.)
+			sd.UpdateLast(input)
+		}
+	}
+
+	fresh, _ := NewLinearRegression(10)
+	for _, input := range inputs[len(inputs)-10:] {
+		fresh.Next(float64(input))
+	}
+	want := []float64{fresh.Forecast(), fresh.Slope(), fresh.RSquared(), fresh.StandardError()}
+	got := []float64{float64(sd.Forecast()), float64(sd.Slope()), float64(sd.RSquared()), float64(sd.StandardError())}
+	for i := range want {
+		assert.InEpsilon(t, want[i], got[i], 1e-2, "must match a fresh window after a long stream")
+	}
+}
+
+func TestLinearRegressionString(t *testing.T) {
+	sd, _ := NewLinearRegression(4)
+	want := "LinReg(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+LogReturnOf returns the logarithmic return of the input over _n_ periods. Unlike simple returns,
+log returns add up over consecutive periods.
+
+# Formula
+
+r<sub>t</sub> = ln(p<sub>t</sub> / p<sub>t-n</sub>)
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the first input stands in for p<sub>t-n</sub>.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+lr, _ := NewLogReturn(1)
+lr.Next(10.)
+```
+*/
+type LogReturnOf[T Float] struct {
+	lookback[T]
+}
+
+// LogReturn is a LogReturnOf float64 values
+type LogReturn = LogReturnOf[float64]
+
+// NewLogReturn creates a new LogReturn with the given number of periods
+// Example: NewLogReturn(1)
+func NewLogReturn(n int) (*LogReturn, error) {
+	return NewLogReturnOf[float64](n)
+}
+
+// NewLogReturnOf creates a new LogReturnOf values of type T with the given number of periods
+// Example: NewLogReturnOf[float32](1)
+func NewLogReturnOf[T Float](n int) (*LogReturnOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &LogReturnOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next LogReturn value
+func (lr *LogReturnOf[T]) Next(input T) T {
+	past := lr.push(input)
+	return logRatio(input, past)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (lr *LogReturnOf[T]) Peek(input T) T {
+	past := lr.peek(input)
+	return logRatio(input, past)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated LogReturn value.
+// If no input has been committed yet it behaves like Next.
+func (lr *LogReturnOf[T]) UpdateLast(input T) T {
+	past := lr.replaceLast(input)
+	return logRatio(input, past)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (lr *LogReturnOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = lr.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (lr *LogReturnOf[T]) Reset() {
+	lr.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (lr *LogReturnOf[T]) Clone() *LogReturnOf[T] {
+	return &LogReturnOf[T]{
+		lookback: lr.clone(),
+	}
+}
+
+func (lr *LogReturnOf[T]) String() string {
+	return fmt.Sprintf("LogReturn(%d)", lr.n)
+}
+
+// ComputeLogReturn computes a LogReturn with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputeLogReturn[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	lr, err := NewLogReturnOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = lr.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogReturn(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *LogReturn
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &LogReturn{lookback: newLookback[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewLogReturn(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestLogReturnNext(t *testing.T) {
+	sd, _ := NewLogReturn(2)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 1.38629},
+		{input: 1., want: -0.693147},
+		{input: 4., want: -0.693147},
+		{input: 1., want: 0.},
+		{input: 9., want: 0.81093},
+		{input: 9., want: 2.19722},
+		{input: 5., want: -0.587787},
+		{input: 3., want: -1.09861},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLogReturnComposed(t *testing.T) {
+	// log returns add up, so summing one-period returns gives the return over the window
+	lr, _ := NewLogReturn(1)
+	sum, _ := NewRollingSum(3)
+	want, _ := NewLogReturn(3)
+	for _, input := range []float64{2., 8., 1., 4., 1., 9., 9., 5., 3.} {
+		diff := cmp.Diff(want.Next(input), sum.Next(lr.Next(input)), approxComparer)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+}
+
+func TestComputeLogReturn(t *testing.T) {
+	inputs := []float64{2., 8., 1., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 2, fillNaN: false, want: []float64{0., 1.38629, -0.693147, -0.693147, 0.}},
+		"fill NaN": {n: 2, fillNaN: true, want: []float64{math.NaN(), math.NaN(), -0.693147, -0.693147, 0.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeLogReturn(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLogReturnLookback(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"warm-up":        {n: 3, inputs: []float64{5., 6., 5.}, want: 0.},
+		"round trip":     {n: 2, inputs: []float64{4., 8., 4.}, want: 0.},
+		"oldest evicted": {n: 3, inputs: []float64{1., 5., 6., 7., math.E}, want: -0.609438},
+		"zero input":     {n: 1, inputs: []float64{1., 0.}, want: math.Inf(-1)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewLogReturn(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLogReturnString(t *testing.T) {
+	sd, _ := NewLogReturn(2)
+	want := "LogReturn(2)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
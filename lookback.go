@@ -0,0 +1,80 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+// lookback is a ring buffer of the last n+1 inputs, giving the input n periods before the most recent one.
+// Until n inputs have preceded it, the first input stands in for the input n periods ago.
+type lookback[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index int
+	count int
+
+	// slice of data needed for calculation
+	data []T
+}
+
+func newLookback[T Float](n int) lookback[T] {
+	return lookback[T]{
+		n: n,
+
+		index: 0,
+		count: 0,
+
+		data: make([]T, n+1),
+	}
+}
+
+// push adds input to the buffer and returns the input n periods ago
+func (lb *lookback[T]) push(input T) T {
+	lb.index = (lb.index + 1) % len(lb.data)
+	lb.data[lb.index] = input
+	if lb.count < len(lb.data) {
+		lb.count++
+	}
+	return lb.ago()
+}
+
+// peek returns the value push would return for input without changing the buffer
+func (lb *lookback[T]) peek(input T) T {
+	switch {
+	case lb.count == 0:
+		return input
+	case lb.count < len(lb.data):
+		return lb.ago()
+	default:
+		return lb.data[(lb.index+2)%len(lb.data)]
+	}
+}
+
+// replaceLast replaces the most recent input with input, pushing it if the buffer is empty,
+// and returns the input n periods ago
+func (lb *lookback[T]) replaceLast(input T) T {
+	if lb.count == 0 {
+		return lb.push(input)
+	}
+	lb.data[lb.index] = input
+	return lb.ago()
+}
+
+// ago returns the input n periods before the most recent one, or the first input if there are fewer
+func (lb *lookback[T]) ago() T {
+	size := len(lb.data)
+	return lb.data[(lb.index-lb.count+1+size)%size]
+}
+
+func (lb *lookback[T]) reset() {
+	lb.index = 0
+	lb.count = 0
+	lb.data = make([]T, lb.n+1)
+}
+
+func (lb lookback[T]) clone() lookback[T] {
+	lb.data = cloneSlice(lb.data)
+	return lb
+}
@@ -11,7 +11,7 @@ import (
 )
 
 /*
-Maximum returns the highest value in a given time frame
+MaximumOf returns the highest value in a given time frame
 
 # Parameters
 
@@ -21,43 +21,93 @@ Maximum returns the highest value in a given time frame
 ```
 ```
 */
-type Maximum struct {
+type MaximumOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
 	// internal parameters for calculations
 	maxIndex int
 	curIndex int
+	count    int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// Maximum is a MaximumOf float64 values
+type Maximum = MaximumOf[float64]
+
 // NewMaximum creates a new Maximum with the given number of periods
 // Example: NewMaximum(9)
 func NewMaximum(n int) (*Maximum, error) {
+	return NewMaximumOf[float64](n)
+}
+
+// NewMaximumOf creates a new MaximumOf values of type T with the given number of periods
+// Example: NewMaximumOf[float32](9)
+func NewMaximumOf[T Float](n int) (*MaximumOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	data := make([]float64, n)
+	data := make([]T, n)
 	for i := range data {
-		data[i] = math.Inf(-1)
+		data[i] = T(math.Inf(-1))
 	}
-	return &Maximum{
+	return &MaximumOf[T]{
 		n: n,
 
 		maxIndex: 0,
 		curIndex: 0,
+		count:    0,
 
 		data: data,
 	}, nil
 }
 
 // Next takes the next input and returns the next Maximum value
-func (m *Maximum) Next(input float64) float64 {
+func (m *MaximumOf[T]) Next(input T) T {
 	// add input to data
 	m.curIndex = (m.curIndex + 1) % m.n
+	if m.count < m.n {
+		m.count++
+	}
+
+	return m.set(input)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *MaximumOf[T]) Peek(input T) T {
+	index := (m.curIndex + 1) % m.n
+	if input > m.data[m.maxIndex] {
+		return input
+	}
+	if index != m.maxIndex {
+		return m.data[m.maxIndex]
+	}
+
+	// the current maximum would leave the window
+	max := input
+	for i, v := range m.data {
+		if i != index && v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Maximum value.
+// If no input has been committed yet it behaves like Next.
+func (m *MaximumOf[T]) UpdateLast(input T) T {
+	if m.count == 0 {
+		return m.Next(input)
+	}
+
+	return m.set(input)
+}
+
+// set stores input at the current index and updates the index of the maximum
+func (m *MaximumOf[T]) set(input T) T {
 	m.data[m.curIndex] = input
 
 	if input > m.data[m.maxIndex] {
@@ -69,8 +119,8 @@ func (m *Maximum) Next(input float64) float64 {
 	return m.data[m.maxIndex]
 }
 
-func findMaxIndex(data []float64) int {
-	max := math.Inf(-1)
+func findMaxIndex[T Float](data []T) int {
+	max := T(math.Inf(-1))
 	index := 0
 
 	for i, v := range data {
@@ -83,17 +133,50 @@ func findMaxIndex(data []float64) int {
 	return index
 }
 
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *MaximumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = m.Next(input)
+	}
+	return out
+}
+
 // Reset resets the indicators to a clean state
-func (m *Maximum) Reset() {
-	data := make([]float64, m.n)
+func (m *MaximumOf[T]) Reset() {
+	data := make([]T, m.n)
 	for i := range data {
-		data[i] = math.Inf(-1)
+		data[i] = T(math.Inf(-1))
 	}
 	m.data = data
 	m.curIndex = 0
 	m.maxIndex = 0
+	m.count = 0
 }
 
-func (m *Maximum) String() string {
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *MaximumOf[T]) Clone() *MaximumOf[T] {
+	clone := *m
+	clone.data = cloneSlice(m.data)
+	return &clone
+}
+
+func (m *MaximumOf[T]) String() string {
 	return fmt.Sprintf("Max(%d)", m.n)
 }
+
+// ComputeMaximum computes a Maximum with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMaximum[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	m, err := NewMaximumOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = m.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
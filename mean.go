@@ -10,7 +10,7 @@ import (
 )
 
 /*
-Mean returns the arithmetic mean value of the last n values.
+MeanOf returns the arithmetic mean value of the last n values.
 
 # Formula
 
@@ -28,7 +28,7 @@ Where:
 ```
 ```
 */
-type Mean struct {
+type MeanOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
@@ -36,20 +36,31 @@ type Mean struct {
 	index int
 	count int
 
-	sum float64
+	sum T
+	// number of updates since sum was last recomputed from data
+	updates int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// Mean is a MeanOf float64 values
+type Mean = MeanOf[float64]
+
 // NewMean creates a new Mean with the given number of periods
 // Example: NewMean(9)
 func NewMean(n int) (*Mean, error) {
+	return NewMeanOf[float64](n)
+}
+
+// NewMeanOf creates a new MeanOf values of type T with the given number of periods
+// Example: NewMeanOf[float32](9)
+func NewMeanOf[T Float](n int) (*MeanOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	return &Mean{
+	return &MeanOf[T]{
 		n: n,
 
 		index: 0,
@@ -57,12 +68,12 @@ func NewMean(n int) (*Mean, error) {
 
 		sum: 0,
 
-		data: make([]float64, n),
+		data: make([]T, n),
 	}, nil
 }
 
 // Next takes the next input and returns the next Mean value
-func (m *Mean) Next(input float64) float64 {
+func (m *MeanOf[T]) Next(input T) T {
 	// add input to data
 	m.index = (m.index + 1) % m.n
 	oldValue := m.data[m.index]
@@ -77,19 +88,109 @@ func (m *Mean) Next(input float64) float64 {
 		m.sum += delta
 	}
 
-	return m.sum / float64(m.count)
+	m.resync()
+	return m.sum / T(m.count)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *MeanOf[T]) Peek(input T) T {
+	index := (m.index + 1) % m.n
+	count := m.count
+	if count < m.n {
+		count++
+	}
+
+	sum := m.sum + input - m.data[index]
+	if m.updates+1 >= m.n {
+		sum = windowSum(m.data, index, count, input)
+	}
+	return sum / T(count)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Mean value.
+// If no input has been committed yet it behaves like Next.
+func (m *MeanOf[T]) UpdateLast(input T) T {
+	if m.count == 0 {
+		return m.Next(input)
+	}
+
+	m.sum += input - m.data[m.index]
+	m.data[m.index] = input
+	m.resync()
+	return m.sum / T(m.count)
+}
+
+// resync recomputes sum from data once every n updates, so that its rounding errors do not accumulate
+// over long streams
+func (m *MeanOf[T]) resync() {
+	m.updates++
+	if m.updates >= m.n {
+		m.sum = windowSum(m.data, m.index, m.count, m.data[m.index])
+		m.updates = 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *MeanOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+
+	n, index, count, sum, updates, data := m.n, m.index, m.count, m.sum, m.updates, m.data
+	for i, input := range inputs {
+		index = (index + 1) % n
+		oldValue := data[index]
+		data[index] = input
+
+		if count < n {
+			count++
+			sum += input
+		} else {
+			sum += input - oldValue
+		}
+		if updates++; updates >= n {
+			sum, updates = windowSum(data, index, count, data[index]), 0
+		}
+
+		out[i] = sum / T(count)
+	}
+
+	m.index, m.count, m.sum, m.updates = index, count, sum, updates
+	return out
 }
 
 // Reset resets the indicators to a clean state
-func (m *Mean) Reset() {
+func (m *MeanOf[T]) Reset() {
 	m.index = 0
 	m.count = 0
 
 	m.sum = 0
+	m.updates = 0
 
-	m.data = make([]float64, m.n)
+	m.data = make([]T, m.n)
 }
 
-func (m *Mean) String() string {
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *MeanOf[T]) Clone() *MeanOf[T] {
+	clone := *m
+	clone.data = cloneSlice(m.data)
+	return &clone
+}
+
+func (m *MeanOf[T]) String() string {
 	return fmt.Sprintf("Mean(%d)", m.n)
 }
+
+// ComputeMean computes a Mean with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMean[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	m, err := NewMeanOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = m.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
@@ -13,7 +13,7 @@ import (
 )
 
 /*
-Median returns the median value of the last n values.
+MedianOf returns the median value of the last n values.
 
 Where:
 
@@ -27,7 +27,7 @@ Where:
 ```
 ```
 */
-type Median struct {
+type MedianOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
@@ -35,27 +35,36 @@ type Median struct {
 	index int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// Median is a MedianOf float64 values
+type Median = MedianOf[float64]
+
 // NewMedian creates a new Median with the given number of periods
 // Example: NewMedian(9)
 func NewMedian(n int) (*Median, error) {
+	return NewMedianOf[float64](n)
+}
+
+// NewMedianOf creates a new MedianOf values of type T with the given number of periods
+// Example: NewMedianOf[float32](9)
+func NewMedianOf[T Float](n int) (*MedianOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	return &Median{
+	return &MedianOf[T]{
 		n: n,
 
 		index: 0,
 
-		data: make([]float64, 0, n),
+		data: make([]T, 0, n),
 	}, nil
 }
 
 // Next takes the next input and returns the next Median value
-func (m *Median) Next(input float64) float64 {
+func (m *MedianOf[T]) Next(input T) T {
 	// add input to data
 	if len(m.data) < m.n {
 		m.data = append(m.data, input)
@@ -64,21 +73,60 @@ func (m *Median) Next(input float64) float64 {
 		m.data[m.index] = input
 	}
 	m.index++
-	return quickselectMedian(m.data, defaultPivotFunc)
+	return quickselectMedian(m.data, defaultPivotFunc[T])
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *MedianOf[T]) Peek(input T) T {
+	data := cloneSlice(m.data)
+	if len(data) < m.n {
+		data = append(data, input)
+	} else {
+		data[m.index%m.n] = input
+	}
+	return quickselectMedian(data, defaultPivotFunc[T])
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Median value.
+// If no input has been committed yet it behaves like Next.
+func (m *MedianOf[T]) UpdateLast(input T) T {
+	if len(m.data) == 0 {
+		return m.Next(input)
+	}
+
+	m.data[m.index-1] = input
+	return quickselectMedian(m.data, defaultPivotFunc[T])
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *MedianOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = m.Next(input)
+	}
+	return out
 }
 
 // Reset resets the indicators to a clean state
-func (m *Median) Reset() {
+func (m *MedianOf[T]) Reset() {
 	m.index = 0
 
-	m.data = make([]float64, 0, m.n)
+	m.data = make([]T, 0, m.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *MedianOf[T]) Clone() *MedianOf[T] {
+	clone := *m
+	clone.data = cloneSlice(m.data)
+	return &clone
 }
 
-func (m *Median) String() string {
+func (m *MedianOf[T]) String() string {
 	return fmt.Sprintf("Median(%d)", m.n)
 }
 
-func quickselect(l []float64, k int, pivotFn func([]float64) float64) float64 {
+func quickselect[T Float](l []T, k int, pivotFn func([]T) T) T {
 	/*
 	   Select the kth element in l (0 based)
 	   :param l: List of numerics
@@ -95,7 +143,7 @@ func quickselect(l []float64, k int, pivotFn func([]float64) float64) float64 {
 	}
 
 	pivot := pivotFn(l)
-	var lows, highs, pivots []float64
+	var lows, highs, pivots []T
 	for _, v := range l {
 		if v < pivot {
 			lows = append(lows, v)
@@ -121,7 +169,7 @@ func quickselect(l []float64, k int, pivotFn func([]float64) float64) float64 {
 	}
 }
 
-func quickselectMedian(l []float64, pivotFn func([]float64) float64) float64 {
+func quickselectMedian[T Float](l []T, pivotFn func([]T) T) T {
 	if len(l)%2 == 1 {
 		return quickselect(l, len(l)/2, pivotFn)
 	}
@@ -129,7 +177,22 @@ func quickselectMedian(l []float64, pivotFn func([]float64) float64) float64 {
 	return 0.5 * (quickselect(l, len(l)/2-1, pivotFn) + quickselect(l, len(l)/2, pivotFn))
 }
 
-func defaultPivotFunc(l []float64) float64 {
+func defaultPivotFunc[T Float](l []T) T {
 	rand.Seed(time.Now().Unix())
 	return l[rand.Intn(len(l))]
 }
+
+// ComputeMedian computes a Median with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMedian[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	m, err := NewMedianOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = m.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
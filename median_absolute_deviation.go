@@ -0,0 +1,113 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+MedianAbsoluteDeviationOf returns the median absolute deviation (MAD) of the last n values,
+a measure of dispersion that, unlike StandardDeviation, is robust to outliers.
+The window is kept sorted as values enter and leave it.
+
+# Formula
+
+MAD = median(|x<sub>i</sub> - median(x)|)
+
+Multiply by 1.4826 to estimate the standard deviation of normally distributed values.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+mad, _ := NewMedianAbsoluteDeviation(20)
+mad.Next(10.)
+```
+*/
+type MedianAbsoluteDeviationOf[T Float] struct {
+	sortedWindow[T]
+}
+
+// MedianAbsoluteDeviation is a MedianAbsoluteDeviationOf float64 values
+type MedianAbsoluteDeviation = MedianAbsoluteDeviationOf[float64]
+
+// NewMedianAbsoluteDeviation creates a new MedianAbsoluteDeviation with the given number of periods
+// Example: NewMedianAbsoluteDeviation(20)
+func NewMedianAbsoluteDeviation(n int) (*MedianAbsoluteDeviation, error) {
+	return NewMedianAbsoluteDeviationOf[float64](n)
+}
+
+// NewMedianAbsoluteDeviationOf creates a new MedianAbsoluteDeviationOf values of type T with the given number of periods
+// Example: NewMedianAbsoluteDeviationOf[float32](20)
+func NewMedianAbsoluteDeviationOf[T Float](n int) (*MedianAbsoluteDeviationOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &MedianAbsoluteDeviationOf[T]{
+		sortedWindow: newSortedWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next MedianAbsoluteDeviation value
+func (mad *MedianAbsoluteDeviationOf[T]) Next(input T) T {
+	mad.push(input)
+	return medianAbsoluteDeviation(mad.sorted)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (mad *MedianAbsoluteDeviationOf[T]) Peek(input T) T {
+	return mad.peek(input, medianAbsoluteDeviation[T])
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated MedianAbsoluteDeviation value.
+// If no input has been committed yet it behaves like Next.
+func (mad *MedianAbsoluteDeviationOf[T]) UpdateLast(input T) T {
+	mad.replaceLast(input)
+	return medianAbsoluteDeviation(mad.sorted)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (mad *MedianAbsoluteDeviationOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = mad.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (mad *MedianAbsoluteDeviationOf[T]) Reset() {
+	mad.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (mad *MedianAbsoluteDeviationOf[T]) Clone() *MedianAbsoluteDeviationOf[T] {
+	return &MedianAbsoluteDeviationOf[T]{
+		sortedWindow: mad.clone(),
+	}
+}
+
+func (mad *MedianAbsoluteDeviationOf[T]) String() string {
+	return fmt.Sprintf("MAD(%d)", mad.n)
+}
+
+// ComputeMedianAbsoluteDeviation computes a MedianAbsoluteDeviation with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMedianAbsoluteDeviation[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	mad, err := NewMedianAbsoluteDeviationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = mad.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
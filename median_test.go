@@ -6,6 +6,7 @@ Licensed under terms of MIT license (see LICENSE)
 package tago
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -110,6 +111,171 @@ func TestMedianReset(t *testing.T) {
 	}
 }
 
+func TestMedianPeek(t *testing.T) {
+	sd, _ := NewMedian(3)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 10., want: 10.},
+		{input: 20., want: 15.},
+		{input: 30., want: 20.},
+		{input: 15., want: 20.},
+		{input: 40., want: 30.},
+		{input: 25., want: 25.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMedianUpdateLast(t *testing.T) {
+	sd, _ := NewMedian(3)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 10., update: 20., want: 20.},
+		{input: 10., update: 30., want: 25.},
+		{input: 0., update: 50., want: 30.},
+		{input: 40., update: 10., want: 30.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMedianUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewMedian(3)
+	diff := cmp.Diff(10., sd.UpdateLast(10.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(15., sd.Next(20.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestMedianCompute(t *testing.T) {
+	inputs := []float64{10., 20., 30., 15., 40., 25.}
+
+	stream, _ := NewMedian(3)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewMedian(3)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeMedian(t *testing.T) {
+	inputs := []float64{10., 20., 30., 15.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 3, fillNaN: false, want: []float64{10., 15., 20., 20.}},
+		"fill NaN": {n: 3, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 20., 20.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeMedian(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMedianClone(t *testing.T) {
+	sd, _ := NewMedian(3)
+	sd.Next(10.)
+	sd.Next(20.)
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone, "clone must start from the same state")
+
+	tests := []struct {
+		indicator *Median
+		input     float64
+		want      float64
+	}{
+		{indicator: clone, input: 30., want: 20.},
+		{indicator: sd, input: 0., want: 10.},
+		{indicator: clone, input: 40., want: 30.},
+		{indicator: sd, input: 5., want: 5.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tc.indicator.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMedianOfFloat32(t *testing.T) {
+	sd, _ := NewMedianOf[float32](3)
+	tests := []struct {
+		input float32
+		want  float64
+	}{
+		{input: 10., want: 10.},
+		{input: 20., want: 15.},
+		{input: 30., want: 20.},
+		{input: 15., want: 20.},
+		{input: 40., want: 30.},
+		{input: 25., want: 25.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, float64(got), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestMedianString(t *testing.T) {
 	sd, _ := NewMedian(4)
 	want := "Median(4)"
@@ -119,3 +285,24 @@ func TestMedianString(t *testing.T) {
 		t.Fatalf(diff)
 	}
 }
+
+func BenchmarkMedianNext(b *testing.B) {
+	inputs := benchmarkInputs()
+	sd, _ := NewMedian(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			sd.Next(input)
+		}
+	}
+}
+
+func BenchmarkMedianCompute(b *testing.B) {
+	inputs := benchmarkInputs()
+	out := make([]float64, len(inputs))
+	sd, _ := NewMedian(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = sd.Compute(inputs, out)
+	}
+}
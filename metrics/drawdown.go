@@ -0,0 +1,223 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"math"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+/*
+RunningMaxDrawdownOf returns the largest drawdown of an equity curve so far, the largest fall from a peak
+as a fraction of the peak, 0.25 for a fall of 25%.
+
+# Formula
+
+MDD = max(1 - E<sub>t</sub> / max(E<sub>0</sub> ... E<sub>t</sub>))
+
+# Example
+```
+mdd := NewRunningMaxDrawdown()
+mdd.Next(10000.)
+```
+*/
+type RunningMaxDrawdownOf[T tago.Float] struct {
+	// internal parameters for calculations
+	peak     T
+	drawdown T
+	max      T
+	count    int
+}
+
+// RunningMaxDrawdown is a RunningMaxDrawdownOf float64 values
+type RunningMaxDrawdown = RunningMaxDrawdownOf[float64]
+
+// NewRunningMaxDrawdown creates a new RunningMaxDrawdown
+func NewRunningMaxDrawdown() *RunningMaxDrawdown {
+	return NewRunningMaxDrawdownOf[float64]()
+}
+
+// NewRunningMaxDrawdownOf creates a new RunningMaxDrawdownOf values of type T
+func NewRunningMaxDrawdownOf[T tago.Float]() *RunningMaxDrawdownOf[T] {
+	return &RunningMaxDrawdownOf[T]{}
+}
+
+// Next takes the next equity and returns the next RunningMaxDrawdown value
+func (mdd *RunningMaxDrawdownOf[T]) Next(equity T) T {
+	if mdd.count == 0 || equity > mdd.peak {
+		mdd.peak = equity
+	}
+	mdd.count++
+
+	mdd.drawdown = drawdown(mdd.peak, equity)
+	if mdd.drawdown > mdd.max {
+		mdd.max = mdd.drawdown
+	}
+	return mdd.max
+}
+
+// Drawdown returns the current drawdown, the fall of the last equity from the peak
+func (mdd *RunningMaxDrawdownOf[T]) Drawdown() T {
+	return mdd.drawdown
+}
+
+// Reset resets the indicators to a clean state
+func (mdd *RunningMaxDrawdownOf[T]) Reset() {
+	*mdd = RunningMaxDrawdownOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (mdd *RunningMaxDrawdownOf[T]) Clone() *RunningMaxDrawdownOf[T] {
+	clone := *mdd
+	return &clone
+}
+
+func (mdd *RunningMaxDrawdownOf[T]) String() string {
+	return "MaxDrawdown"
+}
+
+// MaxDrawdown returns the largest drawdown of the equity curve, see RunningMaxDrawdown
+func MaxDrawdown[T tago.Float](equity []T) T {
+	return last[T](NewRunningMaxDrawdownOf[T](), equity)
+}
+
+/*
+RunningDrawdownDurationOf returns the longest drawdown of an equity curve so far, the largest number of consecutive
+periods below a previous peak, including the drawdown still in progress.
+
+# Example
+```
+duration := NewRunningDrawdownDuration()
+duration.Next(10000.)
+```
+*/
+type RunningDrawdownDurationOf[T tago.Float] struct {
+	// internal parameters for calculations
+	peak      T
+	peakIndex int
+	index     int
+	longest   int
+}
+
+// RunningDrawdownDuration is a RunningDrawdownDurationOf float64 values
+type RunningDrawdownDuration = RunningDrawdownDurationOf[float64]
+
+// NewRunningDrawdownDuration creates a new RunningDrawdownDuration
+func NewRunningDrawdownDuration() *RunningDrawdownDuration {
+	return NewRunningDrawdownDurationOf[float64]()
+}
+
+// NewRunningDrawdownDurationOf creates a new RunningDrawdownDurationOf values of type T
+func NewRunningDrawdownDurationOf[T tago.Float]() *RunningDrawdownDurationOf[T] {
+	return &RunningDrawdownDurationOf[T]{}
+}
+
+// Next takes the next equity and returns the next RunningDrawdownDuration value, in periods
+func (dd *RunningDrawdownDurationOf[T]) Next(equity T) T {
+	if dd.index == 0 || equity >= dd.peak {
+		dd.peak, dd.peakIndex = equity, dd.index
+	}
+	if duration := dd.index - dd.peakIndex; duration > dd.longest {
+		dd.longest = duration
+	}
+	dd.index++
+	return T(dd.longest)
+}
+
+// Reset resets the indicators to a clean state
+func (dd *RunningDrawdownDurationOf[T]) Reset() {
+	*dd = RunningDrawdownDurationOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (dd *RunningDrawdownDurationOf[T]) Clone() *RunningDrawdownDurationOf[T] {
+	clone := *dd
+	return &clone
+}
+
+func (dd *RunningDrawdownDurationOf[T]) String() string {
+	return "DrawdownDuration"
+}
+
+// MaxDrawdownDuration returns the longest drawdown of the equity curve in periods, see RunningDrawdownDuration
+func MaxDrawdownDuration[T tago.Float](equity []T) int {
+	return int(last[T](NewRunningDrawdownDurationOf[T](), equity))
+}
+
+/*
+RunningUlcerIndexOf returns the ulcer index of an equity curve so far, the quadratic mean of the drawdowns
+in percent, which accounts for both the depth and the duration of the drawdowns.
+
+# Formula
+
+UI = √(Σ (100 · (E<sub>t</sub> - max(E<sub>0</sub> ... E<sub>t</sub>)) / max(E<sub>0</sub> ... E<sub>t</sub>))<sup>2</sup> / N)
+
+# Example
+```
+ui := NewRunningUlcerIndex()
+ui.Next(10000.)
+```
+*/
+type RunningUlcerIndexOf[T tago.Float] struct {
+	// internal parameters for calculations
+	peak  T
+	sum   T
+	count int
+}
+
+// RunningUlcerIndex is a RunningUlcerIndexOf float64 values
+type RunningUlcerIndex = RunningUlcerIndexOf[float64]
+
+// NewRunningUlcerIndex creates a new RunningUlcerIndex
+func NewRunningUlcerIndex() *RunningUlcerIndex {
+	return NewRunningUlcerIndexOf[float64]()
+}
+
+// NewRunningUlcerIndexOf creates a new RunningUlcerIndexOf values of type T
+func NewRunningUlcerIndexOf[T tago.Float]() *RunningUlcerIndexOf[T] {
+	return &RunningUlcerIndexOf[T]{}
+}
+
+// Next takes the next equity and returns the next RunningUlcerIndex value
+func (ui *RunningUlcerIndexOf[T]) Next(equity T) T {
+	if ui.count == 0 || equity > ui.peak {
+		ui.peak = equity
+	}
+	ui.count++
+
+	percent := 100 * drawdown(ui.peak, equity)
+	ui.sum += percent * percent
+	return T(math.Sqrt(float64(ui.sum / T(ui.count))))
+}
+
+// Reset resets the indicators to a clean state
+func (ui *RunningUlcerIndexOf[T]) Reset() {
+	*ui = RunningUlcerIndexOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (ui *RunningUlcerIndexOf[T]) Clone() *RunningUlcerIndexOf[T] {
+	clone := *ui
+	return &clone
+}
+
+func (ui *RunningUlcerIndexOf[T]) String() string {
+	return "UlcerIndex"
+}
+
+// UlcerIndex returns the ulcer index of the equity curve, see RunningUlcerIndex
+func UlcerIndex[T tago.Float](equity []T) T {
+	return last[T](NewRunningUlcerIndexOf[T](), equity)
+}
+
+// drawdown returns the fall of equity from peak as a fraction of peak, 0 if peak is not positive
+func drawdown[T tago.Float](peak, equity T) T {
+	if peak <= 0 {
+		return 0
+	}
+	return (peak - equity) / peak
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+var approxComparer = cmpopts.EquateApprox(0.001, 1e-9)
+
+var equity = []float64{100., 110., 105., 120., 90., 95., 130., 125.}
+
+// series feeds every input through next and returns the results
+func series(next func(float64) float64, inputs []float64) []float64 {
+	out := make([]float64, len(inputs))
+	for i, input := range inputs {
+		out[i] = next(input)
+	}
+	return out
+}
+
+func TestRunningMaxDrawdownNext(t *testing.T) {
+	mdd := NewRunningMaxDrawdown()
+	want := []float64{0., 0., 0.0454545, 0.0454545, 0.25, 0.25, 0.25, 0.25}
+	got := series(mdd.Next, equity)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.InDelta(t, 5./130., mdd.Drawdown(), 1e-9, "must keep the current drawdown")
+	assert.Equal(t, 0.25, MaxDrawdown(equity), "must return the last running value")
+	assert.Equal(t, 0., MaxDrawdown([]float64{}))
+}
+
+func TestRunningDrawdownDurationNext(t *testing.T) {
+	dd := NewRunningDrawdownDuration()
+	want := []float64{0., 0., 1., 1., 1., 2., 2., 2.}
+	got := series(dd.Next, equity)
+	assert.Equal(t, want, got)
+	assert.Equal(t, 2, MaxDrawdownDuration(equity))
+	assert.Equal(t, 3, MaxDrawdownDuration([]float64{100., 90., 95., 99.}), "must count the drawdown in progress")
+}
+
+func TestRunningUlcerIndexNext(t *testing.T) {
+	ui := NewRunningUlcerIndex()
+	want := []float64{0., 0., 2.62432, 2.27273, 11.3636, 13.4145, 12.4194, 11.6966}
+	got := series(ui.Next, equity)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Equal(t, got[len(got)-1], UlcerIndex(equity))
+}
+
+func TestRunningDrawdownClone(t *testing.T) {
+	mdd := NewRunningMaxDrawdown()
+	dd := NewRunningDrawdownDuration()
+	ui := NewRunningUlcerIndex()
+	for _, e := range equity[:4] {
+		mdd.Next(e)
+		dd.Next(e)
+		ui.Next(e)
+	}
+
+	mddClone, ddClone, uiClone := mdd.Clone(), dd.Clone(), ui.Clone()
+	assert.Equal(t, series(mdd.Next, equity[4:]), series(mddClone.Next, equity[4:]))
+	assert.Equal(t, series(dd.Next, equity[4:]), series(ddClone.Next, equity[4:]))
+	assert.Equal(t, series(ui.Next, equity[4:]), series(uiClone.Next, equity[4:]))
+}
+
+func TestRunningDrawdownReset(t *testing.T) {
+	mdd := NewRunningMaxDrawdown()
+	dd := NewRunningDrawdownDuration()
+	ui := NewRunningUlcerIndex()
+	series(mdd.Next, equity)
+	series(dd.Next, equity)
+	series(ui.Next, equity)
+	mdd.Reset()
+	dd.Reset()
+	ui.Reset()
+
+	assert.Equal(t, NewRunningMaxDrawdown(), mdd, "must return to a clean state")
+	assert.Equal(t, NewRunningDrawdownDuration(), dd, "must return to a clean state")
+	assert.Equal(t, NewRunningUlcerIndex(), ui, "must return to a clean state")
+}
+
+func TestRunningDrawdownString(t *testing.T) {
+	assert.Equal(t, "MaxDrawdown", NewRunningMaxDrawdown().String())
+	assert.Equal(t, "DrawdownDuration", NewRunningDrawdownDuration().String())
+	assert.Equal(t, "UlcerIndex", NewRunningUlcerIndex().String())
+}
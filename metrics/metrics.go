@@ -0,0 +1,135 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+/*
+Package metrics evaluates the performance of a strategy from its equity curve, the returns of the equity
+curve or the profits and losses of its trades.
+
+Every metric is a batch function over a slice, such as MaxDrawdown or Sharpe, and most also come as a
+streaming indicator with the Next and Reset shape of the indicators of tago, such as RunningMaxDrawdown,
+to follow a strategy as it runs. The batch functions return the last value of their streaming indicator.
+Ratios return 0 when they are undefined, such as Sharpe of returns without deviation, and the annualised
+metrics return NaN for invalid inputs, such as a number of periods per year that is not greater than 0.
+
+# Example
+```
+equity := []float64{10000., 10250., 10100., 10400.}
+sharpe := metrics.Sharpe(metrics.Returns(equity, nil), 0., 252.)
+```
+*/
+package metrics
+
+import (
+	"math"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+var (
+	_ tago.Indicator = (*RunningMaxDrawdown)(nil)
+	_ tago.Indicator = (*RunningDrawdownDuration)(nil)
+	_ tago.Indicator = (*RunningUlcerIndex)(nil)
+	_ tago.Indicator = (*RunningSharpe)(nil)
+	_ tago.Indicator = (*RunningSortino)(nil)
+	_ tago.Indicator = (*RunningTradeStatistics)(nil)
+)
+
+// last feeds every input through indicator and returns the last value, 0 if there are no inputs
+func last[T tago.Float](indicator tago.IndicatorOf[T], inputs []T) T {
+	var value T
+	for _, input := range inputs {
+		value = indicator.Next(input)
+	}
+	return value
+}
+
+// Returns writes the simple returns of the equity curve to out, which is reused when it has enough
+// capacity, and returns it resliced to one less than the length of equity
+func Returns[T tago.Float](equity, out []T) []T {
+	n := len(equity) - 1
+	if n < 0 {
+		n = 0
+	}
+	if cap(out) < n {
+		out = make([]T, n)
+	}
+	out = out[:n]
+
+	for i := range out {
+		out[i] = equity[i+1]/equity[i] - 1
+	}
+	return out
+}
+
+// TotalReturn returns the return of the equity curve from its first to its last value
+func TotalReturn[T tago.Float](equity []T) T {
+	if len(equity) < 2 {
+		return 0
+	}
+	return equity[len(equity)-1]/equity[0] - 1
+}
+
+// AnnualizedReturn returns the compound annual growth rate of the equity curve, with periodsPerYear
+// periods between its values, such as 252 for daily values. It returns NaN if periodsPerYear is not
+// a finite number greater than 0, if the first value is not greater than 0 or if the curve goes below 0.
+func AnnualizedReturn[T tago.Float](equity []T, periodsPerYear T) T {
+	if !validPeriods(periodsPerYear) {
+		return T(math.NaN())
+	}
+	if len(equity) < 2 {
+		return 0
+	}
+	if equity[0] <= 0 {
+		return T(math.NaN())
+	}
+	for _, e := range equity {
+		if e < 0 {
+			return T(math.NaN())
+		}
+	}
+
+	growth := float64(equity[len(equity)-1] / equity[0])
+	years := float64(len(equity)-1) / float64(periodsPerYear)
+	return T(math.Pow(growth, 1/years) - 1)
+}
+
+// Volatility returns the annualised sample standard deviation of returns, with periodsPerYear
+// returns per year. It returns NaN if periodsPerYear is not a finite number greater than 0.
+func Volatility[T tago.Float](returns []T, periodsPerYear T) T {
+	if !validPeriods(periodsPerYear) {
+		return T(math.NaN())
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean, m2 T
+	for i, r := range returns {
+		delta := r - mean
+		mean += delta / T(i+1)
+		m2 += delta * (r - mean)
+	}
+	return T(math.Sqrt(float64(m2 / T(len(returns)-1) * periodsPerYear)))
+}
+
+// Calmar returns the ratio of the annualised return to the largest drawdown of the equity curve.
+// It returns NaN for the inputs AnnualizedReturn is NaN for.
+func Calmar[T tago.Float](equity []T, periodsPerYear T) T {
+	annual := AnnualizedReturn(equity, periodsPerYear)
+	if math.IsNaN(float64(annual)) {
+		return annual
+	}
+
+	mdd := MaxDrawdown(equity)
+	if mdd == 0 {
+		return 0
+	}
+	return annual / mdd
+}
+
+// validPeriods reports whether periodsPerYear is a finite number greater than 0
+func validPeriods[T tago.Float](periodsPerYear T) bool {
+	return periodsPerYear > 0 && !math.IsInf(float64(periodsPerYear), 1)
+}
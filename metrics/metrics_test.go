@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturns(t *testing.T) {
+	want := []float64{0.1, -0.0454545, 0.142857, -0.25, 0.0555556, 0.368421, -0.0384615}
+	out := make([]float64, len(equity))
+	got := Returns(equity, out)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+	assert.Empty(t, Returns([]float64{}, nil))
+}
+
+func TestReturnMetrics(t *testing.T) {
+	assert.InDelta(t, 0.25, TotalReturn(equity), 1e-9)
+	assert.InDelta(t, 0.465991, AnnualizedReturn(equity, 12.), 1e-6)
+	assert.InDelta(t, 3.03587, Volatility(Returns(equity, nil), 252.), 1e-5)
+	assert.InDelta(t, 1.86397, Calmar(equity, 12.), 1e-5)
+
+	assert.Equal(t, 0., TotalReturn([]float64{100.}))
+	assert.Equal(t, 0., AnnualizedReturn([]float64{100.}, 252.))
+	assert.Equal(t, 0., Volatility([]float64{0.01}, 252.))
+	assert.Equal(t, 0., Calmar([]float64{100., 110., 120.}, 252.), "must return 0 without drawdown")
+}
+
+func TestReturnMetricsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		equity         []float64
+		periodsPerYear float64
+	}{
+		"zero periods":      {equity: equity, periodsPerYear: 0.},
+		"negative periods":  {equity: equity, periodsPerYear: -12.},
+		"NaN periods":       {equity: equity, periodsPerYear: math.NaN()},
+		"infinite periods":  {equity: equity, periodsPerYear: math.Inf(1)},
+		"zero start":        {equity: []float64{0., 100., 50., 120.}, periodsPerYear: 12.},
+		"negative start":    {equity: []float64{-100., 100., 50., 120.}, periodsPerYear: 12.},
+		"crossing zero":     {equity: []float64{100., 50., -20., 120.}, periodsPerYear: 12.},
+		"negative end":      {equity: []float64{100., 50., 20., -10.}, periodsPerYear: 12.},
+		"short zero period": {equity: []float64{100.}, periodsPerYear: 0.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, math.IsNaN(AnnualizedReturn(tc.equity, tc.periodsPerYear)), "AnnualizedReturn must be NaN")
+			assert.True(t, math.IsNaN(Calmar(tc.equity, tc.periodsPerYear)), "Calmar must be NaN")
+		})
+	}
+
+	for _, periodsPerYear := range []float64{0., -252., math.NaN(), math.Inf(1)} {
+		assert.True(t, math.IsNaN(Volatility(Returns(equity, nil), periodsPerYear)), "Volatility must be NaN for %g periods", periodsPerYear)
+	}
+	assert.Equal(t, -1., AnnualizedReturn([]float64{100., 50., 0.}, 12.), "must return -1 for a curve wiped out")
+}
+
+func TestMetricsFloat32(t *testing.T) {
+	equity32 := make([]float32, len(equity))
+	for i, e := range equity {
+		equity32[i] = float32(e)
+	}
+	assert.InDelta(t, 0.25, float64(MaxDrawdown(equity32)), 1e-6)
+	assert.InDelta(t, 3.8648, float64(Sharpe(Returns(equity32, nil), 0.001, 252)), 1e-3)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+/*
+RunningSharpeOf returns the annualised Sharpe ratio of the returns so far, their mean excess return over
+the risk-free rate per unit of standard deviation.
+
+# Formula
+
+S = mean(R - R<sub>f</sub>) / σ(R - R<sub>f</sub>) · √P
+
+Where:
+
+* _R_ - returns of a period
+* _R<sub>f</sub>_ - risk-free return of a period
+* _σ_ - sample standard deviation
+* _P_ - number of periods per year.
+
+# Parameters
+
+* _riskFree_ - risk-free return of a period
+* _periodsPerYear_ - number of periods per year (greater than 0), such as 252 for daily returns
+
+# Example
+```
+sharpe, _ := NewRunningSharpe(0., 252.)
+sharpe.Next(0.01)
+```
+*/
+type RunningSharpeOf[T tago.Float] struct {
+	riskFree       T
+	periodsPerYear T
+
+	// internal parameters for calculations
+	count int
+	mean  T
+	m2    T
+}
+
+// RunningSharpe is a RunningSharpeOf float64 values
+type RunningSharpe = RunningSharpeOf[float64]
+
+// NewRunningSharpe creates a new RunningSharpe with the given risk-free return and periods per year
+// Example: NewRunningSharpe(0., 252.)
+func NewRunningSharpe(riskFree, periodsPerYear float64) (*RunningSharpe, error) {
+	return NewRunningSharpeOf(riskFree, periodsPerYear)
+}
+
+// NewRunningSharpeOf creates a new RunningSharpeOf values of type T with the given risk-free return and
+// periods per year
+// Example: NewRunningSharpeOf[float32](0., 252.)
+func NewRunningSharpeOf[T tago.Float](riskFree, periodsPerYear T) (*RunningSharpeOf[T], error) {
+	if !(periodsPerYear > 0) {
+		return nil, tago.ErrInvalidParameters
+	}
+
+	return &RunningSharpeOf[T]{
+		riskFree:       riskFree,
+		periodsPerYear: periodsPerYear,
+	}, nil
+}
+
+// Next takes the next return and returns the next RunningSharpe value
+func (s *RunningSharpeOf[T]) Next(input T) T {
+	s.count++
+	excess := input - s.riskFree
+	delta := excess - s.mean
+	s.mean += delta / T(s.count)
+	s.m2 += delta * (excess - s.mean)
+
+	if s.count < 2 || s.m2 <= 0 {
+		return 0
+	}
+	sd := math.Sqrt(float64(s.m2 / T(s.count-1)))
+	return T(float64(s.mean) / sd * math.Sqrt(float64(s.periodsPerYear)))
+}
+
+// Reset resets the indicators to a clean state
+func (s *RunningSharpeOf[T]) Reset() {
+	s.count = 0
+	s.mean = 0
+	s.m2 = 0
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (s *RunningSharpeOf[T]) Clone() *RunningSharpeOf[T] {
+	clone := *s
+	return &clone
+}
+
+func (s *RunningSharpeOf[T]) String() string {
+	return fmt.Sprintf("Sharpe(%g,%g)", float64(s.riskFree), float64(s.periodsPerYear))
+}
+
+// Sharpe returns the annualised Sharpe ratio of returns, see RunningSharpe
+func Sharpe[T tago.Float](returns []T, riskFree, periodsPerYear T) T {
+	s, err := NewRunningSharpeOf(riskFree, periodsPerYear)
+	if err != nil {
+		return 0
+	}
+	return last[T](s, returns)
+}
+
+/*
+RunningSortinoOf returns the annualised Sortino ratio of the returns so far, their mean excess return over
+a target per unit of downside deviation, which unlike the Sharpe ratio does not penalise gains.
+
+# Formula
+
+S = mean(R - T) / √(Σ min(0, R - T)<sup>2</sup> / N) · √P
+
+Where:
+
+* _R_ - returns of a period
+* _T_ - target return of a period
+* _N_ - number of returns
+* _P_ - number of periods per year.
+
+# Parameters
+
+* _target_ - target return of a period, commonly 0 or the risk-free return
+* _periodsPerYear_ - number of periods per year (greater than 0), such as 252 for daily returns
+
+# Example
+```
+sortino, _ := NewRunningSortino(0., 252.)
+sortino.Next(0.01)
+```
+*/
+type RunningSortinoOf[T tago.Float] struct {
+	target         T
+	periodsPerYear T
+
+	// internal parameters for calculations
+	count    int
+	sum      T
+	downside T
+}
+
+// RunningSortino is a RunningSortinoOf float64 values
+type RunningSortino = RunningSortinoOf[float64]
+
+// NewRunningSortino creates a new RunningSortino with the given target return and periods per year
+// Example: NewRunningSortino(0., 252.)
+func NewRunningSortino(target, periodsPerYear float64) (*RunningSortino, error) {
+	return NewRunningSortinoOf(target, periodsPerYear)
+}
+
+// NewRunningSortinoOf creates a new RunningSortinoOf values of type T with the given target return and
+// periods per year
+// Example: NewRunningSortinoOf[float32](0., 252.)
+func NewRunningSortinoOf[T tago.Float](target, periodsPerYear T) (*RunningSortinoOf[T], error) {
+	if !(periodsPerYear > 0) {
+		return nil, tago.ErrInvalidParameters
+	}
+
+	return &RunningSortinoOf[T]{
+		target:         target,
+		periodsPerYear: periodsPerYear,
+	}, nil
+}
+
+// Next takes the next return and returns the next RunningSortino value
+func (s *RunningSortinoOf[T]) Next(input T) T {
+	s.count++
+	excess := input - s.target
+	s.sum += excess
+	if excess < 0 {
+		s.downside += excess * excess
+	}
+
+	if s.downside <= 0 {
+		return 0
+	}
+	n := T(s.count)
+	deviation := math.Sqrt(float64(s.downside / n))
+	return T(float64(s.sum/n) / deviation * math.Sqrt(float64(s.periodsPerYear)))
+}
+
+// Reset resets the indicators to a clean state
+func (s *RunningSortinoOf[T]) Reset() {
+	s.count = 0
+	s.sum = 0
+	s.downside = 0
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (s *RunningSortinoOf[T]) Clone() *RunningSortinoOf[T] {
+	clone := *s
+	return &clone
+}
+
+func (s *RunningSortinoOf[T]) String() string {
+	return fmt.Sprintf("Sortino(%g,%g)", float64(s.target), float64(s.periodsPerYear))
+}
+
+// Sortino returns the annualised Sortino ratio of returns, see RunningSortino
+func Sortino[T tago.Float](returns []T, target, periodsPerYear T) T {
+	s, err := NewRunningSortinoOf(target, periodsPerYear)
+	if err != nil {
+		return 0
+	}
+	return last[T](s, returns)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/binhnguyenduc/tago"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRunningRatios(t *testing.T) {
+	tests := map[string]struct {
+		periodsPerYear float64
+		wantErr        error
+	}{
+		"zero periods per year":     {periodsPerYear: 0., wantErr: tago.ErrInvalidParameters},
+		"negative periods per year": {periodsPerYear: -252., wantErr: tago.ErrInvalidParameters},
+		"positive periods per year": {periodsPerYear: 252., wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sharpe, sharpeErr := NewRunningSharpe(0., tc.periodsPerYear)
+			sortino, sortinoErr := NewRunningSortino(0., tc.periodsPerYear)
+			if tc.wantErr != nil {
+				assert.EqualError(t, sharpeErr, tc.wantErr.Error(), "must return the correct error")
+				assert.EqualError(t, sortinoErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, sharpe)
+				assert.Nil(t, sortino)
+				return
+			}
+			assert.NoError(t, sharpeErr)
+			assert.NoError(t, sortinoErr)
+		})
+	}
+}
+
+func TestRunningSharpeNext(t *testing.T) {
+	s, _ := NewRunningSharpe(0.001, 252.)
+	want := []float64{0., 4.05502, 10.4219, -1.26701, -0.0414038, 4.70798, 3.8648}
+	returns := Returns(equity, nil)
+	got := series(s.Next, returns)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Equal(t, got[len(got)-1], Sharpe(returns, 0.001, 252.))
+	assert.Equal(t, 0., Sharpe([]float64{0.01, 0.01, 0.01}, 0., 252.), "must return 0 without deviation")
+}
+
+func TestRunningSortinoNext(t *testing.T) {
+	s, _ := NewRunningSortino(0., 252.)
+	want := []float64{0., 13.47, 39.8029, -1.64298, 0.0826482, 9.47196, 7.77261}
+	returns := Returns(equity, nil)
+	got := series(s.Next, returns)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+	assert.Equal(t, got[len(got)-1], Sortino(returns, 0., 252.))
+}
+
+func TestRunningRatiosClone(t *testing.T) {
+	returns := Returns(equity, nil)
+	sharpe, _ := NewRunningSharpe(0., 252.)
+	sortino, _ := NewRunningSortino(0., 252.)
+	series(sharpe.Next, returns[:3])
+	series(sortino.Next, returns[:3])
+
+	sharpeClone, sortinoClone := sharpe.Clone(), sortino.Clone()
+	assert.Equal(t, series(sharpe.Next, returns[3:]), series(sharpeClone.Next, returns[3:]))
+	assert.Equal(t, series(sortino.Next, returns[3:]), series(sortinoClone.Next, returns[3:]))
+}
+
+func TestRunningRatiosReset(t *testing.T) {
+	returns := Returns(equity, nil)
+	sharpe, _ := NewRunningSharpe(0., 252.)
+	sortino, _ := NewRunningSortino(0., 252.)
+	series(sharpe.Next, returns)
+	series(sortino.Next, returns)
+	sharpe.Reset()
+	sortino.Reset()
+
+	wantSharpe, _ := NewRunningSharpe(0., 252.)
+	wantSortino, _ := NewRunningSortino(0., 252.)
+	assert.Equal(t, wantSharpe, sharpe, "must return to a clean state")
+	assert.Equal(t, wantSortino, sortino, "must return to a clean state")
+}
+
+func TestRunningRatiosString(t *testing.T) {
+	sharpe, _ := NewRunningSharpe(0.0001, 252.)
+	sortino, _ := NewRunningSortino(0., 12.)
+	assert.Equal(t, "Sharpe(0.0001,252)", sharpe.String())
+	assert.Equal(t, "Sortino(0,12)", sortino.String())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"math"
+
+	"github.com/binhnguyenduc/tago"
+)
+
+/*
+RunningTradeStatisticsOf returns the expectancy of the trades so far, the average profit or loss of a
+trade, and keeps their win rate and profit factor.
+
+# Formula
+
+Expectancy = Σ PnL / N
+
+Win rate = N<sub>PnL > 0</sub> / N
+
+Profit factor = Σ PnL<sub>PnL > 0</sub> / -Σ PnL<sub>PnL < 0</sub>
+
+# Example
+```
+stats := NewRunningTradeStatistics()
+stats.Next(trade.PnL)
+```
+*/
+type RunningTradeStatisticsOf[T tago.Float] struct {
+	// internal parameters for calculations
+	count  int
+	wins   int
+	profit T
+	loss   T
+}
+
+// RunningTradeStatistics is a RunningTradeStatisticsOf float64 values
+type RunningTradeStatistics = RunningTradeStatisticsOf[float64]
+
+// NewRunningTradeStatistics creates a new RunningTradeStatistics
+func NewRunningTradeStatistics() *RunningTradeStatistics {
+	return NewRunningTradeStatisticsOf[float64]()
+}
+
+// NewRunningTradeStatisticsOf creates a new RunningTradeStatisticsOf values of type T
+func NewRunningTradeStatisticsOf[T tago.Float]() *RunningTradeStatisticsOf[T] {
+	return &RunningTradeStatisticsOf[T]{}
+}
+
+// Next takes the profit or loss of the next trade and returns the next expectancy
+func (ts *RunningTradeStatisticsOf[T]) Next(pnl T) T {
+	ts.count++
+	switch {
+	case pnl > 0:
+		ts.wins++
+		ts.profit += pnl
+	case pnl < 0:
+		ts.loss -= pnl
+	}
+	return ts.Expectancy()
+}
+
+// Expectancy returns the average profit or loss of a trade
+func (ts *RunningTradeStatisticsOf[T]) Expectancy() T {
+	if ts.count == 0 {
+		return 0
+	}
+	return (ts.profit - ts.loss) / T(ts.count)
+}
+
+// WinRate returns the fraction of the trades with a profit
+func (ts *RunningTradeStatisticsOf[T]) WinRate() T {
+	if ts.count == 0 {
+		return 0
+	}
+	return T(ts.wins) / T(ts.count)
+}
+
+// ProfitFactor returns the ratio of the gross profit to the gross loss, +Inf if there are profits but no losses
+func (ts *RunningTradeStatisticsOf[T]) ProfitFactor() T {
+	if ts.loss == 0 {
+		if ts.profit > 0 {
+			return T(math.Inf(1))
+		}
+		return 0
+	}
+	return ts.profit / ts.loss
+}
+
+// Reset resets the indicators to a clean state
+func (ts *RunningTradeStatisticsOf[T]) Reset() {
+	*ts = RunningTradeStatisticsOf[T]{}
+}
+
+// Clone returns a copy of the indicator that can be advanced independently
+func (ts *RunningTradeStatisticsOf[T]) Clone() *RunningTradeStatisticsOf[T] {
+	clone := *ts
+	return &clone
+}
+
+func (ts *RunningTradeStatisticsOf[T]) String() string {
+	return "TradeStatistics"
+}
+
+// tradeStatistics returns the statistics of the trades with the profits and losses pnls
+func tradeStatistics[T tago.Float](pnls []T) *RunningTradeStatisticsOf[T] {
+	ts := NewRunningTradeStatisticsOf[T]()
+	last[T](ts, pnls)
+	return ts
+}
+
+// WinRate returns the fraction of the trades with a profit, see RunningTradeStatistics
+func WinRate[T tago.Float](pnls []T) T {
+	return tradeStatistics(pnls).WinRate()
+}
+
+// ProfitFactor returns the ratio of the gross profit to the gross loss of the trades, see RunningTradeStatistics
+func ProfitFactor[T tago.Float](pnls []T) T {
+	return tradeStatistics(pnls).ProfitFactor()
+}
+
+// Expectancy returns the average profit or loss of the trades, see RunningTradeStatistics
+func Expectancy[T tago.Float](pnls []T) T {
+	return tradeStatistics(pnls).Expectancy()
+}
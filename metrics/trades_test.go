@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pnls = []float64{50., -20., 30., -10., 0., 40.}
+
+func TestRunningTradeStatisticsNext(t *testing.T) {
+	ts := NewRunningTradeStatistics()
+	want := []float64{50., 15., 20., 12.5, 10., 15.}
+	assert.Equal(t, want, series(ts.Next, pnls))
+	assert.Equal(t, 0.5, ts.WinRate())
+	assert.Equal(t, 4., ts.ProfitFactor())
+}
+
+func TestTradeStatistics(t *testing.T) {
+	tests := map[string]struct {
+		pnls             []float64
+		wantWinRate      float64
+		wantProfitFactor float64
+		wantExpectancy   float64
+	}{
+		"trades":      {pnls: pnls, wantWinRate: 0.5, wantProfitFactor: 4., wantExpectancy: 15.},
+		"no trades":   {pnls: nil, wantWinRate: 0., wantProfitFactor: 0., wantExpectancy: 0.},
+		"no losses":   {pnls: []float64{10., 20.}, wantWinRate: 1., wantProfitFactor: math.Inf(1), wantExpectancy: 15.},
+		"only losses": {pnls: []float64{-10., -20.}, wantWinRate: 0., wantProfitFactor: 0., wantExpectancy: -15.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wantWinRate, WinRate(tc.pnls))
+			assert.Equal(t, tc.wantProfitFactor, ProfitFactor(tc.pnls))
+			assert.Equal(t, tc.wantExpectancy, Expectancy(tc.pnls))
+		})
+	}
+}
+
+func TestRunningTradeStatisticsClone(t *testing.T) {
+	ts := NewRunningTradeStatistics()
+	series(ts.Next, pnls[:3])
+	clone := ts.Clone()
+	assert.Equal(t, series(ts.Next, pnls[3:]), series(clone.Next, pnls[3:]))
+}
+
+func TestRunningTradeStatisticsReset(t *testing.T) {
+	ts := NewRunningTradeStatistics()
+	series(ts.Next, pnls)
+	ts.Reset()
+	assert.Equal(t, NewRunningTradeStatistics(), ts, "must return to a clean state")
+}
+
+func TestRunningTradeStatisticsString(t *testing.T) {
+	assert.Equal(t, "TradeStatistics", NewRunningTradeStatistics().String())
+}
@@ -11,7 +11,7 @@ import (
 )
 
 /*
-Minimum returns the lowest value in a given time frame
+MinimumOf returns the lowest value in a given time frame
 
 # Parameters
 
@@ -21,43 +21,93 @@ Minimum returns the lowest value in a given time frame
 ```
 ```
 */
-type Minimum struct {
+type MinimumOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
 	// internal parameters for calculations
 	minIndex int
 	curIndex int
+	count    int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// Minimum is a MinimumOf float64 values
+type Minimum = MinimumOf[float64]
+
 // NewMinimum creates a new Minimum with the given number of periods
 // Example: NewMinimum(9)
 func NewMinimum(n int) (*Minimum, error) {
+	return NewMinimumOf[float64](n)
+}
+
+// NewMinimumOf creates a new MinimumOf values of type T with the given number of periods
+// Example: NewMinimumOf[float32](9)
+func NewMinimumOf[T Float](n int) (*MinimumOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	data := make([]float64, n)
+	data := make([]T, n)
 	for i := range data {
-		data[i] = math.Inf(1)
+		data[i] = T(math.Inf(1))
 	}
-	return &Minimum{
+	return &MinimumOf[T]{
 		n: n,
 
 		minIndex: 0,
 		curIndex: 0,
+		count:    0,
 
 		data: data,
 	}, nil
 }
 
 // Next takes the next input and returns the next Minimum value
-func (m *Minimum) Next(input float64) float64 {
+func (m *MinimumOf[T]) Next(input T) T {
 	// add input to data
 	m.curIndex = (m.curIndex + 1) % m.n
+	if m.count < m.n {
+		m.count++
+	}
+
+	return m.set(input)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *MinimumOf[T]) Peek(input T) T {
+	index := (m.curIndex + 1) % m.n
+	if input < m.data[m.minIndex] {
+		return input
+	}
+	if index != m.minIndex {
+		return m.data[m.minIndex]
+	}
+
+	// the current minimum would leave the window
+	min := input
+	for i, v := range m.data {
+		if i != index && v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Minimum value.
+// If no input has been committed yet it behaves like Next.
+func (m *MinimumOf[T]) UpdateLast(input T) T {
+	if m.count == 0 {
+		return m.Next(input)
+	}
+
+	return m.set(input)
+}
+
+// set stores input at the current index and updates the index of the minimum
+func (m *MinimumOf[T]) set(input T) T {
 	m.data[m.curIndex] = input
 
 	if input < m.data[m.minIndex] {
@@ -69,8 +119,8 @@ func (m *Minimum) Next(input float64) float64 {
 	return m.data[m.minIndex]
 }
 
-func findMinIndex(data []float64) int {
-	min := math.Inf(1)
+func findMinIndex[T Float](data []T) int {
+	min := T(math.Inf(1))
 	index := 0
 
 	for i, v := range data {
@@ -83,17 +133,50 @@ func findMinIndex(data []float64) int {
 	return index
 }
 
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *MinimumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = m.Next(input)
+	}
+	return out
+}
+
 // Reset resets the indicators to a clean state
-func (m *Minimum) Reset() {
-	data := make([]float64, m.n)
+func (m *MinimumOf[T]) Reset() {
+	data := make([]T, m.n)
 	for i := range data {
-		data[i] = math.Inf(-1)
+		data[i] = T(math.Inf(1))
 	}
 	m.data = data
 	m.curIndex = 0
 	m.minIndex = 0
+	m.count = 0
 }
 
-func (m *Minimum) String() string {
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *MinimumOf[T]) Clone() *MinimumOf[T] {
+	clone := *m
+	clone.data = cloneSlice(m.data)
+	return &clone
+}
+
+func (m *MinimumOf[T]) String() string {
 	return fmt.Sprintf("Min(%d)", m.n)
 }
+
+// ComputeMinimum computes a Minimum with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMinimum[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	m, err := NewMinimumOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = m.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
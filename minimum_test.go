@@ -94,6 +94,191 @@ func TestMinimumReset(t *testing.T) {
 	}
 }
 
+func TestMinimumNextAfterReset(t *testing.T) {
+	sd, _ := NewMinimum(3)
+	sd.Next(5.)
+	sd.Next(7.)
+	sd.Reset()
+
+	// floatComparer equates infinities with any value, so compare exactly
+	assert.Equal(t, 8., sd.Next(8.), "must not see the values refilled by Reset")
+	assert.Equal(t, 8., sd.Next(9.), "must not see the values refilled by Reset")
+	assert.Equal(t, 6., sd.Next(6.), "must not see the values refilled by Reset")
+}
+
+func TestMinimumPeek(t *testing.T) {
+	sd, _ := NewMinimum(3)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 4., want: 4.},
+		{input: 1.2, want: 1.2},
+		{input: 5., want: 1.2},
+		{input: 3., want: 1.2},
+		{input: 4., want: 3.},
+		{input: 0., want: 0.},
+		{input: -1., want: -1.},
+		{input: 2., want: -1.},
+		{input: 3., want: -1.},
+		{input: 4., want: 2.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMinimumUpdateLast(t *testing.T) {
+	sd, _ := NewMinimum(3)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 4., update: 1., want: 1.},
+		{input: 5., update: 7., want: 1.},
+		{input: 0., update: 8., want: 1.},
+		{input: 9., update: 6., want: 6.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMinimumUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewMinimum(3)
+	diff := cmp.Diff(2., sd.UpdateLast(2.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(2., sd.Next(3.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestMinimumCompute(t *testing.T) {
+	inputs := []float64{4., 1.2, 5., 3., 4., 0., -1., 2., 3., 4.}
+
+	stream, _ := NewMinimum(3)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewMinimum(3)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeMinimum(t *testing.T) {
+	inputs := []float64{4., 1.2, 5., 3.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 3, fillNaN: false, want: []float64{4., 1.2, 1.2, 1.2}},
+		"fill NaN": {n: 3, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 1.2, 1.2}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeMinimum(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMinimumClone(t *testing.T) {
+	sd, _ := NewMinimum(3)
+	sd.Next(4.)
+	sd.Next(1.2)
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone, "clone must start from the same state")
+
+	tests := []struct {
+		indicator *Minimum
+		input     float64
+		want      float64
+	}{
+		{indicator: clone, input: -5., want: -5.},
+		{indicator: sd, input: 3., want: 1.2},
+		{indicator: clone, input: 2., want: -5.},
+		{indicator: sd, input: 6., want: 1.2},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tc.indicator.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMinimumOfFloat32(t *testing.T) {
+	sd, _ := NewMinimumOf[float32](3)
+	tests := []struct {
+		input float32
+		want  float64
+	}{
+		{input: 4., want: 4.},
+		{input: 1.2, want: 1.2},
+		{input: 5., want: 1.2},
+		{input: 3., want: 1.2},
+		{input: 4., want: 3.},
+		{input: 0., want: 0.},
+		{input: -1., want: -1.},
+		{input: 2., want: -1.},
+		{input: 3., want: -1.},
+		{input: 4., want: 2.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, float64(got), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestMinimumString(t *testing.T) {
 	sd, _ := NewMinimum(4)
 	want := "Min(4)"
@@ -103,3 +288,24 @@ func TestMinimumString(t *testing.T) {
 		t.Fatalf(diff)
 	}
 }
+
+func BenchmarkMinimumNext(b *testing.B) {
+	inputs := benchmarkInputs()
+	sd, _ := NewMinimum(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			sd.Next(input)
+		}
+	}
+}
+
+func BenchmarkMinimumCompute(b *testing.B) {
+	inputs := benchmarkInputs()
+	out := make([]float64, len(inputs))
+	sd, _ := NewMinimum(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = sd.Compute(inputs, out)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "math"
+
+// centralMoments keeps the count, mean and the sums of the 2nd, 3rd and 4th powers of
+// deviations from the mean, updated online as values enter and leave a window
+type centralMoments[T Float] struct {
+	count int
+
+	mean T
+	m2   T
+	m3   T
+	m4   T
+}
+
+// add includes x in the moments
+func (cm *centralMoments[T]) add(x T) {
+	n1 := T(cm.count)
+	cm.count++
+	n := T(cm.count)
+
+	delta := x - cm.mean
+	dn := delta / n
+	dn2 := dn * dn
+	term1 := delta * dn * n1
+
+	cm.mean += dn
+	cm.m4 += term1*dn2*(n*n-3*n+3) + 6*dn2*cm.m2 - 4*dn*cm.m3
+	cm.m3 += term1*dn*(n-2) - 3*dn*cm.m2
+	cm.m2 += term1
+}
+
+// remove excludes x, which must have been added before, by inverting add
+func (cm *centralMoments[T]) remove(x T) {
+	if cm.count <= 1 {
+		*cm = centralMoments[T]{}
+		return
+	}
+
+	n := T(cm.count)
+	cm.count--
+	n1 := T(cm.count)
+
+	mean := (n*cm.mean - x) / n1
+	delta := x - mean
+	dn := delta / n
+	dn2 := dn * dn
+	term1 := delta * dn * n1
+
+	cm.mean = mean
+	cm.m2 -= term1
+	cm.m3 -= term1*dn*(n-2) - 3*dn*cm.m2
+	cm.m4 -= term1*dn2*(n*n-3*n+3) + 6*dn2*cm.m2 - 4*dn*cm.m3
+}
+
+// skewness returns the population skewness, or 0 if all values are equal
+func (cm *centralMoments[T]) skewness() T {
+	if cm.m2 <= 0 {
+		return 0
+	}
+	return T(math.Sqrt(float64(cm.count)) * float64(cm.m3) / math.Pow(float64(cm.m2), 1.5))
+}
+
+// kurtosis returns the population excess kurtosis, or 0 if all values are equal
+func (cm *centralMoments[T]) kurtosis() T {
+	if cm.m2 <= 0 {
+		return 0
+	}
+	return T(cm.count)*cm.m4/(cm.m2*cm.m2) - 3
+}
+
+// momentWindow keeps the central moments of the last n values. The moments are recomputed from the window once
+// every n updates, so that the rounding errors of their online updates do not accumulate over long streams, and
+// a window of equal values is recognised exactly, rather than from moments left with rounding errors.
+type momentWindow[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index   int
+	moments centralMoments[T]
+	run     equalRun[T]
+	// number of updates since moments were last recomputed from data
+	updates int
+
+	// slice of data needed for calculation
+	data []T
+}
+
+func newMomentWindow[T Float](n int) momentWindow[T] {
+	return momentWindow[T]{
+		n: n,
+
+		index: 0,
+
+		data: make([]T, n),
+	}
+}
+
+// push adds input to the window, dropping the oldest value once the window is full
+func (w *momentWindow[T]) push(input T) {
+	w.index = (w.index + 1) % w.n
+	if w.moments.count == w.n {
+		w.moments.remove(w.data[w.index])
+	}
+	w.data[w.index] = input
+	w.moments.add(input)
+	w.run.push(input)
+	w.resync()
+}
+
+// peek returns the moments push would produce for input without changing the window
+func (w *momentWindow[T]) peek(input T) centralMoments[T] {
+	moments := w.moments
+	if moments.count == w.n {
+		moments.remove(w.data[(w.index+1)%w.n])
+	}
+	moments.add(input)
+
+	if w.run.peek(input) >= moments.count {
+		return constantMoments(moments.count, input)
+	}
+	if w.updates+1 >= w.n {
+		return windowCentralMoments(w.data, (w.index+1)%w.n, moments.count, input)
+	}
+	return moments
+}
+
+// replaceLast replaces the most recent value with input, pushing it if the window is empty
+func (w *momentWindow[T]) replaceLast(input T) {
+	if w.moments.count == 0 {
+		w.push(input)
+		return
+	}
+	w.moments.remove(w.data[w.index])
+	w.data[w.index] = input
+	w.moments.add(input)
+	w.run.replaceLast(input)
+	w.resync()
+}
+
+// resync recomputes the moments from data once every n updates, and sets them exactly for a window of equal values
+func (w *momentWindow[T]) resync() {
+	w.updates++
+	switch {
+	case w.run.length >= w.moments.count:
+		w.moments = constantMoments(w.moments.count, w.data[w.index])
+	case w.updates >= w.n:
+		w.moments = windowCentralMoments(w.data, w.index, w.moments.count, w.data[w.index])
+		w.updates = 0
+	}
+}
+
+func (w *momentWindow[T]) reset() {
+	w.index = 0
+	w.moments = centralMoments[T]{}
+	w.run = equalRun[T]{}
+	w.updates = 0
+	w.data = make([]T, w.n)
+}
+
+func (w momentWindow[T]) clone() momentWindow[T] {
+	w.data = cloneSlice(w.data)
+	return w
+}
+
+// constantMoments returns the central moments of count values equal to x
+func constantMoments[T Float](count int, x T) centralMoments[T] {
+	return centralMoments[T]{count: count, mean: x}
+}
+
+// windowCentralMoments returns the central moments of the count most recent values of the ring buffer data,
+// the last at index, taking last as the value at index. They are computed in float64 with two passes,
+// from deviations from last so that values close to each other keep their precision.
+func windowCentralMoments[T Float](data []T, index, count int, last T) centralMoments[T] {
+	return centralMomentsOf(count, func(k int) T { return windowValue(data, index, k, last) })
+}
+
+// centralMomentsOf returns the moments of count values, value(k) returning the k-th of them, computed in
+// float64 with two passes around the 0-th value
+func centralMomentsOf[T Float](count int, value func(k int) T) centralMoments[T] {
+	if count == 0 {
+		return centralMoments[T]{}
+	}
+
+	shift := float64(value(0))
+	sum := 0.
+	for k := 0; k < count; k++ {
+		sum += float64(value(k)) - shift
+	}
+	mean := sum / float64(count)
+
+	var m2, m3, m4 float64
+	for k := 0; k < count; k++ {
+		d := float64(value(k)) - shift - mean
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	return centralMoments[T]{count: count, mean: T(shift + mean), m2: T(m2), m3: T(m3), m4: T(m4)}
+}
+
+// equalRun counts the most recent inputs equal to the last one, so that a window of equal values
+// can be recognised exactly
+type equalRun[T Float] struct {
+	// the last input and the one before it
+	last, before T
+	// number of the most recent inputs equal to last, and the same number before the last input
+	length, previous int
+}
+
+// push adds input to the run
+func (r *equalRun[T]) push(input T) {
+	r.before, r.previous = r.last, r.length
+	r.length = r.peek(input)
+	r.last = input
+}
+
+// peek returns the length push would produce for input
+func (r *equalRun[T]) peek(input T) int {
+	if r.length > 0 && input == r.last {
+		return r.length + 1
+	}
+	return 1
+}
+
+// replaceLast replaces the last input with input, pushing it if there is none
+func (r *equalRun[T]) replaceLast(input T) {
+	if r.length == 0 {
+		r.push(input)
+		return
+	}
+
+	r.length = 1
+	if r.previous > 0 && input == r.before {
+		r.length = r.previous + 1
+	}
+	r.last = input
+}
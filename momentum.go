@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+MomentumOf returns the ratio of the input to the input _n_ periods before, scaled to 100:
+above 100 when the input rose over the period and below 100 when it fell.
+
+# Formula
+
+MOM<sub>t</sub> = 100 · p<sub>t</sub> / p<sub>t-n</sub>
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the first input stands in for p<sub>t-n</sub>.
+See Difference for the momentum expressed as a change.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+mom, _ := NewMomentum(1)
+mom.Next(10.)
+```
+*/
+type MomentumOf[T Float] struct {
+	lookback[T]
+}
+
+// Momentum is a MomentumOf float64 values
+type Momentum = MomentumOf[float64]
+
+// NewMomentum creates a new Momentum with the given number of periods
+// Example: NewMomentum(1)
+func NewMomentum(n int) (*Momentum, error) {
+	return NewMomentumOf[float64](n)
+}
+
+// NewMomentumOf creates a new MomentumOf values of type T with the given number of periods
+// Example: NewMomentumOf[float32](1)
+func NewMomentumOf[T Float](n int) (*MomentumOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &MomentumOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next Momentum value
+func (mom *MomentumOf[T]) Next(input T) T {
+	past := mom.push(input)
+	return 100 * input / past
+}
+
+// Peek returns the value Next would return for input without committing it
+func (mom *MomentumOf[T]) Peek(input T) T {
+	past := mom.peek(input)
+	return 100 * input / past
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Momentum value.
+// If no input has been committed yet it behaves like Next.
+func (mom *MomentumOf[T]) UpdateLast(input T) T {
+	past := mom.replaceLast(input)
+	return 100 * input / past
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (mom *MomentumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = mom.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (mom *MomentumOf[T]) Reset() {
+	mom.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (mom *MomentumOf[T]) Clone() *MomentumOf[T] {
+	return &MomentumOf[T]{
+		lookback: mom.clone(),
+	}
+}
+
+func (mom *MomentumOf[T]) String() string {
+	return fmt.Sprintf("MOM(%d)", mom.n)
+}
+
+// ComputeMomentum computes a Momentum with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputeMomentum[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	mom, err := NewMomentumOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = mom.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
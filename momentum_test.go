@@ -0,0 +1,123 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMomentum(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *Momentum
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &Momentum{lookback: newLookback[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewMomentum(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestMomentumNext(t *testing.T) {
+	sd, _ := NewMomentum(2)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 100.},
+		{input: 8., want: 400.},
+		{input: 1., want: 50.},
+		{input: 4., want: 50.},
+		{input: 1., want: 100.},
+		{input: 9., want: 225.},
+		{input: 9., want: 900.},
+		{input: 5., want: 55.5556},
+		{input: 3., want: 33.3333},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeMomentum(t *testing.T) {
+	inputs := []float64{2., 8., 1., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 2, fillNaN: false, want: []float64{100., 400., 50., 50., 100.}},
+		"fill NaN": {n: 2, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 50., 50., 100.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeMomentum(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMomentumLookback(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"warm-up":        {n: 3, inputs: []float64{5., 6., 10.}, want: 200.},
+		"oldest evicted": {n: 3, inputs: []float64{5., 6., 7., 8., 3.}, want: 50.},
+		"unchanged":      {n: 1, inputs: []float64{5., 5.}, want: 100.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewMomentum(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMomentumString(t *testing.T) {
+	sd, _ := NewMomentum(2)
+	want := "MOM(2)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -8,7 +8,7 @@ package tago
 import "fmt"
 
 /*
-MovingAverage returns the average in _n_ number of periods
+MovingAverageOf returns the average in _n_ number of periods
 
 # Formula
 
@@ -28,7 +28,7 @@ Where:
 ```
 ```
 */
-type MovingAverage struct {
+type MovingAverageOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
@@ -36,20 +36,31 @@ type MovingAverage struct {
 	index int
 	count int
 
-	sum float64
+	sum T
+	// number of updates since sum was last recomputed from data
+	updates int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// MovingAverage is a MovingAverageOf float64 values
+type MovingAverage = MovingAverageOf[float64]
+
 // NewMovingAverage creates a new MovingAverage with the given number of periods
 // Example: NewMovingAverage(9)
 func NewMovingAverage(n int) (*MovingAverage, error) {
+	return NewMovingAverageOf[float64](n)
+}
+
+// NewMovingAverageOf creates a new MovingAverageOf values of type T with the given number of periods
+// Example: NewMovingAverageOf[float32](9)
+func NewMovingAverageOf[T Float](n int) (*MovingAverageOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	return &MovingAverage{
+	return &MovingAverageOf[T]{
 		n: n,
 
 		index: 0,
@@ -57,12 +68,12 @@ func NewMovingAverage(n int) (*MovingAverage, error) {
 
 		sum: 0,
 
-		data: make([]float64, n),
+		data: make([]T, n),
 	}, nil
 }
 
 // Next takes the next input and returns the next MovingAverage value
-func (ma *MovingAverage) Next(input float64) float64 {
+func (ma *MovingAverageOf[T]) Next(input T) T {
 	// add input to data
 	ma.index = (ma.index + 1) % ma.n
 	oldValue := ma.data[ma.index]
@@ -73,19 +84,107 @@ func (ma *MovingAverage) Next(input float64) float64 {
 	}
 
 	ma.sum += input - oldValue
-	return ma.sum / float64(ma.count)
+	ma.resync()
+	return ma.sum / T(ma.count)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (ma *MovingAverageOf[T]) Peek(input T) T {
+	index := (ma.index + 1) % ma.n
+	count := ma.count
+	if count < ma.n {
+		count++
+	}
+
+	sum := ma.sum + (input - ma.data[index])
+	if ma.updates+1 >= ma.n {
+		sum = windowSum(ma.data, index, count, input)
+	}
+	return sum / T(count)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated MovingAverage value.
+// If no input has been committed yet it behaves like Next.
+func (ma *MovingAverageOf[T]) UpdateLast(input T) T {
+	if ma.count == 0 {
+		return ma.Next(input)
+	}
+
+	ma.sum += input - ma.data[ma.index]
+	ma.data[ma.index] = input
+	ma.resync()
+	return ma.sum / T(ma.count)
+}
+
+// resync recomputes sum from data once every n updates, so that its rounding errors do not accumulate
+// over long streams
+func (ma *MovingAverageOf[T]) resync() {
+	ma.updates++
+	if ma.updates >= ma.n {
+		ma.sum = windowSum(ma.data, ma.index, ma.count, ma.data[ma.index])
+		ma.updates = 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (ma *MovingAverageOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+
+	n, index, count, sum, updates, data := ma.n, ma.index, ma.count, ma.sum, ma.updates, ma.data
+	for i, input := range inputs {
+		index = (index + 1) % n
+		oldValue := data[index]
+		data[index] = input
+
+		if count < n {
+			count++
+		}
+
+		sum += input - oldValue
+		if updates++; updates >= n {
+			sum, updates = windowSum(data, index, count, data[index]), 0
+		}
+		out[i] = sum / T(count)
+	}
+
+	ma.index, ma.count, ma.sum, ma.updates = index, count, sum, updates
+	return out
 }
 
 // Reset resets the indicators to a clean state
-func (ma *MovingAverage) Reset() {
+func (ma *MovingAverageOf[T]) Reset() {
 	ma.index = 0
 	ma.count = 0
 
 	ma.sum = 0
+	ma.updates = 0
 
-	ma.data = make([]float64, ma.n)
+	ma.data = make([]T, ma.n)
 }
 
-func (ma *MovingAverage) String() string {
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (ma *MovingAverageOf[T]) Clone() *MovingAverageOf[T] {
+	clone := *ma
+	clone.data = cloneSlice(ma.data)
+	return &clone
+}
+
+func (ma *MovingAverageOf[T]) String() string {
 	return fmt.Sprintf("MA(%d)", ma.n)
 }
+
+// ComputeMovingAverage computes a MovingAverage with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeMovingAverage[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	ma, err := NewMovingAverageOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = ma.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
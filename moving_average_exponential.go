@@ -5,10 +5,8 @@ Licensed under terms of MIT license (see LICENSE)
 
 package tago
 
-import "fmt"
-
 /*
-ExponentialMovingAverage returns the exponential average in _n_ number of periods
+ExponentialMovingAverageOf returns the exponential average in _n_ number of periods
 
 # Formula
 
@@ -28,54 +26,135 @@ _α_ is calculated with the following formula:
 # Parameters
 
 * _n_ - number of periods (integer greater than 0)
+* _options_ - optional ExponentialOption values: WithAlpha replaces α, WithSeeding averages the first
+n inputs instead of starting from the first one
 
 # Example
 ```
+ema, _ := NewExponentialMovingAverage(9, WithSeeding(SeedMean))
+ema.Next(10.)
 ```
 */
-type ExponentialMovingAverage struct {
+type ExponentialMovingAverageOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
-	n int
+	n      int
+	config exponentialConfig
 
 	// internal parameters for calculation
-	k       float64
-	current float64
-	isNew   bool
+	smoothing exponentialSmoothing[T]
+	current   T
+
+	// value before the most recent input, used to replace it
+	previous T
 }
 
-// NewExponentialMovingAverage creates a new ExponentialMovingAverage with the given number of periods
+// ExponentialMovingAverage is an ExponentialMovingAverageOf float64 values
+type ExponentialMovingAverage = ExponentialMovingAverageOf[float64]
+
+// NewExponentialMovingAverage creates a new ExponentialMovingAverage with the given number of periods and options
 // Example: NewExponentialMovingAverage(9)
-func NewExponentialMovingAverage(n int) (*ExponentialMovingAverage, error) {
-	if n <= 0 {
+func NewExponentialMovingAverage(n int, options ...ExponentialOption) (*ExponentialMovingAverage, error) {
+	return NewExponentialMovingAverageOf[float64](n, options...)
+}
+
+// NewExponentialMovingAverageOf creates a new ExponentialMovingAverageOf values of type T with the given number of periods and options
+// Example: NewExponentialMovingAverageOf[float32](9)
+func NewExponentialMovingAverageOf[T Float](n int, options ...ExponentialOption) (*ExponentialMovingAverageOf[T], error) {
+	config, err := newExponentialConfig(options)
+	if n <= 0 || err != nil {
 		return nil, ErrInvalidParameters
 	}
 
-	return &ExponentialMovingAverage{
-		n: n,
+	return &ExponentialMovingAverageOf[T]{
+		n:      n,
+		config: config,
 
-		k:       2. / (float64(n) + 1.),
-		current: 0,
-		isNew:   true,
+		smoothing: newExponentialSmoothing[T](n, config),
+		current:   0,
 	}, nil
 }
 
 // Next takes the next input and returns the next ExponentialMovingAverage value
-func (ma *ExponentialMovingAverage) Next(input float64) float64 {
-	if ma.isNew {
-		ma.isNew = false
-		ma.current = input
-	} else {
-		ma.current = ma.k*input + (1.-ma.k)*ma.current
+func (ma *ExponentialMovingAverageOf[T]) Next(input T) T {
+	ma.previous = ma.current
+	k := ma.smoothing.next()
+	ma.current = k*input + (1.-k)*ma.current
+	return ma.current
+}
+
+// Peek returns the value Next would return for input without committing it
+func (ma *ExponentialMovingAverageOf[T]) Peek(input T) T {
+	smoothing := ma.smoothing
+	k := smoothing.next()
+	return k*input + (1.-k)*ma.current
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ExponentialMovingAverage value.
+// If no input has been committed yet it behaves like Next.
+func (ma *ExponentialMovingAverageOf[T]) UpdateLast(input T) T {
+	if ma.smoothing.count == 0 {
+		return ma.Next(input)
 	}
+
+	k := ma.smoothing.weight()
+	ma.current = k*input + (1.-k)*ma.previous
 	return ma.current
 }
 
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (ma *ExponentialMovingAverageOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+
+	// seed through Next, then run the recurrence
+	i := 0
+	for ; i < len(inputs) && ma.smoothing.count <= ma.smoothing.seed; i++ {
+		out[i] = ma.Next(inputs[i])
+	}
+	if i == len(inputs) {
+		return out
+	}
+
+	k, previous, current := ma.smoothing.alpha, ma.previous, ma.current
+	for ; i < len(inputs); i++ {
+		previous = current
+		current = k*inputs[i] + (1.-k)*current
+		out[i] = current
+	}
+
+	ma.previous, ma.current = previous, current
+	return out
+}
+
 // Reset resets the indicators to a clean state
-func (ma *ExponentialMovingAverage) Reset() {
-	ma.isNew = true
+func (ma *ExponentialMovingAverageOf[T]) Reset() {
+	ma.smoothing.count = 0
 	ma.current = 0
+
+	ma.previous = 0
 }
 
-func (ma *ExponentialMovingAverage) String() string {
-	return fmt.Sprintf("EMA(%d)", ma.n)
+// Clone returns a copy of the indicator that can be advanced independently
+func (ma *ExponentialMovingAverageOf[T]) Clone() *ExponentialMovingAverageOf[T] {
+	clone := *ma
+	return &clone
+}
+
+func (ma *ExponentialMovingAverageOf[T]) String() string {
+	return ma.config.name("EMA", ma.n)
+}
+
+// ComputeExponentialMovingAverage computes a ExponentialMovingAverage with n periods and options over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeExponentialMovingAverage[T Float](n int, inputs, out []T, fillNaN bool, options ...ExponentialOption) ([]T, error) {
+	ma, err := NewExponentialMovingAverageOf[T](n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	out = ma.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
 }
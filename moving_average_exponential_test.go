@@ -6,6 +6,7 @@ Licensed under terms of MIT license (see LICENSE)
 package tago
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -20,7 +21,7 @@ func TestNewExponentialMovingAverage(t *testing.T) {
 	}{
 		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
 		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
-		"positive n": {input: 9, want: &ExponentialMovingAverage{n: 9, k: 2. / 10., isNew: true}, wantErr: nil},
+		"positive n": {input: 9, want: &ExponentialMovingAverage{n: 9, smoothing: exponentialSmoothing[float64]{alpha: 2. / 10., seed: 1}}, wantErr: nil},
 	}
 
 	for name, tc := range tests {
@@ -87,6 +88,167 @@ func TestExponentialMovingAverageReset(t *testing.T) {
 	}
 }
 
+func TestExponentialMovingAveragePeek(t *testing.T) {
+	sd, _ := NewExponentialMovingAverage(3)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 5., want: 3.5},
+		{input: 1., want: 2.25},
+		{input: 6.25, want: 4.25},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverageUpdateLast(t *testing.T) {
+	sd, _ := NewExponentialMovingAverage(3)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 2., update: 4., want: 4.},
+		{input: 5., update: 6., want: 5.},
+		{input: 1., update: 2., want: 3.5},
+		{input: 7., update: 0.5, want: 2.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverageUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewExponentialMovingAverage(3)
+	diff := cmp.Diff(2., sd.UpdateLast(2.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(3.5, sd.Next(5.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestExponentialMovingAverageCompute(t *testing.T) {
+	inputs := []float64{2., 5., 1., 6.25}
+
+	stream, _ := NewExponentialMovingAverage(3)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewExponentialMovingAverage(3)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeExponentialMovingAverage(t *testing.T) {
+	inputs := []float64{2., 5., 1., 6.25}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 3, fillNaN: false, want: []float64{2., 3.5, 2.25, 4.25}},
+		"fill NaN": {n: 3, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 2.25, 4.25}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeExponentialMovingAverage(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverageClone(t *testing.T) {
+	sd, _ := NewExponentialMovingAverage(3)
+	sd.Next(2.)
+	sd.Next(5.)
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone, "clone must start from the same state")
+
+	tests := []struct {
+		indicator *ExponentialMovingAverage
+		input     float64
+		want      float64
+	}{
+		{indicator: clone, input: 1., want: 2.25},
+		{indicator: sd, input: 6.5, want: 5.},
+		{indicator: clone, input: 6.25, want: 4.25},
+		{indicator: sd, input: 5., want: 5.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tc.indicator.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverageOfFloat32(t *testing.T) {
+	sd, _ := NewExponentialMovingAverageOf[float32](3)
+	tests := []struct {
+		input float32
+		want  float64
+	}{
+		{input: 2., want: 2.},
+		{input: 5., want: 3.5},
+		{input: 1., want: 2.25},
+		{input: 6.25, want: 4.25},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, float64(got), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestExponentialMovingAverageString(t *testing.T) {
 	sd, _ := NewExponentialMovingAverage(4)
 	want := "EMA(4)"
@@ -96,3 +258,85 @@ func TestExponentialMovingAverageString(t *testing.T) {
 		t.Fatalf(diff)
 	}
 }
+
+func BenchmarkExponentialMovingAverageNext(b *testing.B) {
+	inputs := benchmarkInputs()
+	sd, _ := NewExponentialMovingAverage(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			sd.Next(input)
+		}
+	}
+}
+
+func BenchmarkExponentialMovingAverageCompute(b *testing.B) {
+	inputs := benchmarkInputs()
+	out := make([]float64, len(inputs))
+	sd, _ := NewExponentialMovingAverage(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = sd.Compute(inputs, out)
+	}
+}
+
+func TestNewExponentialMovingAverageOptions(t *testing.T) {
+	tests := map[string]struct {
+		options []ExponentialOption
+		wantErr error
+	}{
+		"zero alpha":      {options: []ExponentialOption{WithAlpha(0.)}, wantErr: ErrInvalidParameters},
+		"negative alpha":  {options: []ExponentialOption{WithAlpha(-0.5)}, wantErr: ErrInvalidParameters},
+		"alpha above one": {options: []ExponentialOption{WithAlpha(1.5)}, wantErr: ErrInvalidParameters},
+		"NaN alpha":       {options: []ExponentialOption{WithAlpha(math.NaN())}, wantErr: ErrInvalidParameters},
+		"unknown seeding": {options: []ExponentialOption{WithSeeding(Seeding(7))}, wantErr: ErrInvalidParameters},
+		"alpha of one":    {options: []ExponentialOption{WithAlpha(1.)}, wantErr: nil},
+		"all options":     {options: []ExponentialOption{WithAlpha(0.06), WithSeeding(SeedMean), WithBiasCorrection()}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewExponentialMovingAverage(4, tc.options...)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestExponentialMovingAverageOptions(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1., 9., 9., 0., 3., 5., 7., 1.}
+	tests := map[string]struct {
+		options []ExponentialOption
+		want    []float64
+	}{
+		"alpha":     {options: []ExponentialOption{WithAlpha(0.06)}, want: []float64{2., 2.36, 2.2184, 2.3253, 2.24578, 2.65103, 3.03197, 2.85005, 2.85905, 2.98751, 3.22826, 3.09456}},
+		"seed mean": {options: []ExponentialOption{WithSeeding(SeedMean)}, want: []float64{2., 5., 3.33333, 3.5, 2.5, 5.1, 6.66, 3.996, 3.5976, 4.15856, 5.29514, 3.57708}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream, _ := NewExponentialMovingAverage(4, tc.options...)
+			got := make([]float64, len(inputs))
+			for i, input := range inputs {
+				got[i] = stream.Next(input)
+			}
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			batch, _ := NewExponentialMovingAverage(4, tc.options...)
+			assert.Equal(t, got, batch.Compute(inputs, nil), "must return the same values as Next")
+			assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+		})
+	}
+}
+
+func TestExponentialMovingAverageStringAlpha(t *testing.T) {
+	sd, _ := NewExponentialMovingAverage(4, WithAlpha(0.06))
+	assert.Equal(t, "EMA(4,0.06)", sd.String())
+}
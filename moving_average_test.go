@@ -6,6 +6,7 @@ Licensed under terms of MIT license (see LICENSE)
 package tago
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -86,6 +87,194 @@ func TestMovingAverageReset(t *testing.T) {
 	}
 }
 
+func TestMovingAveragePeek(t *testing.T) {
+	sd, _ := NewMovingAverage(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 4., want: 4.},
+		{input: 5., want: 4.5},
+		{input: 6., want: 5.},
+		{input: 6., want: 5.25},
+		{input: 6., want: 5.75},
+		{input: 6., want: 6.},
+		{input: 2., want: 5.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMovingAverageUpdateLast(t *testing.T) {
+	sd, _ := NewMovingAverage(3)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 4., update: 6., want: 6.},
+		{input: 5., update: 3., want: 4.5},
+		{input: 9., update: 9., want: 6.},
+		{input: 1., update: 12., want: 8.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMovingAverageUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewMovingAverage(3)
+	diff := cmp.Diff(2., sd.UpdateLast(2.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(3., sd.Next(4.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestMovingAverageCompute(t *testing.T) {
+	inputs := []float64{4., 5., 6., 6., 6., 6., 2.}
+
+	stream, _ := NewMovingAverage(4)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewMovingAverage(4)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeMovingAverage(t *testing.T) {
+	inputs := []float64{4., 5., 6., 9.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 3, fillNaN: false, want: []float64{4., 4.5, 5., 6.667}},
+		"fill NaN": {n: 3, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 5., 6.667}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeMovingAverage(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMovingAverageClone(t *testing.T) {
+	sd, _ := NewMovingAverage(3)
+	sd.Next(4.)
+	sd.Next(5.)
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone, "clone must start from the same state")
+
+	tests := []struct {
+		indicator *MovingAverage
+		input     float64
+		want      float64
+	}{
+		{indicator: clone, input: 51., want: 20.},
+		{indicator: sd, input: 6., want: 5.},
+		{indicator: clone, input: 10., want: 22.},
+		{indicator: sd, input: 7., want: 6.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tc.indicator.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMovingAverageOfFloat32(t *testing.T) {
+	sd, _ := NewMovingAverageOf[float32](4)
+	tests := []struct {
+		input float32
+		want  float64
+	}{
+		{input: 4., want: 4.},
+		{input: 5., want: 4.5},
+		{input: 6., want: 5.},
+		{input: 6., want: 5.25},
+		{input: 6., want: 5.75},
+		{input: 6., want: 6.},
+		{input: 2., want: 5.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, float64(got), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMovingAverageOfFloat32LongStream(t *testing.T) {
+	inputs := driftInputs[float32](3000007)
+
+	stream, _ := NewMovingAverageOf[float32](10)
+	var got float32
+	for _, input := range inputs {
+		stream.Next(input + 1.)
+		got = stream.UpdateLast(input)
+	}
+	batch, _ := NewMovingAverageOf[float32](10)
+	out := batch.Compute(inputs, nil)
+
+	fresh, _ := NewMovingAverage(10)
+	var want float64
+	for _, input := range inputs[len(inputs)-10:] {
+		want = fresh.Next(float64(input))
+	}
+	assert.InEpsilon(t, want, float64(got), 1e-6, "Next and UpdateLast must match a fresh window")
+	assert.InEpsilon(t, want, float64(out[len(out)-1]), 1e-6, "Compute must match a fresh window")
+}
+
 func TestMovingAverageString(t *testing.T) {
 	sd, _ := NewMovingAverage(4)
 	want := "MA(4)"
@@ -95,3 +284,24 @@ func TestMovingAverageString(t *testing.T) {
 		t.Fatalf(diff)
 	}
 }
+
+func BenchmarkMovingAverageNext(b *testing.B) {
+	inputs := benchmarkInputs()
+	sd, _ := NewMovingAverage(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			sd.Next(input)
+		}
+	}
+}
+
+func BenchmarkMovingAverageCompute(b *testing.B) {
+	inputs := benchmarkInputs()
+	out := make([]float64, len(inputs))
+	sd, _ := NewMovingAverage(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = sd.Compute(inputs, out)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+ParkinsonOf returns the Parkinson volatility of the last _n_ bars, estimated from their high-low ranges.
+Using the range of each bar rather than the close-to-close change makes it several times more efficient
+than StandardDeviation of returns, but it assumes no drift and no opening jumps.
+
+# Formula
+
+σ<sup>2</sup> = Σ ln(H<sub>i</sub> / L<sub>i</sub>)<sup>2</sup> / (4·ln 2·N)
+
+Where:
+
+* _H<sub>i</sub>_, _L<sub>i</sub>_ - high and low of the bar
+* _N_ - number of bars in observation.
+
+The result is the volatility of a single period, see HistoricalVolatility to annualise it.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+p, _ := NewParkinson(20)
+p.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type ParkinsonOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	ma *MovingAverageOf[T]
+}
+
+// Parkinson is a ParkinsonOf float64 values
+type Parkinson = ParkinsonOf[float64]
+
+// NewParkinson creates a new Parkinson with the given number of periods
+// Example: NewParkinson(20)
+func NewParkinson(n int) (*Parkinson, error) {
+	return NewParkinsonOf[float64](n)
+}
+
+// NewParkinsonOf creates a new ParkinsonOf values of type T with the given number of periods
+// Example: NewParkinsonOf[float32](20)
+func NewParkinsonOf[T Float](n int) (*ParkinsonOf[T], error) {
+	ma, err := NewMovingAverageOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParkinsonOf[T]{
+		n: n,
+
+		ma: ma,
+	}, nil
+}
+
+// Next takes the next bar and returns the next Parkinson value
+func (p *ParkinsonOf[T]) Next(bar BarOf[T]) T {
+	return volatility(p.ma.Next(parkinsonTerm(bar)))
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (p *ParkinsonOf[T]) Peek(bar BarOf[T]) T {
+	return volatility(p.ma.Peek(parkinsonTerm(bar)))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated Parkinson value. If no bar has been committed yet it behaves like Next.
+func (p *ParkinsonOf[T]) UpdateLast(bar BarOf[T]) T {
+	return volatility(p.ma.UpdateLast(parkinsonTerm(bar)))
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (p *ParkinsonOf[T]) Compute(bars []BarOf[T], out []T) []T {
+	return computeBars(p.Next, bars, out)
+}
+
+// Reset resets the indicators to a clean state
+func (p *ParkinsonOf[T]) Reset() {
+	p.ma.Reset()
+}
+
+// Clone returns a deep copy of the indicator, including its MovingAverage
+func (p *ParkinsonOf[T]) Clone() *ParkinsonOf[T] {
+	clone := *p
+	clone.ma = p.ma.Clone()
+	return &clone
+}
+
+func (p *ParkinsonOf[T]) String() string {
+	return fmt.Sprintf("Parkinson(%d)", p.n)
+}
+
+// ComputeParkinson computes a Parkinson with n periods over bars and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeParkinson[T Float](n int, bars []BarOf[T], out []T, fillNaN bool) ([]T, error) {
+	p, err := NewParkinsonOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = p.Compute(bars, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// parkinsonTerm returns the contribution of bar to the Parkinson variance
+func parkinsonTerm[T Float](bar BarOf[T]) T {
+	hl := logRatio(bar.High, bar.Low)
+	return hl * hl / T(4*math.Ln2)
+}
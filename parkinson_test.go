@@ -0,0 +1,129 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewParkinson(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		wantErr error
+	}{
+		"negative n": {input: -3, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewParkinson(tc.input)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.input, got.n, "must return the correct value")
+		})
+	}
+}
+
+func TestParkinsonNext(t *testing.T) {
+	p, _ := NewParkinson(4)
+	tests := []struct {
+		input Bar
+		want  float64
+	}{
+		{input: Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5}, want: 0.0880444},
+		{input: Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.}, want: 0.0761786},
+		{input: Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6}, want: 0.0713027},
+		{input: Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9}, want: 0.0695232},
+		{input: Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3}, want: 0.058934},
+		{input: Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4}, want: 0.0632549},
+		{input: Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1}, want: 0.05914},
+		{input: Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2}, want: 0.0586838},
+		{input: Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7}, want: 0.0596724},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := p.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeParkinson(t *testing.T) {
+	bars := []Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+	}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0.0880444, 0.0761786, 0.0713027, 0.0695232, 0.058934}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 0.0695232, 0.058934}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeParkinson(tc.n, bars, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestParkinsonBars(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		bars []Bar
+		want float64
+	}{
+		"flat bars": {n: 3, bars: []Bar{
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+		}, want: 0.},
+		"scaled prices": {n: 4, bars: []Bar{Bar{Open: 100., High: 110., Low: 95., Close: 105.}}, want: 0.0880444},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewParkinson(tc.n)
+			got := sd.Compute(tc.bars, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestParkinsonString(t *testing.T) {
+	p, _ := NewParkinson(4)
+	assert.Equal(t, "Parkinson(4)", p.String())
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+PercentChangeOf returns the simple return of the input over _n_ periods, as a fraction:
+0.01 for a rise of 1%. It is RateOfChange divided by 100.
+
+# Formula
+
+PctChange<sub>t</sub> = p<sub>t</sub> / p<sub>t-n</sub> - 1
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the first input stands in for p<sub>t-n</sub>.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+pc, _ := NewPercentChange(1)
+pc.Next(10.)
+```
+*/
+type PercentChangeOf[T Float] struct {
+	lookback[T]
+}
+
+// PercentChange is a PercentChangeOf float64 values
+type PercentChange = PercentChangeOf[float64]
+
+// NewPercentChange creates a new PercentChange with the given number of periods
+// Example: NewPercentChange(1)
+func NewPercentChange(n int) (*PercentChange, error) {
+	return NewPercentChangeOf[float64](n)
+}
+
+// NewPercentChangeOf creates a new PercentChangeOf values of type T with the given number of periods
+// Example: NewPercentChangeOf[float32](1)
+func NewPercentChangeOf[T Float](n int) (*PercentChangeOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &PercentChangeOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next PercentChange value
+func (pc *PercentChangeOf[T]) Next(input T) T {
+	past := pc.push(input)
+	return input/past - 1
+}
+
+// Peek returns the value Next would return for input without committing it
+func (pc *PercentChangeOf[T]) Peek(input T) T {
+	past := pc.peek(input)
+	return input/past - 1
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated PercentChange value.
+// If no input has been committed yet it behaves like Next.
+func (pc *PercentChangeOf[T]) UpdateLast(input T) T {
+	past := pc.replaceLast(input)
+	return input/past - 1
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (pc *PercentChangeOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = pc.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (pc *PercentChangeOf[T]) Reset() {
+	pc.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (pc *PercentChangeOf[T]) Clone() *PercentChangeOf[T] {
+	return &PercentChangeOf[T]{
+		lookback: pc.clone(),
+	}
+}
+
+func (pc *PercentChangeOf[T]) String() string {
+	return fmt.Sprintf("PctChange(%d)", pc.n)
+}
+
+// ComputePercentChange computes a PercentChange with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputePercentChange[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	pc, err := NewPercentChangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = pc.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+PercentRankOf returns the fraction of the last n values before the current input that are below it,
+placing the input within its recent history: 0 for a new low and 1 for a new high.
+The window is kept sorted, so counting the values below an input takes a binary search.
+
+# Formula
+
+PercentRank = count(x<sub>i</sub> < x<sub>t</sub>) / N
+
+Where:
+
+* _x<sub>t</sub>_ - current input
+* _x<sub>i</sub>_ - the last n inputs before it
+* _N_ - number of probes in observation.
+
+The percent rank of the first input, which has no history, is 0.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+pr, _ := NewPercentRank(20)
+pr.Next(10.)
+```
+*/
+type PercentRankOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// sorted window of the current input and the n inputs before it
+	window sortedWindow[T]
+}
+
+// PercentRank is a PercentRankOf float64 values
+type PercentRank = PercentRankOf[float64]
+
+// NewPercentRank creates a new PercentRank with the given number of periods
+// Example: NewPercentRank(20)
+func NewPercentRank(n int) (*PercentRank, error) {
+	return NewPercentRankOf[float64](n)
+}
+
+// NewPercentRankOf creates a new PercentRankOf values of type T with the given number of periods
+// Example: NewPercentRankOf[float32](20)
+func NewPercentRankOf[T Float](n int) (*PercentRankOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &PercentRankOf[T]{
+		n: n,
+
+		window: newSortedWindow[T](n + 1),
+	}, nil
+}
+
+// Next takes the next input and returns the next PercentRank value
+func (pr *PercentRankOf[T]) Next(input T) T {
+	pr.window.push(input)
+	return percentRank(pr.window.sorted, input)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (pr *PercentRankOf[T]) Peek(input T) T {
+	return pr.window.peek(input, func(sorted []T) T {
+		return percentRank(sorted, input)
+	})
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated PercentRank value.
+// If no input has been committed yet it behaves like Next.
+func (pr *PercentRankOf[T]) UpdateLast(input T) T {
+	pr.window.replaceLast(input)
+	return percentRank(pr.window.sorted, input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (pr *PercentRankOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = pr.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (pr *PercentRankOf[T]) Reset() {
+	pr.window.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (pr *PercentRankOf[T]) Clone() *PercentRankOf[T] {
+	return &PercentRankOf[T]{
+		n: pr.n,
+
+		window: pr.window.clone(),
+	}
+}
+
+func (pr *PercentRankOf[T]) String() string {
+	return fmt.Sprintf("PercentRank(%d)", pr.n)
+}
+
+// ComputePercentRank computes a PercentRank with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputePercentRank[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	pr, err := NewPercentRankOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = pr.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
+
+// percentRank returns the fraction of the other values of sorted, which includes x, that are below x
+func percentRank[T Float](sorted []T, x T) T {
+	if len(sorted) < 2 {
+		return 0
+	}
+	return T(searchSorted(sorted, x)) / T(len(sorted)-1)
+}
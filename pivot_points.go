@@ -0,0 +1,242 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+// PivotMethod selects how PivotPoints derives the floor trader levels from a bar
+type PivotMethod int
+
+const (
+	// ClassicPivots derives three support and resistance levels from the typical price
+	ClassicPivots PivotMethod = iota
+	// FibonacciPivots places three levels at 0.382, 0.618 and 1 times the range around the typical price
+	FibonacciPivots
+	// CamarillaPivots places four levels at 1.1/12, 1.1/6, 1.1/4 and 1.1/2 times the range around the close
+	CamarillaPivots
+	// WoodiePivots weighs the close twice in the pivot and derives four levels from it
+	WoodiePivots
+)
+
+func (m PivotMethod) String() string {
+	switch m {
+	case ClassicPivots:
+		return "Classic"
+	case FibonacciPivots:
+		return "Fibonacci"
+	case CamarillaPivots:
+		return "Camarilla"
+	case WoodiePivots:
+		return "Woodie"
+	default:
+		return fmt.Sprintf("PivotMethod(%d)", int(m))
+	}
+}
+
+// PivotLevelsOf holds the floor trader pivot point of a session of values of type T, with its
+// resistance levels above and support levels below. The levels a method does not define are NaN.
+type PivotLevelsOf[T Float] struct {
+	Pivot T
+
+	R1, R2, R3, R4 T
+	S1, S2, S3, S4 T
+}
+
+// PivotLevels is a PivotLevelsOf float64 values
+type PivotLevels = PivotLevelsOf[float64]
+
+/*
+PivotPointsOf returns the floor trader pivot points of a session from the bar of the previous session,
+such as a daily bar from a BarAggregator.
+
+# Formula
+
+Classic and Fibonacci:
+
+* _P_ = (H + L + C) / 3
+* Classic: _R1_ = 2P - L, _S1_ = 2P - H, _R2_ = P + (H - L), _S2_ = P - (H - L), _R3_ = H + 2(P - L), _S3_ = L - 2(H - P)
+* Fibonacci: _R<sub>i</sub>_ = P + f<sub>i</sub>(H - L), _S<sub>i</sub>_ = P - f<sub>i</sub>(H - L), f = 0.382, 0.618, 1
+
+Camarilla:
+
+* _P_ = (H + L + C) / 3
+* _R<sub>i</sub>_ = C + f<sub>i</sub>(H - L), _S<sub>i</sub>_ = C - f<sub>i</sub>(H - L), f = 1.1/12, 1.1/6, 1.1/4, 1.1/2
+
+Woodie:
+
+* _P_ = (H + L + 2C) / 4
+* _R1_, _S1_, _R2_, _S2_, _R3_ and _S3_ as Classic, _R4_ = R3 + (H - L), _S4_ = S3 - (H - L)
+
+Where:
+
+* _H_, _L_, _C_ - high, low and close of the previous session
+
+# Parameters
+
+* _method_ - ClassicPivots, FibonacciPivots, CamarillaPivots or WoodiePivots
+
+# Example
+```
+pivots, _ := NewPivotPoints(ClassicPivots)
+levels := pivots.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type PivotPointsOf[T Float] struct {
+	method PivotMethod
+
+	// internal parameters for calculations
+	last  BarOf[T]
+	count int
+}
+
+// PivotPoints is a PivotPointsOf float64 values
+type PivotPoints = PivotPointsOf[float64]
+
+// NewPivotPoints creates a new PivotPoints with the given method
+// Example: NewPivotPoints(ClassicPivots)
+func NewPivotPoints(method PivotMethod) (*PivotPoints, error) {
+	return NewPivotPointsOf[float64](method)
+}
+
+// NewPivotPointsOf creates a new PivotPointsOf values of type T with the given method
+// Example: NewPivotPointsOf[float32](ClassicPivots)
+func NewPivotPointsOf[T Float](method PivotMethod) (*PivotPointsOf[T], error) {
+	if method < ClassicPivots || method > WoodiePivots {
+		return nil, ErrInvalidParameters
+	}
+
+	return &PivotPointsOf[T]{
+		method: method,
+	}, nil
+}
+
+// Next takes the next session bar and returns the levels of the session it opens,
+// those derived from bar and in force until the next session bar
+func (pp *PivotPointsOf[T]) Next(bar BarOf[T]) PivotLevelsOf[T] {
+	pp.last = bar
+	pp.count++
+	return pivotLevels(pp.method, bar)
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (pp *PivotPointsOf[T]) Peek(bar BarOf[T]) PivotLevelsOf[T] {
+	return pivotLevels(pp.method, bar)
+}
+
+// UpdateLast replaces the most recent bar with bar and returns the updated levels.
+// If no bar has been committed yet it behaves like Next.
+func (pp *PivotPointsOf[T]) UpdateLast(bar BarOf[T]) PivotLevelsOf[T] {
+	if pp.count == 0 {
+		return pp.Next(bar)
+	}
+
+	pp.last = bar
+	return pivotLevels(pp.method, bar)
+}
+
+// Levels returns the levels in force, derived from the last session bar, and false before the first one
+func (pp *PivotPointsOf[T]) Levels() (PivotLevelsOf[T], bool) {
+	if pp.count == 0 {
+		return PivotLevelsOf[T]{}, false
+	}
+	return pivotLevels(pp.method, pp.last), true
+}
+
+// Reset resets the indicators to a clean state
+func (pp *PivotPointsOf[T]) Reset() {
+	pp.last = BarOf[T]{}
+	pp.count = 0
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (pp *PivotPointsOf[T]) Clone() *PivotPointsOf[T] {
+	clone := *pp
+	return &clone
+}
+
+func (pp *PivotPointsOf[T]) String() string {
+	return fmt.Sprintf("PivotPoints(%s)", pp.method)
+}
+
+// ComputePivotPoints returns the levels derived with method from each bar of bars, and writes them to out,
+// which is reused when it has enough capacity
+func ComputePivotPoints[T Float](method PivotMethod, bars []BarOf[T], out []PivotLevelsOf[T]) ([]PivotLevelsOf[T], error) {
+	pp, err := NewPivotPointsOf[T](method)
+	if err != nil {
+		return nil, err
+	}
+
+	out = resize(out, len(bars))
+	for i, bar := range bars {
+		out[i] = pp.Next(bar)
+	}
+	return out, nil
+}
+
+// pivotLevels returns the levels derived with method from the high, low and close of bar
+func pivotLevels[T Float](method PivotMethod, bar BarOf[T]) PivotLevelsOf[T] {
+	high, low, close := bar.High, bar.Low, bar.Close
+	span := high - low
+	nan := T(math.NaN())
+
+	switch method {
+	case FibonacciPivots:
+		p := (high + low + close) / 3
+		return PivotLevelsOf[T]{
+			Pivot: p,
+
+			R1: p + 0.382*span,
+			R2: p + 0.618*span,
+			R3: p + span,
+			R4: nan,
+
+			S1: p - 0.382*span,
+			S2: p - 0.618*span,
+			S3: p - span,
+			S4: nan,
+		}
+	case CamarillaPivots:
+		return PivotLevelsOf[T]{
+			Pivot: (high + low + close) / 3,
+
+			R1: close + span*1.1/12,
+			R2: close + span*1.1/6,
+			R3: close + span*1.1/4,
+			R4: close + span*1.1/2,
+
+			S1: close - span*1.1/12,
+			S2: close - span*1.1/6,
+			S3: close - span*1.1/4,
+			S4: close - span*1.1/2,
+		}
+	case WoodiePivots:
+		levels := floorLevels((high+low+2*close)/4, high, low)
+		levels.R4, levels.S4 = levels.R3+span, levels.S3-span
+		return levels
+	default:
+		levels := floorLevels((high+low+close)/3, high, low)
+		levels.R4, levels.S4 = nan, nan
+		return levels
+	}
+}
+
+// floorLevels returns the classic levels around the pivot p of a bar with the given high and low
+func floorLevels[T Float](p, high, low T) PivotLevelsOf[T] {
+	return PivotLevelsOf[T]{
+		Pivot: p,
+
+		R1: 2*p - low,
+		R2: p + (high - low),
+		R3: high + 2*(p-low),
+
+		S1: 2*p - high,
+		S2: p - (high - low),
+		S3: low - 2*(high-p),
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionBar = Bar{Open: 102., High: 110., Low: 100., Close: 108.}
+
+func TestNewPivotPoints(t *testing.T) {
+	tests := map[string]struct {
+		method  PivotMethod
+		wantErr error
+	}{
+		"negative method": {method: -1, wantErr: ErrInvalidParameters},
+		"unknown method":  {method: WoodiePivots + 1, wantErr: ErrInvalidParameters},
+		"classic":         {method: ClassicPivots, wantErr: nil},
+		"woodie":          {method: WoodiePivots, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewPivotPoints(tc.method)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestPivotPointsNext(t *testing.T) {
+	nan := math.NaN()
+	tests := map[PivotMethod]PivotLevels{
+		ClassicPivots: {
+			Pivot: 106.,
+
+			R1: 112.,
+			R2: 116.,
+			R3: 122.,
+			R4: nan,
+
+			S1: 102.,
+			S2: 96.,
+			S3: 92.,
+			S4: nan,
+		},
+		FibonacciPivots: {
+			Pivot: 106.,
+
+			R1: 109.82,
+			R2: 112.18,
+			R3: 116.,
+			R4: nan,
+
+			S1: 102.18,
+			S2: 99.82,
+			S3: 96.,
+			S4: nan,
+		},
+		CamarillaPivots: {
+			Pivot: 106.,
+
+			R1: 108.916667,
+			R2: 109.833333,
+			R3: 110.75,
+			R4: 113.5,
+
+			S1: 107.083333,
+			S2: 106.166667,
+			S3: 105.25,
+			S4: 102.5,
+		},
+		WoodiePivots: {
+			Pivot: 106.5,
+
+			R1: 113.,
+			R2: 116.5,
+			R3: 123.,
+			R4: 133.,
+
+			S1: 103.,
+			S2: 96.5,
+			S3: 93.,
+			S4: 83.,
+		},
+	}
+
+	for method, want := range tests {
+		t.Run(method.String(), func(t *testing.T) {
+			pp, _ := NewPivotPoints(method)
+			_, ok := pp.Levels()
+			assert.False(t, ok, "must not return levels before the first session")
+
+			got := pp.Next(sessionBar)
+			if diff := cmp.Diff(want, got, nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+
+			levels, ok := pp.Levels()
+			assert.True(t, ok)
+			if diff := cmp.Diff(want, levels, nanComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestPivotPointsUpdateLast(t *testing.T) {
+	pp, _ := NewPivotPoints(ClassicPivots)
+	assert.Equal(t, 106., pp.UpdateLast(sessionBar).Pivot, "must behave like Next when empty")
+
+	assert.Equal(t, 105., pp.Peek(Bar{High: 110., Low: 100., Close: 105.}).Pivot)
+	levels, _ := pp.Levels()
+	assert.Equal(t, 106., levels.Pivot, "Peek must not be committed")
+
+	assert.Equal(t, 105., pp.UpdateLast(Bar{High: 110., Low: 100., Close: 105.}).Pivot)
+	levels, _ = pp.Levels()
+	assert.Equal(t, 105., levels.Pivot, "must replace the last session")
+}
+
+func TestComputePivotPoints(t *testing.T) {
+	_, err := ComputePivotPoints(PivotMethod(10), []Bar{sessionBar}, nil)
+	assert.EqualError(t, err, ErrInvalidParameters.Error(), "must return the correct error")
+
+	bars := []Bar{sessionBar, {High: 110., Low: 100., Close: 105.}}
+	out := make([]PivotLevels, 2)
+	got, err := ComputePivotPoints(ClassicPivots, bars, out)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{106., 105.}, []float64{got[0].Pivot, got[1].Pivot})
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+}
+
+func TestPivotPointsClone(t *testing.T) {
+	pp, _ := NewPivotPoints(CamarillaPivots)
+	pp.Next(sessionBar)
+	clone := pp.Clone()
+	clone.Next(Bar{High: 110., Low: 100., Close: 105.})
+
+	levels, _ := pp.Levels()
+	assert.Equal(t, 113.5, levels.R4, "original must not see the clone's inputs")
+}
+
+func TestPivotPointsReset(t *testing.T) {
+	pp, _ := NewPivotPoints(FibonacciPivots)
+	pp.Next(sessionBar)
+	pp.Reset()
+
+	want, _ := NewPivotPoints(FibonacciPivots)
+	assert.Equal(t, want, pp, "must return to a clean state")
+}
+
+func TestPivotPointsString(t *testing.T) {
+	pp, _ := NewPivotPoints(CamarillaPivots)
+	assert.Equal(t, "PivotPoints(Camarilla)", pp.String())
+	assert.Equal(t, "PivotMethod(7)", PivotMethod(7).String())
+}
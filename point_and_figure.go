@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+PointAndFigureOf turns bars into the columns of a point-and-figure chart, rising columns of Xs and falling
+columns of Os on a grid of boxes. A column grows while the close reaches further boxes in its direction, and a
+move of _reversal_ boxes the other way completes it and starts a column in the other direction one box away.
+Passing ticks as bars, see Trade.Bar, builds the columns from every trade.
+
+Columns are emitted as bars from their first box, the Open, to their last box, the Close, so a rising column
+has Close above Open. Each column has the time of the bar that started it and the volume traded while it grew.
+The direction of the first column is set by the first move of a whole box from the box of the first close.
+
+# Parameters
+
+* _box_ - size of a box (greater than 0)
+* _reversal_ - number of boxes of a reversal (integer greater than 0), commonly 3
+
+# Example
+```
+pf, _ := NewPointAndFigure(1., 3)
+columns := pf.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type PointAndFigureOf[T Float] struct {
+	// size of a box (must be greater than 0)
+	box T
+	// number of boxes of a reversal (must be an integer greater than 0)
+	reversal int
+
+	// column in progress, rising if direction is 1, falling if -1 and not yet known if 0
+	current    BarOf[T]
+	hasCurrent bool
+	direction  int
+
+	out []BarOf[T]
+}
+
+// PointAndFigure is a PointAndFigureOf float64 values
+type PointAndFigure = PointAndFigureOf[float64]
+
+// NewPointAndFigure creates a new PointAndFigure with the given box size and reversal
+// Example: NewPointAndFigure(1., 3)
+func NewPointAndFigure(box float64, reversal int) (*PointAndFigure, error) {
+	return NewPointAndFigureOf(box, reversal)
+}
+
+// NewPointAndFigureOf creates a new PointAndFigureOf values of type T with the given box size and reversal
+// Example: NewPointAndFigureOf[float32](1., 3)
+func NewPointAndFigureOf[T Float](box T, reversal int) (*PointAndFigureOf[T], error) {
+	if !(box > 0) || reversal <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &PointAndFigureOf[T]{
+		box:      box,
+		reversal: reversal,
+	}, nil
+}
+
+// Next takes the next bar and returns the column it completes, if any. The returned slice is reused by
+// the next call.
+func (pf *PointAndFigureOf[T]) Next(bar BarOf[T]) []BarOf[T] {
+	pf.out = pf.out[:0]
+	price := bar.Close
+	if !pf.hasCurrent {
+		pf.start(bar, pf.floor(price))
+		pf.current.Volume = bar.Volume
+		return pf.out
+	}
+
+	last := pf.current.Close
+	reversal := T(pf.reversal) * pf.box
+	switch {
+	case pf.direction >= 0 && price >= last+pf.box:
+		pf.direction = 1
+		pf.extend(pf.floor(price))
+	case pf.direction <= 0 && price <= last-pf.box:
+		pf.direction = -1
+		pf.extend(pf.ceil(price))
+	case pf.direction > 0 && price <= last-reversal:
+		pf.out = append(pf.out, pf.current)
+		pf.start(bar, last-pf.box)
+		pf.direction = -1
+		pf.extend(pf.ceil(price))
+	case pf.direction < 0 && price >= last+reversal:
+		pf.out = append(pf.out, pf.current)
+		pf.start(bar, last+pf.box)
+		pf.direction = 1
+		pf.extend(pf.floor(price))
+	}
+
+	pf.current.Volume += bar.Volume
+	return pf.out
+}
+
+// start starts a column at the box level
+func (pf *PointAndFigureOf[T]) start(bar BarOf[T], level T) {
+	pf.current = BarOf[T]{
+		Time:  bar.Time,
+		Open:  level,
+		High:  level,
+		Low:   level,
+		Close: level,
+	}
+	pf.hasCurrent = true
+}
+
+// extend extends the column in progress to the box level
+func (pf *PointAndFigureOf[T]) extend(level T) {
+	pf.current.Close = level
+	if level > pf.current.High {
+		pf.current.High = level
+	}
+	if level < pf.current.Low {
+		pf.current.Low = level
+	}
+}
+
+// floor returns the highest box level at or below price, tolerating the rounding of price / box
+func (pf *PointAndFigureOf[T]) floor(price T) T {
+	return T(math.Floor(float64(price/pf.box)+1e-9)) * pf.box
+}
+
+// ceil returns the lowest box level at or above price, tolerating the rounding of price / box
+func (pf *PointAndFigureOf[T]) ceil(price T) T {
+	return T(math.Ceil(float64(price/pf.box)-1e-9)) * pf.box
+}
+
+// Current returns the column in progress, if there is one
+func (pf *PointAndFigureOf[T]) Current() (BarOf[T], bool) {
+	return pf.current, pf.hasCurrent
+}
+
+// Reset resets the transformer to a clean state
+func (pf *PointAndFigureOf[T]) Reset() {
+	pf.current = BarOf[T]{}
+	pf.hasCurrent = false
+	pf.direction = 0
+}
+
+// Clone returns a copy of the transformer that can be advanced independently
+func (pf *PointAndFigureOf[T]) Clone() *PointAndFigureOf[T] {
+	clone := *pf
+	clone.out = nil
+	return &clone
+}
+
+func (pf *PointAndFigureOf[T]) String() string {
+	return fmt.Sprintf("PnF(%g,%d)", float64(pf.box), pf.reversal)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPointAndFigure(t *testing.T) {
+	tests := map[string]struct {
+		box      float64
+		reversal int
+		wantErr  error
+	}{
+		"zero box":      {box: 0., reversal: 3, wantErr: ErrInvalidParameters},
+		"zero reversal": {box: 1., reversal: 0, wantErr: ErrInvalidParameters},
+		"valid":         {box: 1., reversal: 3, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewPointAndFigure(tc.box, tc.reversal)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.box, got.box, "must return the correct value")
+			assert.Equal(t, tc.reversal, got.reversal, "must return the correct value")
+		})
+	}
+}
+
+func TestPointAndFigureNext(t *testing.T) {
+	pf, _ := NewPointAndFigure(1., 3)
+	bars := priceBars(10.3, 10.8, 11.5, 13.2, 11.1, 9.9, 10.5, 13.4)
+	want := [][]Bar{
+		{},
+		{},
+		{},
+		{},
+		{},
+		{{Time: at(0), Open: 10., High: 13., Low: 10., Close: 13., Volume: 50.}},
+		{},
+		{{Time: at(5), Open: 12., High: 12., Low: 10., Close: 10., Volume: 20.}},
+	}
+	for i, bar := range bars {
+		got := pf.Next(bar)
+		diff := cmp.Diff(want[i], got, approxComparer, cmpopts.EquateEmpty())
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+
+	wantCurrent := Bar{Time: at(7), Open: 11., High: 13., Low: 11., Close: 13., Volume: 10.}
+	got, ok := pf.Current()
+	assert.True(t, ok)
+	if diff := cmp.Diff(wantCurrent, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestPointAndFigureBoxRounding(t *testing.T) {
+	pf, _ := NewPointAndFigure(0.1, 3)
+	TransformBars[float64](pf, priceBars(1., 1.3), nil)
+
+	got, _ := pf.Current()
+	assert.InDelta(t, 1.3, got.Close, 1e-9, "must not lose a box to rounding")
+}
+
+func TestPointAndFigureClone(t *testing.T) {
+	bars := priceBars(10.3, 10.8, 11.5, 13.2, 11.1, 9.9, 10.5, 13.4)
+	pf, _ := NewPointAndFigure(1., 3)
+	TransformBars[float64](pf, bars[:4], nil)
+	clone := pf.Clone()
+
+	want := TransformBars[float64](pf, bars[4:], nil)
+	got := TransformBars[float64](clone, bars[4:], nil)
+	assert.Equal(t, want, got, "clone must continue from the same state")
+}
+
+func TestPointAndFigureReset(t *testing.T) {
+	pf, _ := NewPointAndFigure(1., 3)
+	TransformBars[float64](pf, priceBars(10.3, 10.8, 11.5, 13.2, 11.1, 9.9), nil)
+	pf.Reset()
+	pf.out = nil
+
+	want, _ := NewPointAndFigure(1., 3)
+	assert.Equal(t, want, pf, "must return to a clean state")
+}
+
+func TestPointAndFigureString(t *testing.T) {
+	pf, _ := NewPointAndFigure(0.5, 3)
+	assert.Equal(t, "PnF(0.5,3)", pf.String())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+RSquaredOf returns the coefficient of determination (R²) of the last n pairs of values, the share
+of the variance of one series explained by a linear regression on the other. It is updated in
+constant time as pairs enter and leave the window.
+
+# Formula
+
+R<sup>2</sup> = ρ(x, y)<sup>2</sup>
+
+Where:
+
+* _ρ(x, y)_ - Pearson correlation of the two series, see PearsonCorrelation
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+r2, _ := NewRSquared(20)
+r2.Next(asset, benchmark)
+```
+*/
+type RSquaredOf[T Float] struct {
+	pairWindow[T]
+}
+
+// RSquared is an RSquaredOf float64 values
+type RSquared = RSquaredOf[float64]
+
+// NewRSquared creates a new RSquared with the given number of periods
+// Example: NewRSquared(20)
+func NewRSquared(n int) (*RSquared, error) {
+	return NewRSquaredOf[float64](n)
+}
+
+// NewRSquaredOf creates a new RSquaredOf values of type T with the given number of periods
+// Example: NewRSquaredOf[float32](20)
+func NewRSquaredOf[T Float](n int) (*RSquaredOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RSquaredOf[T]{
+		pairWindow: newPairWindow[T](n),
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next RSquared value
+func (r2 *RSquaredOf[T]) Next(x, y T) T {
+	r2.push(x, y)
+	return r2.moments.rSquared()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (r2 *RSquaredOf[T]) Peek(x, y T) T {
+	moments := r2.peek(x, y)
+	return moments.rSquared()
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated RSquared value.
+// If no pair has been committed yet it behaves like Next.
+func (r2 *RSquaredOf[T]) UpdateLast(x, y T) T {
+	r2.replaceLast(x, y)
+	return r2.moments.rSquared()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (r2 *RSquaredOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(r2.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (r2 *RSquaredOf[T]) Reset() {
+	r2.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (r2 *RSquaredOf[T]) Clone() *RSquaredOf[T] {
+	return &RSquaredOf[T]{
+		pairWindow: r2.clone(),
+	}
+}
+
+func (r2 *RSquaredOf[T]) String() string {
+	return fmt.Sprintf("R2(%d)", r2.n)
+}
+
+// ComputeRSquared computes an RSquared with n periods over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRSquared[T Float](n int, xs, ys, out []T, fillNaN bool) ([]T, error) {
+	r2, err := NewRSquaredOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = r2.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
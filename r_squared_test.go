@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRSquared(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *RSquared
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &RSquared{pairWindow: newPairWindow[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewRSquared(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestRSquaredNext(t *testing.T) {
+	r2, _ := NewRSquared(4)
+	tests := []struct {
+		x    float64
+		y    float64
+		want float64
+	}{
+		{x: 2., y: 1., want: 0.},
+		{x: 8., y: 5., want: 1.},
+		{x: 0., y: 2., want: 0.782544},
+		{x: 4., y: 6., want: 0.485714},
+		{x: 1., y: 3., want: 0.580645},
+		{x: 9., y: 8., want: 0.947522},
+		{x: 9., y: 7., want: 0.880061},
+		{x: 0., y: 1., want: 0.956979},
+		{x: 3., y: 4., want: 0.967901},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := r2.Next(tc.x, tc.y)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeRSquared(t *testing.T) {
+	xs := []float64{2., 8., 0., 4., 1.}
+	ys := []float64{1., 5., 2., 6., 3.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 1., 0.782544, 0.485714, 0.580645}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 0.485714, 0.580645}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeRSquared(tc.n, xs, ys, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRSquaredWindows(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		xs   []float64
+		ys   []float64
+		want float64
+	}{
+		"constant x": {n: 4, xs: []float64{3., 3., 3., 3.}, ys: []float64{1., 5., 2., 6.}, want: 0.},
+		"increasing": {n: 3, xs: []float64{1., 2., 4.}, ys: []float64{3., 5., 9.}, want: 1.},
+		"decreasing": {n: 3, xs: []float64{1., 2., 4.}, ys: []float64{9., 5., -3.}, want: 1.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewRSquared(tc.n)
+			got := sd.Compute(tc.xs, tc.ys, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRSquaredOfFloat32LongStream(t *testing.T) {
+	sd, _ := NewRSquaredOf[float32](10)
+	fresh, _ := NewRSquared(10)
+	testPairLongStream(t, sd, fresh, 10, 1e-2)
+}
+
+func TestRSquaredString(t *testing.T) {
+	sd, _ := NewRSquared(4)
+	want := "R2(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
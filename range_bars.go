@@ -0,0 +1,155 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+)
+
+/*
+RangeBarsOf turns bars into range bars, which all span the same price range whatever the time they take.
+A range bar is completed when the price moves beyond its range, closing at the edge of the range, and the
+next range bar opens there, so a single bar can complete none or several range bars. Passing ticks as bars,
+see Trade.Bar, builds the range bars from every trade.
+
+The path of the price within a bar is unknown, it is taken to be open, low, high, close for a rising bar and
+open, high, low, close otherwise. Each range bar has the time of the bar that opened it, and the volume of a bar
+is added to the range bar in progress when it arrives.
+
+Bars with a non-finite price are skipped. A single bar completes at most 1000 range bars, so that an outlier
+cannot build an unbounded number of them: the rest of its path is dropped and the range bar in progress stays
+at the edge of the last one.
+
+# Parameters
+
+* _size_ - range of a bar (greater than 0)
+
+# Example
+```
+rb, _ := NewRangeBars(1.)
+bars := rb.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type RangeBarsOf[T Float] struct {
+	// range of a bar (must be greater than 0)
+	size T
+
+	// range bar in progress
+	current    BarOf[T]
+	hasCurrent bool
+
+	out []BarOf[T]
+}
+
+// RangeBars is a RangeBarsOf float64 values
+type RangeBars = RangeBarsOf[float64]
+
+// NewRangeBars creates a new RangeBars with the given range
+// Example: NewRangeBars(1.)
+func NewRangeBars(size float64) (*RangeBars, error) {
+	return NewRangeBarsOf(size)
+}
+
+// NewRangeBarsOf creates a new RangeBarsOf values of type T with the given range
+// Example: NewRangeBarsOf[float32](1.)
+func NewRangeBarsOf[T Float](size T) (*RangeBarsOf[T], error) {
+	if !(size > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RangeBarsOf[T]{
+		size: size,
+	}, nil
+}
+
+// Next takes the next bar and returns the range bars it completes. The returned slice is reused by the next call.
+func (rb *RangeBarsOf[T]) Next(bar BarOf[T]) []BarOf[T] {
+	rb.out = rb.out[:0]
+	if !finiteBar(bar) {
+		return rb.out
+	}
+	if !rb.hasCurrent {
+		rb.open(bar, bar.Open)
+	}
+	rb.current.Volume += bar.Volume
+
+	first, second := bar.High, bar.Low
+	if bar.Close > bar.Open {
+		first, second = bar.Low, bar.High
+	}
+	for _, price := range []T{bar.Open, first, second, bar.Close} {
+		if !rb.move(bar, price) {
+			break
+		}
+	}
+	return rb.out
+}
+
+// move moves the price of the range bar in progress to price, completing range bars on the way. It returns
+// false, leaving the range bar in progress at the edge of the last one, once maxBricks range bars are completed.
+func (rb *RangeBarsOf[T]) move(bar BarOf[T], price T) bool {
+	for price > rb.current.Low+rb.size {
+		if len(rb.out) == maxBricks {
+			return false
+		}
+		top := rb.current.Low + rb.size
+		rb.current.High, rb.current.Close = top, top
+		rb.out = append(rb.out, rb.current)
+		rb.open(bar, top)
+	}
+	for price < rb.current.High-rb.size {
+		if len(rb.out) == maxBricks {
+			return false
+		}
+		bottom := rb.current.High - rb.size
+		rb.current.Low, rb.current.Close = bottom, bottom
+		rb.out = append(rb.out, rb.current)
+		rb.open(bar, bottom)
+	}
+
+	if price > rb.current.High {
+		rb.current.High = price
+	}
+	if price < rb.current.Low {
+		rb.current.Low = price
+	}
+	rb.current.Close = price
+	return true
+}
+
+// open starts a range bar at price
+func (rb *RangeBarsOf[T]) open(bar BarOf[T], price T) {
+	rb.current = BarOf[T]{
+		Time:  bar.Time,
+		Open:  price,
+		High:  price,
+		Low:   price,
+		Close: price,
+	}
+	rb.hasCurrent = true
+}
+
+// Current returns the range bar in progress, if there is one
+func (rb *RangeBarsOf[T]) Current() (BarOf[T], bool) {
+	return rb.current, rb.hasCurrent
+}
+
+// Reset resets the transformer to a clean state
+func (rb *RangeBarsOf[T]) Reset() {
+	rb.current = BarOf[T]{}
+	rb.hasCurrent = false
+}
+
+// Clone returns a copy of the transformer that can be advanced independently
+func (rb *RangeBarsOf[T]) Clone() *RangeBarsOf[T] {
+	clone := *rb
+	clone.out = nil
+	return &clone
+}
+
+func (rb *RangeBarsOf[T]) String() string {
+	return fmt.Sprintf("RangeBars(%g)", float64(rb.size))
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRangeBars(t *testing.T) {
+	tests := map[string]struct {
+		input   float64
+		wantErr error
+	}{
+		"negative size": {input: -1., wantErr: ErrInvalidParameters},
+		"NaN size":      {input: math.NaN(), wantErr: ErrInvalidParameters},
+		"positive size": {input: 1., wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewRangeBars(tc.input)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.input, got.size, "must return the correct value")
+		})
+	}
+}
+
+func TestRangeBarsNext(t *testing.T) {
+	rb, _ := NewRangeBars(1.)
+	tests := []struct {
+		input Bar
+		want  []Bar
+	}{
+		{
+			input: Bar{Time: at(0), Open: 10., High: 10.4, Low: 9.8, Close: 10.2, Volume: 10.},
+			want:  []Bar{},
+		},
+		{
+			input: Bar{Time: at(60), Open: 10.2, High: 11.3, Low: 10.1, Close: 11.1, Volume: 10.},
+			want:  []Bar{{Time: at(0), Open: 10., High: 10.8, Low: 9.8, Close: 10.8, Volume: 20.}},
+		},
+		{
+			input: Bar{Time: at(120), Open: 11.1, High: 11.2, Low: 9.5, Close: 9.7, Volume: 10.},
+			want:  []Bar{{Time: at(60), Open: 10.8, High: 11.3, Low: 10.3, Close: 10.3, Volume: 10.}},
+		},
+		{
+			input: Bar{Time: at(180), Open: 9.7, High: 12.4, Low: 9.6, Close: 12.2, Volume: 10.},
+			want: []Bar{
+				{Time: at(120), Open: 10.3, High: 10.5, Low: 9.5, Close: 10.5, Volume: 10.},
+				{Time: at(180), Open: 10.5, High: 11.5, Low: 10.5, Close: 11.5},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := rb.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer, cmpopts.EquateEmpty())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+
+	want := Bar{Time: at(180), Open: 11.5, High: 12.4, Low: 11.5, Close: 12.2}
+	got, ok := rb.Current()
+	assert.True(t, ok)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRangeBarsTicks(t *testing.T) {
+	rb, _ := NewRangeBars(1.)
+	want := []Bar{
+		{Time: at(0), Open: 10., High: 11., Low: 10., Close: 11., Volume: 20.},
+		{Time: at(1), Open: 11., High: 11.5, Low: 10.5, Close: 10.5, Volume: 20.},
+	}
+	got := TransformBars[float64](rb, priceBars(10., 11.2, 11.5, 10.2), nil)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRangeBarsNonFinite(t *testing.T) {
+	tests := map[string]Bar{
+		"infinite high":  {Time: at(0.5), Open: 10.5, High: math.Inf(1), Low: 10.5, Close: 10.5, Volume: 10.},
+		"infinite low":   {Time: at(0.5), Open: 10.5, High: 10.5, Low: math.Inf(-1), Close: 10.5, Volume: 10.},
+		"NaN close":      {Time: at(0.5), Open: 10.5, High: 10.5, Low: 10.5, Close: math.NaN(), Volume: 10.},
+		"infinite open":  {Time: at(0.5), Open: math.Inf(-1), High: 10.5, Low: 10.5, Close: 10.5, Volume: 10.},
+		"infinite range": {Time: at(0.5), Open: 10.5, High: math.Inf(1), Low: math.Inf(-1), Close: 10.5, Volume: 10.},
+	}
+	want := []Bar{
+		{Time: at(0), Open: 10., High: 11., Low: 10., Close: 11., Volume: 20.},
+		{Time: at(1), Open: 11., High: 11.5, Low: 10.5, Close: 10.5, Volume: 20.},
+	}
+
+	for name, bad := range tests {
+		t.Run(name, func(t *testing.T) {
+			rb, _ := NewRangeBars(1.)
+			bars := priceBars(10., 11.2, 11.5, 10.2)
+			bars = append([]Bar{bars[0], bad}, bars[1:]...)
+			got := TransformBars[float64](rb, bars, nil)
+			if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRangeBarsOutlier(t *testing.T) {
+	rb, _ := NewRangeBars(0.01)
+	bars := priceBars(100., 1e6, 90.)
+	rb.Next(bars[0])
+
+	got := rb.Next(bars[1])
+	assert.Len(t, got, maxBricks, "must cap the range bars of a single bar")
+	assert.InDelta(t, 110., got[len(got)-1].Close, 1e-6)
+	current, _ := rb.Current()
+	assert.InDelta(t, 110., current.Close, 1e-6, "must leave the range bar in progress at the edge of the last one")
+
+	got = rb.Next(bars[2])
+	assert.Len(t, got, maxBricks, "must cap the range bars of a falling bar")
+	assert.InDelta(t, 100., got[len(got)-1].Close, 1e-6)
+}
+
+func TestRangeBarsClone(t *testing.T) {
+	bars := priceBars(10., 11.2, 11.5, 10.2, 9.1, 12.4)
+	rb, _ := NewRangeBars(1.)
+	TransformBars[float64](rb, bars[:2], nil)
+	clone := rb.Clone()
+
+	want := TransformBars[float64](rb, bars[2:], nil)
+	got := TransformBars[float64](clone, bars[2:], nil)
+	assert.Equal(t, want, got, "clone must continue from the same state")
+}
+
+func TestRangeBarsReset(t *testing.T) {
+	rb, _ := NewRangeBars(1.)
+	TransformBars[float64](rb, priceBars(10., 11.2, 11.5, 10.2), nil)
+	rb.Reset()
+	rb.out = nil
+
+	want, _ := NewRangeBars(1.)
+	assert.Equal(t, want, rb, "must return to a clean state")
+}
+
+func TestRangeBarsString(t *testing.T) {
+	rb, _ := NewRangeBars(0.25)
+	assert.Equal(t, "RangeBars(0.25)", rb.String())
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+RateOfChangeOf returns the change of the input over _n_ periods, as a percentage of the input
+_n_ periods before.
+
+# Formula
+
+ROC<sub>t</sub> = 100 · (p<sub>t</sub> - p<sub>t-n</sub>) / p<sub>t-n</sub>
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+
+Until _n_ inputs have preceded the current one, the first input stands in for p<sub>t-n</sub>.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+roc, _ := NewRateOfChange(1)
+roc.Next(10.)
+```
+*/
+type RateOfChangeOf[T Float] struct {
+	lookback[T]
+}
+
+// RateOfChange is a RateOfChangeOf float64 values
+type RateOfChange = RateOfChangeOf[float64]
+
+// NewRateOfChange creates a new RateOfChange with the given number of periods
+// Example: NewRateOfChange(1)
+func NewRateOfChange(n int) (*RateOfChange, error) {
+	return NewRateOfChangeOf[float64](n)
+}
+
+// NewRateOfChangeOf creates a new RateOfChangeOf values of type T with the given number of periods
+// Example: NewRateOfChangeOf[float32](1)
+func NewRateOfChangeOf[T Float](n int) (*RateOfChangeOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RateOfChangeOf[T]{
+		lookback: newLookback[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next RateOfChange value
+func (roc *RateOfChangeOf[T]) Next(input T) T {
+	past := roc.push(input)
+	return 100 * (input - past) / past
+}
+
+// Peek returns the value Next would return for input without committing it
+func (roc *RateOfChangeOf[T]) Peek(input T) T {
+	past := roc.peek(input)
+	return 100 * (input - past) / past
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated RateOfChange value.
+// If no input has been committed yet it behaves like Next.
+func (roc *RateOfChangeOf[T]) UpdateLast(input T) T {
+	past := roc.replaceLast(input)
+	return 100 * (input - past) / past
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (roc *RateOfChangeOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = roc.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (roc *RateOfChangeOf[T]) Reset() {
+	roc.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (roc *RateOfChangeOf[T]) Clone() *RateOfChangeOf[T] {
+	return &RateOfChangeOf[T]{
+		lookback: roc.clone(),
+	}
+}
+
+func (roc *RateOfChangeOf[T]) String() string {
+	return fmt.Sprintf("ROC(%d)", roc.n)
+}
+
+// ComputeRateOfChange computes a RateOfChange with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n results, produced before n inputs precede the current one, are set to NaN.
+func ComputeRateOfChange[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	roc, err := NewRateOfChangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = roc.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n)
+	}
+	return out, nil
+}
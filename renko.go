@@ -0,0 +1,170 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+RenkoOf turns bars into Renko bricks of a fixed box size, or of the AverageTrueRange of the bars. A brick is
+emitted each time the close moves a whole box beyond the last brick, in the same direction, or two boxes in the
+other direction, so a single bar can emit none or several bricks. Passing ticks as bars, see Trade.Bar, builds
+the bricks from every trade.
+
+Bricks are measured from the close of the first bar. Each brick has the time of the bar that completed it, and
+the first brick completed by a bar carries the volume traded since the previous brick.
+With an ATR-sized box no brick is emitted until the AverageTrueRange is seeded, then each bar uses its current
+value as the box size.
+
+Bars with a non-finite close, or with an ATR-sized box a non-finite price, are skipped. A single bar completes at
+most 1000 bricks, so that an outlier cannot build an unbounded number of them: the rest of its move is completed
+by the next bars only if their close stays beyond the last brick.
+
+# Parameters
+
+* _box_ - size of a brick (greater than 0)
+* _n_ - number of periods of the AverageTrueRange (integer greater than 0)
+
+# Example
+```
+renko, _ := NewRenko(0.5)
+bricks := renko.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type RenkoOf[T Float] struct {
+	// size of a brick, or the AverageTrueRange sizing it
+	box T
+	atr *AverageTrueRangeOf[T]
+
+	// internal parameters for calculations
+	top, bottom T
+	started     bool
+	volume      T
+
+	out []BarOf[T]
+}
+
+// Renko is a RenkoOf float64 values
+type Renko = RenkoOf[float64]
+
+// NewRenko creates a new Renko with the given box size
+// Example: NewRenko(0.5)
+func NewRenko(box float64) (*Renko, error) {
+	return NewRenkoOf(box)
+}
+
+// NewRenkoOf creates a new RenkoOf values of type T with the given box size
+// Example: NewRenkoOf[float32](0.5)
+func NewRenkoOf[T Float](box T) (*RenkoOf[T], error) {
+	if !(box > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RenkoOf[T]{
+		box: box,
+	}, nil
+}
+
+// NewATRRenko creates a new Renko with a box size of the AverageTrueRange of n periods
+// Example: NewATRRenko(14)
+func NewATRRenko(n int) (*Renko, error) {
+	return NewATRRenkoOf[float64](n)
+}
+
+// NewATRRenkoOf creates a new RenkoOf values of type T with a box size of the AverageTrueRange of n periods
+// Example: NewATRRenkoOf[float32](14)
+func NewATRRenkoOf[T Float](n int) (*RenkoOf[T], error) {
+	atr, err := NewAverageTrueRangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenkoOf[T]{
+		atr: atr,
+	}, nil
+}
+
+// Next takes the next bar and returns the bricks it completes. The returned slice is reused by the next call.
+func (r *RenkoOf[T]) Next(bar BarOf[T]) []BarOf[T] {
+	r.out = r.out[:0]
+	if !isFinite(bar.Close) || r.atr != nil && !finiteBar(bar) {
+		return r.out
+	}
+	r.volume += bar.Volume
+
+	box := r.box
+	if r.atr != nil {
+		box = r.atr.Next(bar)
+		if r.atr.count < r.atr.n {
+			box = 0
+		}
+	}
+
+	if !r.started {
+		r.top, r.bottom = bar.Close, bar.Close
+		r.started = true
+	}
+	if !(box > 0) || math.IsInf(float64(box), 1) {
+		return r.out
+	}
+
+	for len(r.out) < maxBricks && bar.Close >= r.top+box {
+		r.brick(bar, r.top, r.top+box)
+		r.top, r.bottom = r.top+box, r.top
+	}
+	for len(r.out) < maxBricks && bar.Close <= r.bottom-box {
+		r.brick(bar, r.bottom, r.bottom-box)
+		r.top, r.bottom = r.bottom, r.bottom-box
+	}
+	return r.out
+}
+
+// brick emits a brick from open to close completed by bar
+func (r *RenkoOf[T]) brick(bar BarOf[T], open, close T) {
+	high, low := close, open
+	if open > close {
+		high, low = open, close
+	}
+
+	r.out = append(r.out, BarOf[T]{
+		Time:   bar.Time,
+		Open:   open,
+		High:   high,
+		Low:    low,
+		Close:  close,
+		Volume: r.volume,
+	})
+	r.volume = 0
+}
+
+// Reset resets the transformer to a clean state
+func (r *RenkoOf[T]) Reset() {
+	if r.atr != nil {
+		r.atr.Reset()
+	}
+	r.top, r.bottom = 0, 0
+	r.started = false
+	r.volume = 0
+}
+
+// Clone returns a deep copy of the transformer, including its AverageTrueRange
+func (r *RenkoOf[T]) Clone() *RenkoOf[T] {
+	clone := *r
+	if r.atr != nil {
+		clone.atr = r.atr.Clone()
+	}
+	clone.out = nil
+	return &clone
+}
+
+func (r *RenkoOf[T]) String() string {
+	if r.atr != nil {
+		return fmt.Sprintf("Renko(%s)", r.atr)
+	}
+	return fmt.Sprintf("Renko(%g)", float64(r.box))
+}
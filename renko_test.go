@@ -0,0 +1,201 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickBars returns a bar of every price, a second apart and of volume 10
+func priceBars(prices ...float64) []Bar {
+	bars := make([]Bar, len(prices))
+	for i, price := range prices {
+		bars[i] = Trade{Time: at(float64(i)), Price: price, Size: 10.}.Bar()
+	}
+	return bars
+}
+
+func TestNewRenko(t *testing.T) {
+	tests := map[string]struct {
+		new     func() (*Renko, error)
+		wantErr error
+	}{
+		"zero box":     {new: func() (*Renko, error) { return NewRenko(0.) }, wantErr: ErrInvalidParameters},
+		"NaN box":      {new: func() (*Renko, error) { return NewRenko(math.NaN()) }, wantErr: ErrInvalidParameters},
+		"zero n":       {new: func() (*Renko, error) { return NewATRRenko(0) }, wantErr: ErrInvalidParameters},
+		"positive box": {new: func() (*Renko, error) { return NewRenko(0.5) }, wantErr: nil},
+		"positive n":   {new: func() (*Renko, error) { return NewATRRenko(14) }, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := tc.new()
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestRenkoNext(t *testing.T) {
+	r, _ := NewRenko(1.)
+	tests := []struct {
+		input Bar
+		want  []Bar
+	}{
+		{input: priceBars(10.)[0], want: []Bar{}},
+		{input: Trade{Time: at(1), Price: 10.4, Size: 10.}.Bar(), want: []Bar{}},
+		{
+			input: Trade{Time: at(2), Price: 11.2, Size: 10.}.Bar(),
+			want:  []Bar{{Time: at(2), Open: 10., High: 11., Low: 10., Close: 11., Volume: 30.}},
+		},
+		{
+			input: Trade{Time: at(3), Price: 13.1, Size: 10.}.Bar(),
+			want: []Bar{
+				{Time: at(3), Open: 11., High: 12., Low: 11., Close: 12., Volume: 10.},
+				{Time: at(3), Open: 12., High: 13., Low: 12., Close: 13.},
+			},
+		},
+		{input: Trade{Time: at(4), Price: 12.5, Size: 10.}.Bar(), want: []Bar{}},
+		{
+			input: Trade{Time: at(5), Price: 10.9, Size: 10.}.Bar(),
+			want:  []Bar{{Time: at(5), Open: 12., High: 12., Low: 11., Close: 11., Volume: 20.}},
+		},
+		{input: Trade{Time: at(6), Price: 11.5, Size: 10.}.Bar(), want: []Bar{}},
+		{
+			input: Trade{Time: at(7), Price: 9.8, Size: 10.}.Bar(),
+			want:  []Bar{{Time: at(7), Open: 11., High: 11., Low: 10., Close: 10., Volume: 20.}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := r.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer, cmpopts.EquateEmpty())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRenkoATR(t *testing.T) {
+	r, _ := NewATRRenko(2)
+	bars := []Bar{
+		{Time: at(0), Open: 10., High: 10.5, Low: 9.5, Close: 10., Volume: 10.},
+		{Time: at(1), Open: 10., High: 10.7, Low: 9.7, Close: 10.2, Volume: 10.},
+		{Time: at(2), Open: 10.2, High: 11.6, Low: 10.6, Close: 11.1, Volume: 10.},
+		{Time: at(3), Open: 11.1, High: 11.8, Low: 10.8, Close: 11.3, Volume: 10.},
+	}
+
+	// box sizes of 1, 1.2 and 1.1 once the ATR is seeded on the second bar
+	want := []Bar{{Time: at(3), Open: 10., High: 11.1, Low: 10., Close: 11.1, Volume: 40.}}
+	got := TransformBars[float64](r, bars, nil)
+	if diff := cmp.Diff(want, got, approxComparer); diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRenkoNonFinite(t *testing.T) {
+	atrBars := []Bar{
+		{Time: at(0), Open: 10., High: 10.5, Low: 9.5, Close: 10., Volume: 10.},
+		{Time: at(1), Open: 10., High: 10.7, Low: 9.7, Close: 10.2, Volume: 10.},
+		{Time: at(1.5), Open: 10.2, High: math.Inf(1), Low: 10.2, Close: 10.2, Volume: 10.},
+		{Time: at(2), Open: 10.2, High: 11.6, Low: 10.6, Close: 11.1, Volume: 10.},
+		{Time: at(3), Open: 11.1, High: 11.8, Low: 10.8, Close: 11.3, Volume: 10.},
+	}
+	tests := map[string]struct {
+		newRenko func() (*Renko, error)
+		bars     []Bar
+		want     []Bar
+	}{
+		"infinite close": {
+			newRenko: func() (*Renko, error) { return NewRenko(1.) },
+			bars:     priceBars(10., math.Inf(1), 11.2),
+			want:     []Bar{{Time: at(2), Open: 10., High: 11., Low: 10., Close: 11., Volume: 20.}},
+		},
+		"negative infinite close": {
+			newRenko: func() (*Renko, error) { return NewRenko(1.) },
+			bars:     priceBars(10., math.Inf(-1), 8.9),
+			want:     []Bar{{Time: at(2), Open: 10., High: 10., Low: 9., Close: 9., Volume: 20.}},
+		},
+		"NaN close": {
+			newRenko: func() (*Renko, error) { return NewRenko(1.) },
+			bars:     priceBars(math.NaN(), 10., math.NaN(), 11.2),
+			want:     []Bar{{Time: at(3), Open: 10., High: 11., Low: 10., Close: 11., Volume: 20.}},
+		},
+		"infinite ATR": {
+			newRenko: func() (*Renko, error) { return NewATRRenko(2) },
+			bars:     atrBars,
+			want:     []Bar{{Time: at(3), Open: 10., High: 11.1, Low: 10., Close: 11.1, Volume: 40.}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, _ := tc.newRenko()
+			got := TransformBars[float64](r, tc.bars, nil)
+			if diff := cmp.Diff(tc.want, got, approxComparer); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRenkoOutlier(t *testing.T) {
+	r, _ := NewRenko(0.01)
+	bars := priceBars(100., 1e6, 1e6, 100.)
+	r.Next(bars[0])
+
+	got := r.Next(bars[1])
+	assert.Len(t, got, maxBricks, "must cap the bricks of a single bar")
+	assert.InDelta(t, 110., got[len(got)-1].Close, 1e-6)
+
+	got = r.Next(bars[2])
+	assert.Len(t, got, maxBricks, "must carry on while the close stays beyond the last brick")
+	assert.InDelta(t, 120., got[len(got)-1].Close, 1e-6)
+
+	got = r.Next(bars[3])
+	assert.Len(t, got, maxBricks)
+	assert.InDelta(t, 109.99, got[len(got)-1].Close, 1e-6, "must reverse from the last brick")
+}
+
+func TestRenkoClone(t *testing.T) {
+	bars := priceBars(10., 10.4, 11.2, 13.1, 12.5, 10.9, 11.5, 9.8)
+	r, _ := NewATRRenko(2)
+	TransformBars[float64](r, bars[:3], nil)
+	clone := r.Clone()
+
+	want := TransformBars[float64](r, bars[3:], nil)
+	got := TransformBars[float64](clone, bars[3:], nil)
+	assert.Equal(t, want, got, "clone must continue from the same state")
+	assert.NotSame(t, r.atr, clone.atr, "clone must not share the AverageTrueRange")
+}
+
+func TestRenkoReset(t *testing.T) {
+	r, _ := NewATRRenko(2)
+	TransformBars[float64](r, priceBars(10., 10.4, 11.2, 13.1), nil)
+	r.Reset()
+	r.out = nil
+
+	want, _ := NewATRRenko(2)
+	assert.Equal(t, want, r, "must return to a clean state")
+}
+
+func TestRenkoString(t *testing.T) {
+	fixed, _ := NewRenko(0.5)
+	atr, _ := NewATRRenko(14)
+	assert.Equal(t, "Renko(0.5)", fixed.String())
+	assert.Equal(t, "Renko(ATR(14))", atr.String())
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+RogersSatchellOf returns the Rogers-Satchell volatility of the last _n_ bars, estimated from their open,
+high, low and close prices. Unlike Parkinson and GarmanKlass it stays unbiased when prices drift,
+but it still ignores opening jumps.
+
+# Formula
+
+σ<sup>2</sup> = Σ (ln(H<sub>i</sub> / C<sub>i</sub>)·ln(H<sub>i</sub> / O<sub>i</sub>) + ln(L<sub>i</sub> / C<sub>i</sub>)·ln(L<sub>i</sub> / O<sub>i</sub>)) / N
+
+Where:
+
+* _O<sub>i</sub>_, _H<sub>i</sub>_, _L<sub>i</sub>_, _C<sub>i</sub>_ - open, high, low and close of the bar
+* _N_ - number of bars in observation.
+
+The result is the volatility of a single period, see HistoricalVolatility to annualise it.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+rs, _ := NewRogersSatchell(20)
+rs.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type RogersSatchellOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	ma *MovingAverageOf[T]
+}
+
+// RogersSatchell is a RogersSatchellOf float64 values
+type RogersSatchell = RogersSatchellOf[float64]
+
+// NewRogersSatchell creates a new RogersSatchell with the given number of periods
+// Example: NewRogersSatchell(20)
+func NewRogersSatchell(n int) (*RogersSatchell, error) {
+	return NewRogersSatchellOf[float64](n)
+}
+
+// NewRogersSatchellOf creates a new RogersSatchellOf values of type T with the given number of periods
+// Example: NewRogersSatchellOf[float32](20)
+func NewRogersSatchellOf[T Float](n int) (*RogersSatchellOf[T], error) {
+	ma, err := NewMovingAverageOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RogersSatchellOf[T]{
+		n: n,
+
+		ma: ma,
+	}, nil
+}
+
+// Next takes the next bar and returns the next RogersSatchell value
+func (rs *RogersSatchellOf[T]) Next(bar BarOf[T]) T {
+	return volatility(rs.ma.Next(rogersSatchellTerm(bar)))
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (rs *RogersSatchellOf[T]) Peek(bar BarOf[T]) T {
+	return volatility(rs.ma.Peek(rogersSatchellTerm(bar)))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated RogersSatchell value. If no bar has been committed yet it behaves like Next.
+func (rs *RogersSatchellOf[T]) UpdateLast(bar BarOf[T]) T {
+	return volatility(rs.ma.UpdateLast(rogersSatchellTerm(bar)))
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (rs *RogersSatchellOf[T]) Compute(bars []BarOf[T], out []T) []T {
+	return computeBars(rs.Next, bars, out)
+}
+
+// Reset resets the indicators to a clean state
+func (rs *RogersSatchellOf[T]) Reset() {
+	rs.ma.Reset()
+}
+
+// Clone returns a deep copy of the indicator, including its MovingAverage
+func (rs *RogersSatchellOf[T]) Clone() *RogersSatchellOf[T] {
+	clone := *rs
+	clone.ma = rs.ma.Clone()
+	return &clone
+}
+
+func (rs *RogersSatchellOf[T]) String() string {
+	return fmt.Sprintf("RogersSatchell(%d)", rs.n)
+}
+
+// ComputeRogersSatchell computes a RogersSatchell with n periods over bars and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRogersSatchell[T Float](n int, bars []BarOf[T], out []T, fillNaN bool) ([]T, error) {
+	rs, err := NewRogersSatchellOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = rs.Compute(bars, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// rogersSatchellTerm returns the contribution of bar to the Rogers-Satchell variance
+func rogersSatchellTerm[T Float](bar BarOf[T]) T {
+	return logRatio(bar.High, bar.Close)*logRatio(bar.High, bar.Open) +
+		logRatio(bar.Low, bar.Close)*logRatio(bar.Low, bar.Open)
+}
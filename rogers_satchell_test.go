@@ -0,0 +1,134 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRogersSatchell(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		wantErr error
+	}{
+		"negative n": {input: -3, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewRogersSatchell(tc.input)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.input, got.n, "must return the correct value")
+		})
+	}
+}
+
+func TestRogersSatchellNext(t *testing.T) {
+	rs, _ := NewRogersSatchell(4)
+	tests := []struct {
+		input Bar
+		want  float64
+	}{
+		{input: Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5}, want: 0.0978133},
+		{input: Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.}, want: 0.0856855},
+		{input: Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6}, want: 0.0779952},
+		{input: Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9}, want: 0.0750798},
+		{input: Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3}, want: 0.0599647},
+		{input: Bar{Open: 10.4, High: 11.6, Low: 10.2, Close: 11.4}, want: 0.057718},
+		{input: Bar{Open: 11.8, High: 12.3, Low: 11.5, Close: 12.1}, want: 0.0541982},
+		{input: Bar{Open: 12., High: 12.2, Low: 11., Close: 11.2}, want: 0.0510711},
+		{input: Bar{Open: 11.1, High: 11.9, Low: 10.9, Close: 11.7}, want: 0.0536139},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := rs.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeRogersSatchell(t *testing.T) {
+	bars := []Bar{
+		Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5},
+		Bar{Open: 10.6, High: 11.2, Low: 10.1, Close: 11.},
+		Bar{Open: 11.2, High: 11.5, Low: 10.4, Close: 10.6},
+		Bar{Open: 10.5, High: 10.9, Low: 9.8, Close: 9.9},
+		Bar{Open: 9.7, High: 10.4, Low: 9.6, Close: 10.3},
+	}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0.0978133, 0.0856855, 0.0779952, 0.0750798, 0.0599647}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 0.0750798, 0.0599647}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeRogersSatchell(tc.n, bars, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRogersSatchellBars(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		bars []Bar
+		want float64
+	}{
+		"flat bars": {n: 3, bars: []Bar{
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+			Bar{Open: 10., High: 10., Low: 10., Close: 10.},
+		}, want: 0.},
+		"pure drift": {n: 3, bars: []Bar{
+			Bar{Open: 10., High: 11., Low: 10., Close: 11.},
+			Bar{Open: 11., High: 12., Low: 11., Close: 12.},
+			Bar{Open: 12., High: 13., Low: 12., Close: 13.},
+		}, want: 0.},
+		"scaled prices": {n: 4, bars: []Bar{Bar{Open: 100., High: 110., Low: 95., Close: 105.}}, want: 0.0978133},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewRogersSatchell(tc.n)
+			got := sd.Compute(tc.bars, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRogersSatchellString(t *testing.T) {
+	rs, _ := NewRogersSatchell(4)
+	assert.Equal(t, "RogersSatchell(4)", rs.String())
+}
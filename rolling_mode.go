@@ -0,0 +1,136 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+RollingModeOf returns the most frequent of the last n values. It is meant for discretised inputs,
+such as prices rounded to their tick size or integer signals, where values repeat exactly.
+Equal values are adjacent in the sorted window, so the mode is its longest run.
+
+# Formula
+
+Mode = the value x<sub>i</sub> with the highest count(x<sub>j</sub> = x<sub>i</sub>)
+
+When several values are equally frequent the smallest one is returned. NaN values never count as equal.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+mode, _ := NewRollingMode(20)
+mode.Next(10.)
+```
+*/
+type RollingModeOf[T Float] struct {
+	sortedWindow[T]
+}
+
+// RollingMode is a RollingModeOf float64 values
+type RollingMode = RollingModeOf[float64]
+
+// NewRollingMode creates a new RollingMode with the given number of periods
+// Example: NewRollingMode(20)
+func NewRollingMode(n int) (*RollingMode, error) {
+	return NewRollingModeOf[float64](n)
+}
+
+// NewRollingModeOf creates a new RollingModeOf values of type T with the given number of periods
+// Example: NewRollingModeOf[float32](20)
+func NewRollingModeOf[T Float](n int) (*RollingModeOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RollingModeOf[T]{
+		sortedWindow: newSortedWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next RollingMode value
+func (m *RollingModeOf[T]) Next(input T) T {
+	m.push(input)
+	return mode(m.sorted)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *RollingModeOf[T]) Peek(input T) T {
+	return m.peek(input, mode[T])
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated RollingMode value.
+// If no input has been committed yet it behaves like Next.
+func (m *RollingModeOf[T]) UpdateLast(input T) T {
+	m.replaceLast(input)
+	return mode(m.sorted)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *RollingModeOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = m.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (m *RollingModeOf[T]) Reset() {
+	m.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *RollingModeOf[T]) Clone() *RollingModeOf[T] {
+	return &RollingModeOf[T]{
+		sortedWindow: m.clone(),
+	}
+}
+
+func (m *RollingModeOf[T]) String() string {
+	return fmt.Sprintf("Mode(%d)", m.n)
+}
+
+// ComputeRollingMode computes a RollingMode with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRollingMode[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	m, err := NewRollingModeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = m.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// mode returns the first value of the longest run of equal values in sorted
+func mode[T Float](sorted []T) T {
+	if len(sorted) == 0 {
+		return T(math.NaN())
+	}
+
+	best, bestCount := sorted[0], 1
+	for i, count := 1, 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			count++
+		} else {
+			count = 1
+		}
+		if count > bestCount {
+			best, bestCount = sorted[i], count
+		}
+	}
+	return best
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+RollingProductOf returns the product of the last _n_ inputs, such as the compounded growth of a
+series of gross returns (1 + r). The product is kept in log space, as the sum of the logarithms of
+the absolute values with separate counts of zero, negative and non-finite inputs, so long windows
+neither overflow nor underflow while they are updated, and it is recomputed from the window once every
+n updates so that rounding errors do not accumulate over long streams.
+
+# Formula
+
+Product<sub>t</sub> = p<sub>t</sub> · p<sub>t-1</sub> · ... · p<sub>t-n+1</sub> = ±exp(Σ ln|p<sub>i</sub>|)
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+* _n_ - number of periods (length)
+
+The sign is negative when the window holds an odd number of negative inputs. The product is NaN while
+the window holds a NaN, or both a zero and an infinity, and otherwise 0 while it holds a zero and
+infinite while it holds an infinity.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+growth, _ := NewRollingProduct(20)
+growth.Next(1.01)
+```
+*/
+type RollingProductOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index   int
+	count   int
+	product logProduct[T]
+	// number of updates since product was last recomputed from data
+	updates int
+
+	// slice of data needed for calculation
+	data []T
+}
+
+// RollingProduct is a RollingProductOf float64 values
+type RollingProduct = RollingProductOf[float64]
+
+// NewRollingProduct creates a new RollingProduct with the given number of periods
+// Example: NewRollingProduct(20)
+func NewRollingProduct(n int) (*RollingProduct, error) {
+	return NewRollingProductOf[float64](n)
+}
+
+// NewRollingProductOf creates a new RollingProductOf values of type T with the given number of periods
+// Example: NewRollingProductOf[float32](20)
+func NewRollingProductOf[T Float](n int) (*RollingProductOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RollingProductOf[T]{
+		n: n,
+
+		index: 0,
+		count: 0,
+
+		data: make([]T, n),
+	}, nil
+}
+
+// Next takes the next input and returns the next RollingProduct value
+func (p *RollingProductOf[T]) Next(input T) T {
+	// add input to data
+	p.index = (p.index + 1) % p.n
+	if p.count < p.n {
+		p.count++
+	} else {
+		p.product.remove(p.data[p.index])
+	}
+
+	p.data[p.index] = input
+	p.product.add(input)
+	p.resync()
+	return p.product.value()
+}
+
+// Peek returns the value Next would return for input without committing it
+func (p *RollingProductOf[T]) Peek(input T) T {
+	index := (p.index + 1) % p.n
+	count := p.count
+	if count < p.n {
+		count++
+	}
+	if p.updates+1 >= p.n {
+		product := windowLogProduct(p.data, index, count, input)
+		return product.value()
+	}
+
+	product := p.product
+	if p.count == p.n {
+		product.remove(p.data[index])
+	}
+	product.add(input)
+	return product.value()
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated RollingProduct value.
+// If no input has been committed yet it behaves like Next.
+func (p *RollingProductOf[T]) UpdateLast(input T) T {
+	if p.count == 0 {
+		return p.Next(input)
+	}
+
+	p.product.remove(p.data[p.index])
+	p.data[p.index] = input
+	p.product.add(input)
+	p.resync()
+	return p.product.value()
+}
+
+// resync recomputes product from data once every n updates, so that the rounding errors of its logSum
+// do not accumulate over long streams
+func (p *RollingProductOf[T]) resync() {
+	p.updates++
+	if p.updates >= p.n {
+		p.product = windowLogProduct(p.data, p.index, p.count, p.data[p.index])
+		p.updates = 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (p *RollingProductOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = p.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (p *RollingProductOf[T]) Reset() {
+	p.index = 0
+	p.count = 0
+	p.product = logProduct[T]{}
+	p.updates = 0
+
+	p.data = make([]T, p.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (p *RollingProductOf[T]) Clone() *RollingProductOf[T] {
+	clone := *p
+	clone.data = cloneSlice(p.data)
+	return &clone
+}
+
+func (p *RollingProductOf[T]) String() string {
+	return fmt.Sprintf("Product(%d)", p.n)
+}
+
+// ComputeRollingProduct computes a RollingProduct with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRollingProduct[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	p, err := NewRollingProductOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = p.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
+
+// logProduct keeps a product as the sum of the logarithms of the absolute values of its factors,
+// and counts the zero, negative and non-finite factors, whose logarithms cannot be summed
+type logProduct[T Float] struct {
+	logSum    T
+	zeros     int
+	negatives int
+	nans      int
+	infs      int
+}
+
+func (lp *logProduct[T]) add(x T) {
+	lp.count(x, 1)
+	if isLogFinite(x) {
+		lp.logSum += T(math.Log(math.Abs(float64(x))))
+	}
+}
+
+// remove excludes the factor x, which must have been added before
+func (lp *logProduct[T]) remove(x T) {
+	lp.count(x, -1)
+	if isLogFinite(x) {
+		lp.logSum -= T(math.Log(math.Abs(float64(x))))
+	}
+}
+
+// count adds delta to the counts x belongs to
+func (lp *logProduct[T]) count(x T, delta int) {
+	switch {
+	case math.IsNaN(float64(x)):
+		lp.nans += delta
+		return
+	case x == 0:
+		lp.zeros += delta
+		return
+	case math.IsInf(float64(x), 0):
+		lp.infs += delta
+	}
+	if x < 0 {
+		lp.negatives += delta
+	}
+}
+
+func (lp *logProduct[T]) value() T {
+	switch {
+	case lp.nans > 0, lp.zeros > 0 && lp.infs > 0:
+		return T(math.NaN())
+	case lp.zeros > 0:
+		return 0
+	}
+
+	product := T(math.Inf(1))
+	if lp.infs == 0 {
+		product = T(math.Exp(float64(lp.logSum)))
+	}
+	if lp.negatives%2 == 1 {
+		return -product
+	}
+	return product
+}
+
+// isLogFinite reports whether the logarithm of the absolute value of x is finite
+func isLogFinite[T Float](x T) bool {
+	return x != 0 && !math.IsNaN(float64(x)) && !math.IsInf(float64(x), 0)
+}
+
+// windowLogProduct returns the logProduct of the count most recent values of the ring buffer data, the last
+// at index, taking last as the value at index, with the logarithms summed in float64
+func windowLogProduct[T Float](data []T, index, count int, last T) logProduct[T] {
+	var lp logProduct[float64]
+	for k := 0; k < count; k++ {
+		lp.add(float64(windowValue(data, index, k, last)))
+	}
+	return logProduct[T]{logSum: T(lp.logSum), zeros: lp.zeros, negatives: lp.negatives, nans: lp.nans, infs: lp.infs}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRollingProduct(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *RollingProduct
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &RollingProduct{n: 9, data: make([]float64, 9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewRollingProduct(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestRollingProductNext(t *testing.T) {
+	sd, _ := NewRollingProduct(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 1.5, want: 1.5},
+		{input: -2., want: -3.},
+		{input: 0.5, want: -1.5},
+		{input: 4., want: -6.},
+		{input: 0., want: -0.},
+		{input: 3., want: 0.},
+		{input: -1., want: -0.},
+		{input: 2., want: -0.},
+		{input: 0.25, want: -1.5},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeRollingProduct(t *testing.T) {
+	inputs := []float64{1.5, -2., 0.5, 4., 0.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{1.5, -3., -1.5, -6., -0.}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), -6., -0.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeRollingProduct(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRollingProductWindows(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"single value":   {n: 1, inputs: []float64{4., 9.}, want: 9.},
+		"oldest evicted": {n: 3, inputs: []float64{100., 1., 2., 3.}, want: 6.},
+		"zero evicted":   {n: 2, inputs: []float64{0., 2., 3.}, want: 6.},
+		"negatives":      {n: 3, inputs: []float64{-2., -3., -4.}, want: -24.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewRollingProduct(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRollingProductNonFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := map[string]struct {
+		inputs []float64
+		want   []float64
+	}{
+		"NaN":               {inputs: []float64{2., nan, 3., 4., 5.}, want: []float64{2., nan, nan, 12., 20.}},
+		"infinity":          {inputs: []float64{2., inf, 3., 4., 5.}, want: []float64{2., inf, inf, 12., 20.}},
+		"negative infinity": {inputs: []float64{2., -inf, 3., 4., 5.}, want: []float64{2., -inf, -inf, 12., 20.}},
+		"zero and infinity": {inputs: []float64{0., inf, 3., 4., 5.}, want: []float64{0., nan, inf, 12., 20.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewRollingProduct(2)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRollingProductUpdateLastNonFinite(t *testing.T) {
+	sd, _ := NewRollingProduct(2)
+	sd.Next(2.)
+	sd.Next(math.NaN())
+	diff := cmp.Diff(6., sd.UpdateLast(3.), approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	sd.Next(math.Inf(-1))
+	diff = cmp.Diff(12., sd.UpdateLast(4.), approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRollingProductString(t *testing.T) {
+	sd, _ := NewRollingProduct(4)
+	want := "Product(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRollingProductLogSpace(t *testing.T) {
+	sd, _ := NewRollingProduct(4)
+	got := sd.Compute([]float64{1e200, 1e200, 1e-200, -1e-200}, nil)
+	diff := cmp.Diff([]float64{1e200, math.Inf(1), 1e200, -1.}, got, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+RollingRankOf returns the rank of the current input among the last n values, including itself:
+1 for the lowest value and N for the highest. Equal values share the average of their ranks.
+The window is kept sorted, so ranking an input takes a binary search.
+
+# Formula
+
+Rank = count(x<sub>i</sub> < x<sub>t</sub>) + (count(x<sub>i</sub> = x<sub>t</sub>) + 1) / 2
+
+Where:
+
+* _x<sub>t</sub>_ - current input
+* _x<sub>i</sub>_ - values of the observation, including x<sub>t</sub>
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+rank, _ := NewRollingRank(20)
+rank.Next(10.)
+```
+*/
+type RollingRankOf[T Float] struct {
+	sortedWindow[T]
+}
+
+// RollingRank is a RollingRankOf float64 values
+type RollingRank = RollingRankOf[float64]
+
+// NewRollingRank creates a new RollingRank with the given number of periods
+// Example: NewRollingRank(20)
+func NewRollingRank(n int) (*RollingRank, error) {
+	return NewRollingRankOf[float64](n)
+}
+
+// NewRollingRankOf creates a new RollingRankOf values of type T with the given number of periods
+// Example: NewRollingRankOf[float32](20)
+func NewRollingRankOf[T Float](n int) (*RollingRankOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RollingRankOf[T]{
+		sortedWindow: newSortedWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next RollingRank value
+func (r *RollingRankOf[T]) Next(input T) T {
+	r.push(input)
+	return rank(r.sorted, input)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (r *RollingRankOf[T]) Peek(input T) T {
+	return r.peek(input, func(sorted []T) T {
+		return rank(sorted, input)
+	})
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated RollingRank value.
+// If no input has been committed yet it behaves like Next.
+func (r *RollingRankOf[T]) UpdateLast(input T) T {
+	r.replaceLast(input)
+	return rank(r.sorted, input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (r *RollingRankOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = r.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (r *RollingRankOf[T]) Reset() {
+	r.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (r *RollingRankOf[T]) Clone() *RollingRankOf[T] {
+	return &RollingRankOf[T]{
+		sortedWindow: r.clone(),
+	}
+}
+
+func (r *RollingRankOf[T]) String() string {
+	return fmt.Sprintf("Rank(%d)", r.n)
+}
+
+// ComputeRollingRank computes a RollingRank with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRollingRank[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	r, err := NewRollingRankOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = r.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
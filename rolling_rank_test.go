@@ -0,0 +1,125 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRollingRank(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *RollingRank
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &RollingRank{sortedWindow: newSortedWindow[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewRollingRank(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestRollingRankNext(t *testing.T) {
+	sd, _ := NewRollingRank(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 1.},
+		{input: 8., want: 2.},
+		{input: 0., want: 1.},
+		{input: 4., want: 3.},
+		{input: 1., want: 2.},
+		{input: 9., want: 4.},
+		{input: 9., want: 3.5},
+		{input: 0., want: 1.},
+		{input: 3., want: 2.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeRollingRank(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{1., 2., 1., 3., 2.}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 3., 2.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeRollingRank(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRollingRankWindows(t *testing.T) {
+	tests := map[string]struct {
+		n      int
+		inputs []float64
+		want   float64
+	}{
+		"ties with the input": {n: 4, inputs: []float64{3., 1., 3., 3.}, want: 3.},
+		"equal values":        {n: 3, inputs: []float64{2., 2., 2.}, want: 2.},
+		"highest":             {n: 3, inputs: []float64{1., 2., 3.}, want: 3.},
+		"lowest":              {n: 3, inputs: []float64{3., 2., 1.}, want: 1.},
+		"oldest evicted":      {n: 3, inputs: []float64{9., 5., 4., 4.5}, want: 2.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewRollingRank(tc.n)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRollingRankString(t *testing.T) {
+	sd, _ := NewRollingRank(4)
+	want := "Rank(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
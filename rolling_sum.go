@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+RollingSumOf returns the sum of the last _n_ inputs
+
+# Formula
+
+Sum<sub>t</sub> = p<sub>t</sub> + p<sub>t-1</sub> + ... + p<sub>t-n+1</sub>
+
+Where:
+
+* _p<sub>t</sub>_ - input value at a point of time _t_
+* _n_ - number of periods (length)
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+sum, _ := NewRollingSum(20)
+sum.Next(10.)
+```
+*/
+type RollingSumOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index int
+	count int
+
+	sum T
+	// number of updates since sum was last recomputed from data
+	updates int
+
+	// slice of data needed for calculation
+	data []T
+}
+
+// RollingSum is a RollingSumOf float64 values
+type RollingSum = RollingSumOf[float64]
+
+// NewRollingSum creates a new RollingSum with the given number of periods
+// Example: NewRollingSum(20)
+func NewRollingSum(n int) (*RollingSum, error) {
+	return NewRollingSumOf[float64](n)
+}
+
+// NewRollingSumOf creates a new RollingSumOf values of type T with the given number of periods
+// Example: NewRollingSumOf[float32](20)
+func NewRollingSumOf[T Float](n int) (*RollingSumOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &RollingSumOf[T]{
+		n: n,
+
+		index: 0,
+		count: 0,
+
+		sum: 0,
+
+		data: make([]T, n),
+	}, nil
+}
+
+// Next takes the next input and returns the next RollingSum value
+func (s *RollingSumOf[T]) Next(input T) T {
+	// add input to data
+	s.index = (s.index + 1) % s.n
+	oldValue := s.data[s.index]
+	s.data[s.index] = input
+
+	if s.count < s.n {
+		s.count++
+	}
+
+	s.sum += input - oldValue
+	s.resync()
+	return s.sum
+}
+
+// Peek returns the value Next would return for input without committing it
+func (s *RollingSumOf[T]) Peek(input T) T {
+	index := (s.index + 1) % s.n
+	if s.updates+1 >= s.n {
+		count := s.count
+		if count < s.n {
+			count++
+		}
+		return windowSum(s.data, index, count, input)
+	}
+	return s.sum + (input - s.data[index])
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated RollingSum value.
+// If no input has been committed yet it behaves like Next.
+func (s *RollingSumOf[T]) UpdateLast(input T) T {
+	if s.count == 0 {
+		return s.Next(input)
+	}
+
+	s.sum += input - s.data[s.index]
+	s.data[s.index] = input
+	s.resync()
+	return s.sum
+}
+
+// resync recomputes sum from data once every n updates, so that its rounding errors do not accumulate
+// over long streams
+func (s *RollingSumOf[T]) resync() {
+	s.updates++
+	if s.updates >= s.n {
+		s.sum = windowSum(s.data, s.index, s.count, s.data[s.index])
+		s.updates = 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (s *RollingSumOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = s.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (s *RollingSumOf[T]) Reset() {
+	s.index = 0
+	s.count = 0
+
+	s.sum = 0
+	s.updates = 0
+
+	s.data = make([]T, s.n)
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (s *RollingSumOf[T]) Clone() *RollingSumOf[T] {
+	clone := *s
+	clone.data = cloneSlice(s.data)
+	return &clone
+}
+
+func (s *RollingSumOf[T]) String() string {
+	return fmt.Sprintf("Sum(%d)", s.n)
+}
+
+// ComputeRollingSum computes a RollingSum with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeRollingSum[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	s, err := NewRollingSumOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = s.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Signal is an event reported by the signal detectors, such as Cross, Zone and Divergence
+type Signal int8
+
+const (
+	// NoSignal is reported when nothing happened
+	NoSignal Signal = iota
+	// CrossAbove is reported when a series moves above another one or a level
+	CrossAbove
+	// CrossBelow is reported when a series moves below another one or a level
+	CrossBelow
+	// EnterZone is reported when a series moves into a zone
+	EnterZone
+	// ExitZone is reported when a series leaves a zone
+	ExitZone
+	// BullishDivergence is reported when price makes a lower low while an oscillator makes a higher low
+	BullishDivergence
+	// BearishDivergence is reported when price makes a higher high while an oscillator makes a lower high
+	BearishDivergence
+	// HiddenBullishDivergence is reported when price makes a higher low while an oscillator makes a lower low
+	HiddenBullishDivergence
+	// HiddenBearishDivergence is reported when price makes a lower high while an oscillator makes a higher high
+	HiddenBearishDivergence
+)
+
+func (s Signal) String() string {
+	switch s {
+	case NoSignal:
+		return "NoSignal"
+	case CrossAbove:
+		return "CrossAbove"
+	case CrossBelow:
+		return "CrossBelow"
+	case EnterZone:
+		return "EnterZone"
+	case ExitZone:
+		return "ExitZone"
+	case BullishDivergence:
+		return "BullishDivergence"
+	case BearishDivergence:
+		return "BearishDivergence"
+	case HiddenBullishDivergence:
+		return "HiddenBullishDivergence"
+	case HiddenBearishDivergence:
+		return "HiddenBearishDivergence"
+	default:
+		return fmt.Sprintf("Signal(%d)", int8(s))
+	}
+}
+
+// SignalOption configures Cross, CrossOver, Zone and the other signal detectors
+type SignalOption func(*signalConfig)
+
+// WithHysteresis sets the distance, at least 0, by which a series must move past a level before
+// the detector changes side, so that a series hovering around the level does not report every tick.
+// It is 0 by default.
+func WithHysteresis(band float64) SignalOption {
+	return func(c *signalConfig) {
+		c.band = band
+	}
+}
+
+// WithMinBars sets the number of inputs, at least 0, that must follow a signal before the next one
+// is reported. The signals in between are dropped, though the detector still follows the series.
+// It is 0 by default.
+func WithMinBars(n int) SignalOption {
+	return func(c *signalConfig) {
+		c.minBars = n
+	}
+}
+
+// WithConfirmation sets the number of consecutive inputs, at least 1, that must stay on the new side
+// before a change is reported, on the last of them. It is 1 by default.
+func WithConfirmation(k int) SignalOption {
+	return func(c *signalConfig) {
+		c.confirmation = k
+	}
+}
+
+// signalConfig holds the options of a signal detector
+type signalConfig struct {
+	band         float64
+	minBars      int
+	confirmation int
+}
+
+func newSignalConfig(options []SignalOption) (signalConfig, error) {
+	config := signalConfig{confirmation: 1}
+	for _, option := range options {
+		option(&config)
+	}
+
+	if !(config.band >= 0) || config.minBars < 0 || config.confirmation < 1 {
+		return signalConfig{}, ErrInvalidParameters
+	}
+	return config, nil
+}
+
+// name returns the name of a detector with the given parameters, followed by the options if any was set
+func (c signalConfig) name(prefix string, params ...string) string {
+	if c.band != 0 || c.minBars != 0 || c.confirmation != 1 {
+		params = append(params, fmt.Sprintf("%g", c.band), fmt.Sprint(c.minBars), fmt.Sprint(c.confirmation))
+	}
+	if len(params) == 0 {
+		return prefix
+	}
+	return fmt.Sprintf("%s(%s)", prefix, strings.Join(params, ","))
+}
+
+// signalFilter follows the side of a series, 1 or -1, and reports the changes of side
+// that pass the confirmation and debouncing of its config
+type signalFilter struct {
+	config signalConfig
+
+	// side confirmed so far, 0 until the first input on either side
+	side int
+	// consecutive inputs on the other side, waiting for confirmation
+	run int
+
+	// inputs since the last reported change, and whether there was one
+	since    int
+	reported bool
+}
+
+func newSignalFilter(config signalConfig, side int) signalFilter {
+	return signalFilter{
+		config: config,
+		side:   side,
+	}
+}
+
+// next takes the side of the next input, or 0 if it is within the hysteresis band, and returns
+// the new side if a change is reported, or 0
+func (f *signalFilter) next(side int) int {
+	f.since++
+	if side == 0 || side == f.side {
+		f.run = 0
+		return 0
+	}
+	if f.side == 0 {
+		f.side = side
+		return 0
+	}
+
+	f.run++
+	if f.run < f.config.confirmation {
+		return 0
+	}
+	f.side, f.run = side, 0
+
+	if f.reported && f.since < f.config.minBars {
+		return 0
+	}
+	f.since, f.reported = 0, true
+	return side
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSignalConfig(t *testing.T) {
+	tests := map[string]struct {
+		options []SignalOption
+		want    signalConfig
+		wantErr error
+	}{
+		"default":              {options: nil, want: signalConfig{confirmation: 1}},
+		"all options":          {options: []SignalOption{WithHysteresis(0.5), WithMinBars(3), WithConfirmation(2)}, want: signalConfig{band: 0.5, minBars: 3, confirmation: 2}},
+		"negative hysteresis":  {options: []SignalOption{WithHysteresis(-0.5)}, wantErr: ErrInvalidParameters},
+		"NaN hysteresis":       {options: []SignalOption{WithHysteresis(math.NaN())}, wantErr: ErrInvalidParameters},
+		"negative min bars":    {options: []SignalOption{WithMinBars(-1)}, wantErr: ErrInvalidParameters},
+		"zero confirmation":    {options: []SignalOption{WithConfirmation(0)}, wantErr: ErrInvalidParameters},
+		"infinite hysteresis":  {options: []SignalOption{WithHysteresis(math.Inf(1))}, want: signalConfig{band: math.Inf(1), confirmation: 1}},
+		"zero min bars option": {options: []SignalOption{WithMinBars(0)}, want: signalConfig{confirmation: 1}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := newSignalConfig(tc.options)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestSignalFilterNext(t *testing.T) {
+	tests := map[string]struct {
+		config signalConfig
+		sides  []int
+		want   []int
+	}{
+		"every change": {
+			config: signalConfig{confirmation: 1},
+			sides:  []int{-1, 0, 1, 0, -1, -1, 1},
+			want:   []int{0, 0, 1, 0, -1, 0, 1},
+		},
+		"min bars": {
+			config: signalConfig{minBars: 3, confirmation: 1},
+			sides:  []int{-1, 1, -1, 1, -1, 1, 1, -1},
+			want:   []int{0, 1, 0, 0, -1, 0, 0, -1},
+		},
+		"confirmation": {
+			config: signalConfig{confirmation: 2},
+			sides:  []int{-1, 1, 1, -1, 1, -1, -1, -1},
+			want:   []int{0, 0, 1, 0, 0, 0, -1, 0},
+		},
+		"confirmation interrupted by the band": {
+			config: signalConfig{confirmation: 2},
+			sides:  []int{-1, 1, 0, 1, 1},
+			want:   []int{0, 0, 0, 0, 1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := newSignalFilter(tc.config, 0)
+			got := make([]int, len(tc.sides))
+			for i, side := range tc.sides {
+				got[i] = filter.next(side)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSignalString(t *testing.T) {
+	tests := map[Signal]string{
+		NoSignal:   "NoSignal",
+		CrossAbove: "CrossAbove",
+		CrossBelow: "CrossBelow",
+		EnterZone:  "EnterZone",
+		ExitZone:   "ExitZone",
+
+		BullishDivergence:       "BullishDivergence",
+		BearishDivergence:       "BearishDivergence",
+		HiddenBullishDivergence: "HiddenBullishDivergence",
+		HiddenBearishDivergence: "HiddenBearishDivergence",
+
+		Signal(42): "Signal(42)",
+	}
+	for signal, want := range tests {
+		assert.Equal(t, want, signal.String())
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+SkewnessOf returns the skewness of the last n values, a measure of the asymmetry of their distribution.
+The third and second central moments are updated online as values enter and leave the window, and
+recomputed from it once every n updates so that rounding errors do not accumulate over long streams.
+
+# Formula
+
+g<sub>1</sub> = m<sub>3</sub> / m<sub>2</sub><sup>3/2</sup>
+
+Where:
+
+* _m<sub>k</sub>_ - k-th central moment of the observation: Σ(x<sub>i</sub> - x̄)<sup>k</sup> / N
+* _N_ - number of probes in observation.
+
+The skewness of a window whose values are all equal is 0.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+s, _ := NewSkewness(20)
+s.Next(10.)
+```
+*/
+type SkewnessOf[T Float] struct {
+	momentWindow[T]
+}
+
+// Skewness is a SkewnessOf float64 values
+type Skewness = SkewnessOf[float64]
+
+// NewSkewness creates a new Skewness with the given number of periods
+// Example: NewSkewness(20)
+func NewSkewness(n int) (*Skewness, error) {
+	return NewSkewnessOf[float64](n)
+}
+
+// NewSkewnessOf creates a new SkewnessOf values of type T with the given number of periods
+// Example: NewSkewnessOf[float32](20)
+func NewSkewnessOf[T Float](n int) (*SkewnessOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &SkewnessOf[T]{
+		momentWindow: newMomentWindow[T](n),
+	}, nil
+}
+
+// Next takes the next input and returns the next Skewness value
+func (s *SkewnessOf[T]) Next(input T) T {
+	s.push(input)
+	return s.moments.skewness()
+}
+
+// Peek returns the value Next would return for input without committing it
+func (s *SkewnessOf[T]) Peek(input T) T {
+	moments := s.peek(input)
+	return moments.skewness()
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Skewness value.
+// If no input has been committed yet it behaves like Next.
+func (s *SkewnessOf[T]) UpdateLast(input T) T {
+	s.replaceLast(input)
+	return s.moments.skewness()
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (s *SkewnessOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = s.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (s *SkewnessOf[T]) Reset() {
+	s.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (s *SkewnessOf[T]) Clone() *SkewnessOf[T] {
+	return &SkewnessOf[T]{
+		momentWindow: s.clone(),
+	}
+}
+
+func (s *SkewnessOf[T]) String() string {
+	return fmt.Sprintf("Skew(%d)", s.n)
+}
+
+// ComputeSkewness computes a Skewness with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeSkewness[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	s, err := NewSkewnessOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = s.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
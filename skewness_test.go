@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSkewness(t *testing.T) {
+	tests := map[string]struct {
+		input   int
+		want    *Skewness
+		wantErr error
+	}{
+		"negative n": {input: -3, want: nil, wantErr: ErrInvalidParameters},
+		"zero n":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive n": {input: 9, want: &Skewness{momentWindow: newMomentWindow[float64](9)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSD, gotErr := NewSkewness(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, gotSD, "must return the correct value")
+		})
+	}
+}
+
+func TestSkewnessNext(t *testing.T) {
+	sd, _ := NewSkewness(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 2., want: 0.},
+		{input: 8., want: 0.},
+		{input: 0., want: 0.528005},
+		{input: 4., want: 0.434651},
+		{input: 1., want: 0.513024},
+		{input: 9., want: 0.629738},
+		{input: 9., want: -0.27452},
+		{input: 0., want: -0.0205476},
+		{input: 3., want: -0.213833},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeSkewness(t *testing.T) {
+	inputs := []float64{2., 8., 0., 4., 1.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 4, fillNaN: false, want: []float64{0., 0., 0.528005, 0.434651, 0.513024}},
+		"fill NaN": {n: 4, fillNaN: true, want: []float64{math.NaN(), math.NaN(), math.NaN(), 0.434651, 0.513024}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeSkewness(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestSkewnessConstantWindow(t *testing.T) {
+	sd, _ := NewSkewness(4)
+	sd.Compute([]float64{1.3, 5.7, 2.1, 8.9, 0.7, 7., 7., 7.}, nil)
+
+	// the moments updated incrementally keep a rounding residue after the varied values leave the window,
+	// so compare exactly
+	assert.Equal(t, 0., sd.Peek(7.), "must return 0 for a window of equal values")
+	assert.Equal(t, 0., sd.Next(7.), "must return 0 for a window of equal values")
+	sd.Next(3.)
+	assert.Equal(t, 0., sd.UpdateLast(7.), "must return 0 for a window of equal values")
+}
+
+func TestSkewnessSymmetricWindow(t *testing.T) {
+	sd, _ := NewSkewness(3)
+	got := sd.Compute([]float64{9., 1., 2., 3., 10., 20., 30.}, nil)
+	diff := cmp.Diff([]float64{0., 0.}, []float64{got[3], got[6]}, approxComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestSkewnessOfFloat32LongStream(t *testing.T) {
+	sd, _ := NewSkewnessOf[float32](10)
+	fresh, _ := NewSkewness(10)
+	testLongStream(t, sd, fresh, 10, 5e-2)
+}
+
+func TestSkewnessString(t *testing.T) {
+	sd, _ := NewSkewness(4)
+	want := "Skew(4)"
+	got := sd.String()
+	diff := cmp.Diff(want, got, floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
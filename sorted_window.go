@@ -0,0 +1,183 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"sort"
+)
+
+// sortedWindow keeps the last n values both in arrival order and sorted, so that order
+// statistics can be read on every input without sorting the whole window again.
+// Inserting and removing a value costs a binary search and a copy of the values after it.
+// NaN values are kept after all other values.
+type sortedWindow[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	index int
+
+	// values in arrival order, and the same values sorted
+	data   []T
+	sorted []T
+}
+
+func newSortedWindow[T Float](n int) sortedWindow[T] {
+	return sortedWindow[T]{
+		n: n,
+
+		index: 0,
+
+		data:   make([]T, n),
+		sorted: make([]T, 0, n),
+	}
+}
+
+// push adds input to the window, dropping the oldest value once the window is full.
+// It returns the value previously stored in the slot input took and whether it was in the window,
+// which unpush needs to revert the push.
+func (w *sortedWindow[T]) push(input T) (T, bool) {
+	w.index = (w.index + 1) % w.n
+	old, full := w.data[w.index], len(w.sorted) == w.n
+	if full {
+		w.sorted = removeSorted(w.sorted, old)
+	}
+	w.data[w.index] = input
+	w.sorted = insertSorted(w.sorted, input)
+	return old, full
+}
+
+// unpush reverts the most recent push, given the values it returned
+func (w *sortedWindow[T]) unpush(old T, full bool) {
+	w.sorted = removeSorted(w.sorted, w.data[w.index])
+	if full {
+		w.sorted = insertSorted(w.sorted, old)
+	}
+	w.data[w.index] = old
+	w.index = (w.index - 1 + w.n) % w.n
+}
+
+// replaceLast replaces the most recent value with input, pushing it if the window is empty
+func (w *sortedWindow[T]) replaceLast(input T) {
+	if len(w.sorted) == 0 {
+		w.push(input)
+		return
+	}
+	w.sorted = removeSorted(w.sorted, w.data[w.index])
+	w.data[w.index] = input
+	w.sorted = insertSorted(w.sorted, input)
+}
+
+// peek calls f with the sorted values push would produce for input, then restores the window
+func (w *sortedWindow[T]) peek(input T, f func(sorted []T) T) T {
+	old, full := w.push(input)
+	value := f(w.sorted)
+	w.unpush(old, full)
+	return value
+}
+
+// last returns the most recent value in the window
+func (w *sortedWindow[T]) last() T {
+	return w.data[w.index]
+}
+
+func (w *sortedWindow[T]) reset() {
+	w.index = 0
+	w.data = make([]T, w.n)
+	w.sorted = make([]T, 0, w.n)
+}
+
+func (w sortedWindow[T]) clone() sortedWindow[T] {
+	w.data = cloneSlice(w.data)
+	w.sorted = cloneSlice(w.sorted)
+	return w
+}
+
+// lessNaN orders NaN after every other value
+func lessNaN[T Float](a, b T) bool {
+	return a < b || (isNaN(b) && !isNaN(a))
+}
+
+func isNaN[T Float](x T) bool {
+	return x != x
+}
+
+// searchSorted returns the index of the first value in sorted that is not less than x
+func searchSorted[T Float](sorted []T, x T) int {
+	return sort.Search(len(sorted), func(i int) bool { return !lessNaN(sorted[i], x) })
+}
+
+// insertSorted inserts x into sorted, keeping it sorted
+func insertSorted[T Float](sorted []T, x T) []T {
+	i := sort.Search(len(sorted), func(i int) bool { return lessNaN(x, sorted[i]) })
+	sorted = append(sorted, x)
+	copy(sorted[i+1:], sorted[i:])
+	sorted[i] = x
+	return sorted
+}
+
+// removeSorted removes one occurrence of x from sorted, if there is one
+func removeSorted[T Float](sorted []T, x T) []T {
+	i := searchSorted(sorted, x)
+	if i == len(sorted) || (sorted[i] != x && !(isNaN(x) && isNaN(sorted[i]))) {
+		return sorted
+	}
+	copy(sorted[i:], sorted[i+1:])
+	return sorted[:len(sorted)-1]
+}
+
+// rank returns the 1-based rank of x among the values of sorted, averaging the ranks of equal values
+func rank[T Float](sorted []T, x T) T {
+	lower := searchSorted(sorted, x)
+	upper := sort.Search(len(sorted), func(i int) bool { return lessNaN(x, sorted[i]) })
+	return T(lower+upper+1) / 2
+}
+
+// quantile returns the q-quantile of sorted, interpolating linearly between the closest ranks
+func quantile[T Float](sorted []T, q float64) T {
+	if len(sorted) == 0 {
+		return T(math.NaN())
+	}
+
+	h := q * float64(len(sorted)-1)
+	lo := int(h)
+	if lo >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + T(h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// medianAbsoluteDeviation returns the median of the absolute deviations of sorted from its median.
+// The deviations of the values below and above the median both grow away from it, so they are merged
+// like two sorted lists up to the middle instead of being sorted.
+func medianAbsoluteDeviation[T Float](sorted []T) T {
+	n := len(sorted)
+	if n == 0 {
+		return T(math.NaN())
+	}
+
+	median := quantile(sorted, 0.5)
+	below := searchSorted(sorted, median) - 1
+	above := below + 1
+
+	var previous, current T
+	for k := 0; k <= n/2; k++ {
+		previous = current
+		if above < n && (below < 0 || sorted[above]-median <= median-sorted[below]) {
+			current = sorted[above] - median
+			above++
+		} else {
+			current = median - sorted[below]
+			below--
+		}
+	}
+
+	if n%2 == 1 {
+		return current
+	}
+	return (previous + current) / 2
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedWindowPush(t *testing.T) {
+	w := newSortedWindow[float64](5)
+	var window []float64
+	for i := 0; i < 50; i++ {
+		input := float64((i * 7) % 11) // includes duplicates
+		w.push(input)
+
+		window = append(window, input)
+		if len(window) > 5 {
+			window = window[1:]
+		}
+		want := append([]float64(nil), window...)
+		sort.Float64s(want)
+		assert.Equal(t, want, w.sorted, "must keep the window sorted")
+	}
+}
+
+func TestSortedWindowNaN(t *testing.T) {
+	w := newSortedWindow[float64](3)
+	w.push(2.)
+	w.push(math.NaN())
+	w.push(1.)
+	assert.Equal(t, 3, len(w.sorted))
+	assert.Equal(t, []float64{1., 2.}, w.sorted[:2], "must order NaN last")
+	assert.True(t, math.IsNaN(w.sorted[2]), "must order NaN last")
+
+	w.push(3.)
+	w.push(4.)
+	assert.Equal(t, []float64{1., 3., 4.}, w.sorted, "must remove NaN when it leaves the window")
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{1., 2., 4., 8.}
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{q: 0., want: 1.},
+		{q: 0.25, want: 1.75},
+		{q: 0.5, want: 3.},
+		{q: 0.75, want: 5.},
+		{q: 1., want: 8.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tc.want, quantile(sorted, tc.q), "must interpolate between the closest ranks")
+		})
+	}
+	assert.True(t, math.IsNaN(quantile([]float64{}, 0.5)), "must return NaN for an empty window")
+}
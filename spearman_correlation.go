@@ -0,0 +1,142 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+SpearmanCorrelationOf returns the Spearman rank correlation coefficient of the last n pairs of values
+of two series: the Pearson correlation of the ranks of the values within the window. It measures
+how monotonic the relationship between the series is, and is robust to outliers.
+Both windows are kept sorted, so ranking the window takes a binary search per value.
+
+# Formula
+
+r<sub>s</sub> = ρ(rank(x), rank(y))
+
+Where:
+
+* _rank(x)_ - 1-based rank of a value among the window, equal values sharing their average rank
+* _ρ_ - Pearson correlation, see PearsonCorrelation
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+rs, _ := NewSpearmanCorrelation(20)
+rs.Next(asset, benchmark)
+```
+*/
+type SpearmanCorrelationOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// sorted windows of both series
+	xs sortedWindow[T]
+	ys sortedWindow[T]
+}
+
+// SpearmanCorrelation is a SpearmanCorrelationOf float64 values
+type SpearmanCorrelation = SpearmanCorrelationOf[float64]
+
+// NewSpearmanCorrelation creates a new SpearmanCorrelation with the given number of periods
+// Example: NewSpearmanCorrelation(20)
+func NewSpearmanCorrelation(n int) (*SpearmanCorrelation, error) {
+	return NewSpearmanCorrelationOf[float64](n)
+}
+
+// NewSpearmanCorrelationOf creates a new SpearmanCorrelationOf values of type T with the given number of periods
+// Example: NewSpearmanCorrelationOf[float32](20)
+func NewSpearmanCorrelationOf[T Float](n int) (*SpearmanCorrelationOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &SpearmanCorrelationOf[T]{
+		n: n,
+
+		xs: newSortedWindow[T](n),
+		ys: newSortedWindow[T](n),
+	}, nil
+}
+
+// Next takes the next pair of inputs and returns the next SpearmanCorrelation value
+func (rs *SpearmanCorrelationOf[T]) Next(x, y T) T {
+	rs.xs.push(x)
+	rs.ys.push(y)
+	return rs.correlation()
+}
+
+// Peek returns the value Next would return for the pair without committing it
+func (rs *SpearmanCorrelationOf[T]) Peek(x, y T) T {
+	oldX, fullX := rs.xs.push(x)
+	oldY, fullY := rs.ys.push(y)
+	r := rs.correlation()
+	rs.ys.unpush(oldY, fullY)
+	rs.xs.unpush(oldX, fullX)
+	return r
+}
+
+// UpdateLast replaces the most recent pair with (x, y) and returns the updated SpearmanCorrelation value.
+// If no pair has been committed yet it behaves like Next.
+func (rs *SpearmanCorrelationOf[T]) UpdateLast(x, y T) T {
+	rs.xs.replaceLast(x)
+	rs.ys.replaceLast(y)
+	return rs.correlation()
+}
+
+// correlation returns the Pearson correlation of the ranks of the pairs in the window
+func (rs *SpearmanCorrelationOf[T]) correlation() T {
+	var moments coMoments[T]
+	for k := 0; k < len(rs.xs.sorted); k++ {
+		i := (rs.xs.index - k + rs.n) % rs.n
+		moments.add(rank(rs.xs.sorted, rs.xs.data[i]), rank(rs.ys.sorted, rs.ys.data[i]))
+	}
+	return moments.correlation()
+}
+
+// Compute feeds every pair of xs and ys through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of the shorter input.
+func (rs *SpearmanCorrelationOf[T]) Compute(xs, ys, out []T) []T {
+	return computePairs(rs.Next, xs, ys, out)
+}
+
+// Reset resets the indicators to a clean state
+func (rs *SpearmanCorrelationOf[T]) Reset() {
+	rs.xs.reset()
+	rs.ys.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (rs *SpearmanCorrelationOf[T]) Clone() *SpearmanCorrelationOf[T] {
+	return &SpearmanCorrelationOf[T]{
+		n: rs.n,
+
+		xs: rs.xs.clone(),
+		ys: rs.ys.clone(),
+	}
+}
+
+func (rs *SpearmanCorrelationOf[T]) String() string {
+	return fmt.Sprintf("Spearman(%d)", rs.n)
+}
+
+// ComputeSpearmanCorrelation computes a SpearmanCorrelation with n periods over the pairs of xs and ys and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeSpearmanCorrelation[T Float](n int, xs, ys, out []T, fillNaN bool) ([]T, error) {
+	rs, err := NewSpearmanCorrelationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = rs.Compute(xs, ys, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
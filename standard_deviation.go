@@ -11,7 +11,7 @@ import (
 )
 
 /*
-StandardDeviation returns the standard deviation of the last n values.
+StandardDeviationOf returns the standard deviation of the last n values.
 
 # Formula
 
@@ -37,7 +37,7 @@ sd.Next(20.)
 sd.Next(10.)
 ```
 */
-type StandardDeviation struct {
+type StandardDeviationOf[T Float] struct {
 	// number of periods (must be an integer greater than 0)
 	n int
 
@@ -45,21 +45,32 @@ type StandardDeviation struct {
 	index int
 	count int
 
-	m  float64
-	m2 float64
+	m  T
+	m2 T
+	// number of updates since m and m2 were last recomputed from data
+	updates int
 
 	// slice of data needed for calculation
-	data []float64
+	data []T
 }
 
+// StandardDeviation is a StandardDeviationOf float64 values
+type StandardDeviation = StandardDeviationOf[float64]
+
 // NewStandardDeviation creates a new StandardDeviation with the given number of periods
 // Example: NewStandardDeviation(9)
 func NewStandardDeviation(n int) (*StandardDeviation, error) {
+	return NewStandardDeviationOf[float64](n)
+}
+
+// NewStandardDeviationOf creates a new StandardDeviationOf values of type T with the given number of periods
+// Example: NewStandardDeviationOf[float32](9)
+func NewStandardDeviationOf[T Float](n int) (*StandardDeviationOf[T], error) {
 	if n <= 0 {
 		return nil, ErrInvalidParameters
 	}
 
-	return &StandardDeviation{
+	return &StandardDeviationOf[T]{
 		n: n,
 
 		index: 0,
@@ -68,12 +79,12 @@ func NewStandardDeviation(n int) (*StandardDeviation, error) {
 		m:  0,
 		m2: 0,
 
-		data: make([]float64, n),
+		data: make([]T, n),
 	}, nil
 }
 
 // Next takes the next input and returns the next StandardDeviation value
-func (sd *StandardDeviation) Next(input float64) float64 {
+func (sd *StandardDeviationOf[T]) Next(input T) T {
 	// add input to data
 	sd.index = (sd.index + 1) % sd.n
 	oldValue := sd.data[sd.index]
@@ -83,32 +94,163 @@ func (sd *StandardDeviation) Next(input float64) float64 {
 		// not enough data for n periods yet
 		sd.count++
 		delta := input - sd.m
-		sd.m += delta / float64(sd.count)
+		sd.m += delta / T(sd.count)
 		delta2 := input - sd.m
 		sd.m2 += delta * delta2
 	} else {
 		oldM := sd.m
 		delta := input - oldValue
-		sd.m += delta / float64(sd.n)
+		sd.m += delta / T(sd.n)
 
 		delta2 := input - sd.m + oldValue - oldM
 		sd.m2 += delta * delta2
 	}
 
-	return math.Sqrt(sd.m2 / float64(sd.count))
+	sd.resync()
+	return T(math.Sqrt(float64(sd.m2 / T(sd.count))))
+}
+
+// Peek returns the value Next would return for input without committing it
+func (sd *StandardDeviationOf[T]) Peek(input T) T {
+	_, value := sd.peek(input)
+	return value
+}
+
+// peek returns the mean and the standard deviation Next would produce for input
+func (sd *StandardDeviationOf[T]) peek(input T) (T, T) {
+	index := (sd.index + 1) % sd.n
+	if sd.updates+1 >= sd.n {
+		count := sd.count
+		if count < sd.n {
+			count++
+		}
+		m, m2 := windowMoments(sd.data, index, count, input)
+		return m, T(math.Sqrt(float64(m2 / T(count))))
+	}
+
+	if sd.count < sd.n {
+		count := T(sd.count + 1)
+		delta := input - sd.m
+		m := sd.m + delta/count
+		m2 := sd.m2 + delta*(input-m)
+		return m, T(math.Sqrt(float64(m2 / count)))
+	}
+
+	oldValue := sd.data[index]
+	delta := input - oldValue
+	m := sd.m + delta/T(sd.n)
+	m2 := sd.m2 + delta*(input-m+oldValue-sd.m)
+	return m, T(math.Sqrt(float64(m2 / T(sd.n))))
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated StandardDeviation value.
+// If no input has been committed yet it behaves like Next.
+func (sd *StandardDeviationOf[T]) UpdateLast(input T) T {
+	if sd.count == 0 {
+		return sd.Next(input)
+	}
+
+	oldValue := sd.data[sd.index]
+	sd.data[sd.index] = input
+
+	// replace oldValue by input among the count values in the window
+	oldM := sd.m
+	delta := input - oldValue
+	sd.m += delta / T(sd.count)
+
+	delta2 := input - sd.m + oldValue - oldM
+	sd.m2 += delta * delta2
+
+	sd.resync()
+	return T(math.Sqrt(float64(sd.m2 / T(sd.count))))
+}
+
+// resync recomputes m and m2 from data once every n updates, so that their rounding errors do not
+// accumulate over long streams
+func (sd *StandardDeviationOf[T]) resync() {
+	sd.updates++
+	if sd.updates >= sd.n {
+		sd.m, sd.m2 = windowMoments(sd.data, sd.index, sd.count, sd.data[sd.index])
+		sd.updates = 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (sd *StandardDeviationOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+
+	n, index, count, m, m2, updates, data := sd.n, sd.index, sd.count, sd.m, sd.m2, sd.updates, sd.data
+	for i, input := range inputs {
+		index = (index + 1) % n
+		oldValue := data[index]
+		data[index] = input
+
+		if count < n {
+			count++
+			delta := input - m
+			m += delta / T(count)
+			delta2 := input - m
+			m2 += delta * delta2
+		} else {
+			oldM := m
+			delta := input - oldValue
+			m += delta / T(n)
+
+			delta2 := input - m + oldValue - oldM
+			m2 += delta * delta2
+		}
+		if updates++; updates >= n {
+			m, m2 = windowMoments(data, index, count, data[index])
+			updates = 0
+		}
+
+		out[i] = T(math.Sqrt(float64(m2 / T(count))))
+	}
+
+	sd.index, sd.count, sd.m, sd.m2, sd.updates = index, count, m, m2, updates
+	return out
+}
+
+// Mean returns the mean of the values in the window
+func (sd *StandardDeviationOf[T]) Mean() T {
+	return sd.m
 }
 
 // Reset resets the indicators to a clean state
-func (sd *StandardDeviation) Reset() {
+func (sd *StandardDeviationOf[T]) Reset() {
 	sd.index = 0
 	sd.count = 0
 
 	sd.m = 0
 	sd.m2 = 0
+	sd.updates = 0
+
+	sd.data = make([]T, sd.n)
+}
 
-	sd.data = make([]float64, sd.n)
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (sd *StandardDeviationOf[T]) Clone() *StandardDeviationOf[T] {
+	clone := *sd
+	clone.data = cloneSlice(sd.data)
+	return &clone
 }
 
-func (sd *StandardDeviation) String() string {
+func (sd *StandardDeviationOf[T]) String() string {
 	return fmt.Sprintf("SD(%d)", sd.n)
 }
+
+// ComputeStandardDeviation computes a StandardDeviation with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeStandardDeviation[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	sd, err := NewStandardDeviationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = sd.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
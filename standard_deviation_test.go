@@ -6,6 +6,7 @@ Licensed under terms of MIT license (see LICENSE)
 package tago
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -107,6 +108,192 @@ func TestStandardDeviationReset(t *testing.T) {
 	}
 }
 
+func TestStandardDeviationPeek(t *testing.T) {
+	sd, _ := NewStandardDeviation(4)
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 10., want: 0.},
+		{input: 20., want: 5.},
+		{input: 30., want: 8.165},
+		{input: 20., want: 7.071},
+		{input: 10., want: 7.071},
+		{input: 100., want: 35.355},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Peek(1000.) // must not be committed
+			got := sd.Peek(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(got, sd.Next(tc.input), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationUpdateLast(t *testing.T) {
+	sd, _ := NewStandardDeviation(3)
+	tests := []struct {
+		input  float64
+		update float64
+		want   float64
+	}{
+		{input: 10., update: 20., want: 0.},
+		{input: 10., update: 30., want: 5.},
+		{input: 0., update: 50., want: 12.472},
+		{input: 40., update: 20., want: 12.472},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			sd.Next(tc.input)
+			got := sd.UpdateLast(tc.update)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationUpdateLastEmpty(t *testing.T) {
+	sd, _ := NewStandardDeviation(3)
+	diff := cmp.Diff(0., sd.UpdateLast(10.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(5., sd.Next(20.), floatComparer)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestStandardDeviationCompute(t *testing.T) {
+	inputs := []float64{10., 20., 30., 20., 10., 100.}
+
+	stream, _ := NewStandardDeviation(4)
+	want := make([]float64, len(inputs))
+	for i, input := range inputs {
+		want[i] = stream.Next(input)
+	}
+
+	batch, _ := NewStandardDeviation(4)
+	out := make([]float64, len(inputs))
+	out[0] = batch.Next(inputs[0])
+	got := batch.Compute(inputs[1:], out[1:])
+	assert.Equal(t, want[1:], got, "must return the same values as Next")
+	assert.Same(t, &out[1], &got[0], "must reuse the output buffer")
+	assert.Equal(t, stream, batch, "must leave the indicator in the same state as Next")
+}
+
+func TestComputeStandardDeviation(t *testing.T) {
+	inputs := []float64{10., 20., 30., 20.}
+	tests := map[string]struct {
+		n       int
+		fillNaN bool
+		want    []float64
+		wantErr error
+	}{
+		"zero n":   {n: 0, want: nil, wantErr: ErrInvalidParameters},
+		"no fill":  {n: 3, fillNaN: false, want: []float64{0., 5., 8.165, 4.714}},
+		"fill NaN": {n: 3, fillNaN: true, want: []float64{math.NaN(), math.NaN(), 8.165, 4.714}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeStandardDeviation(tc.n, inputs, nil, tc.fillNaN)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, nanComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationClone(t *testing.T) {
+	sd, _ := NewStandardDeviation(4)
+	sd.Next(10.)
+	sd.Next(20.)
+
+	clone := sd.Clone()
+	assert.Equal(t, sd, clone, "clone must start from the same state")
+
+	tests := []struct {
+		indicator *StandardDeviation
+		input     float64
+		want      float64
+	}{
+		{indicator: clone, input: 30., want: 8.165},
+		{indicator: sd, input: 20., want: 4.714},
+		{indicator: clone, input: 20., want: 7.071},
+		{indicator: sd, input: 10., want: 5.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tc.indicator.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationOfFloat32(t *testing.T) {
+	sd, _ := NewStandardDeviationOf[float32](4)
+	tests := []struct {
+		input float32
+		want  float64
+	}{
+		{input: 10., want: 0.},
+		{input: 20., want: 5.},
+		{input: 30., want: 8.165},
+		{input: 20., want: 7.071},
+		{input: 10., want: 7.071},
+		{input: 100., want: 35.355},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, float64(got), floatComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationOfFloat32LongStream(t *testing.T) {
+	inputs := driftInputs[float32](3000007)
+
+	stream, _ := NewStandardDeviationOf[float32](10)
+	var got float32
+	for _, input := range inputs {
+		stream.Next(input + 1.)
+		got = stream.UpdateLast(input)
+	}
+	batch, _ := NewStandardDeviationOf[float32](10)
+	out := batch.Compute(inputs, nil)
+
+	fresh, _ := NewStandardDeviation(10)
+	var want float64
+	for _, input := range inputs[len(inputs)-10:] {
+		want = fresh.Next(float64(input))
+	}
+	assert.InEpsilon(t, want, float64(got), 1e-3, "Next and UpdateLast must match a fresh window")
+	assert.InEpsilon(t, want, float64(out[len(out)-1]), 1e-3, "Compute must match a fresh window")
+}
+
 func TestStandardDeviationString(t *testing.T) {
 	sd, _ := NewStandardDeviation(4)
 	want := "SD(4)"
@@ -116,3 +303,24 @@ func TestStandardDeviationString(t *testing.T) {
 		t.Fatalf(diff)
 	}
 }
+
+func BenchmarkStandardDeviationNext(b *testing.B) {
+	inputs := benchmarkInputs()
+	sd, _ := NewStandardDeviation(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			sd.Next(input)
+		}
+	}
+}
+
+func BenchmarkStandardDeviationCompute(b *testing.B) {
+	inputs := benchmarkInputs()
+	out := make([]float64, len(inputs))
+	sd, _ := NewStandardDeviation(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = sd.Compute(inputs, out)
+	}
+}
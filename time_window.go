@@ -0,0 +1,147 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "time"
+
+// TimedValueOf is an input of type T observed at Time, the input of the time-windowed indicators
+type TimedValueOf[T Float] struct {
+	Time  time.Time
+	Value T
+}
+
+// TimedValue is a TimedValueOf float64 values
+type TimedValue = TimedValueOf[float64]
+
+// timeWindow keeps the values observed within a duration d of the most recent one, that is at times
+// in (latest - d, latest]. Older values are evicted from the front as newer ones are pushed at the back.
+//
+// A value timestamped before the most recent one is taken to be observed at the time of the most recent
+// one, so the window always moves forward. Values with equal timestamps are all kept.
+type timeWindow[T Float] struct {
+	// duration of the window (must be greater than 0)
+	d time.Duration
+
+	values deque[TimedValueOf[T]]
+
+	// sequence number of the front value, counting every value pushed since the last reset
+	first int
+	// number of updates since the running sums of the indicator were last recomputed from values
+	updates int
+}
+
+func newTimeWindow[T Float](d time.Duration) timeWindow[T] {
+	return timeWindow[T]{
+		d: d,
+	}
+}
+
+// stamp returns input with its time moved forward to the time of the most recent value if it is earlier
+func (w *timeWindow[T]) stamp(input TimedValueOf[T]) TimedValueOf[T] {
+	if w.values.len() > 0 {
+		if latest := w.values.back().Time; input.Time.Before(latest) {
+			input.Time = latest
+		}
+	}
+	return input
+}
+
+// push adds input, which must have been stamped, at the back of the window
+func (w *timeWindow[T]) push(input TimedValueOf[T]) {
+	w.values.pushBack(input)
+}
+
+// unpush removes the most recent value so that input can replace it, and returns the removed value
+// and input, timestamped no earlier than the removed value
+func (w *timeWindow[T]) unpush(input TimedValueOf[T]) (last, replacement TimedValueOf[T]) {
+	last = w.values.popBack()
+	if input.Time.Before(last.Time) {
+		input.Time = last.Time
+	}
+	return last, input
+}
+
+// expired reports whether the i-th value from the front is out of the window once a value is observed at now
+func (w *timeWindow[T]) expired(i int, now time.Time) bool {
+	return i < w.values.len() && !w.values.at(i).Time.After(now.Add(-w.d))
+}
+
+// evict removes the front value, which has expired
+func (w *timeWindow[T]) evict() TimedValueOf[T] {
+	w.first++
+	return w.values.popFront()
+}
+
+// next returns the sequence number the next pushed value gets
+func (w *timeWindow[T]) next() int {
+	return w.first + w.values.len()
+}
+
+// bySequence returns the value with the given sequence number, which must be in the window
+func (w *timeWindow[T]) bySequence(seq int) TimedValueOf[T] {
+	return w.values.at(seq - w.first)
+}
+
+// due counts an update that leaves count values in the window and reports whether the running sums of the
+// indicator are to be recomputed from the values, which happens once every count updates so that their
+// rounding errors do not accumulate over long streams while updates still take amortised constant time
+func (w *timeWindow[T]) due(count int) bool {
+	w.updates++
+	if w.updates >= count {
+		w.updates = 0
+		return true
+	}
+	return false
+}
+
+// peekDue reports whether the next update, leaving count values in the window, would be due
+func (w *timeWindow[T]) peekDue(count int) bool {
+	return w.updates+1 >= count
+}
+
+// recent returns the k-th most recent of the values followed by last, last being the 0-th
+func (w *timeWindow[T]) recent(k int, last T) T {
+	if k == 0 {
+		return last
+	}
+	return w.values.at(w.values.len() - k).Value
+}
+
+// sumFrom returns the sum of the values from the i-th from the front onwards and of last, computed in float64
+func (w *timeWindow[T]) sumFrom(i int, last T) T {
+	sum := 0.
+	for k := 0; k <= w.values.len()-i; k++ {
+		sum += float64(w.recent(k, last))
+	}
+	return T(sum)
+}
+
+// momentsFrom returns the moments of the values from the i-th from the front onwards and of last,
+// computed in float64
+func (w *timeWindow[T]) momentsFrom(i int, last T) centralMoments[T] {
+	return centralMomentsOf(w.values.len()-i+1, func(k int) T { return w.recent(k, last) })
+}
+
+func (w *timeWindow[T]) reset() {
+	w.values.reset()
+	w.first = 0
+	w.updates = 0
+}
+
+func (w timeWindow[T]) clone() timeWindow[T] {
+	w.values = w.values.clone()
+	return w
+}
+
+// computeTimed feeds every value of inputs through next and writes the results to out,
+// see the Compute methods of the time-windowed indicators
+func computeTimed[T Float](next func(input TimedValueOf[T]) T, inputs []TimedValueOf[T], out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = next(input)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// at returns the time the given number of seconds after a fixed origin
+func at(seconds float64) time.Time {
+	return time.Date(2020, 1, 2, 9, 30, 0, 0, time.UTC).Add(time.Duration(seconds * float64(time.Second)))
+}
+
+func TestTimeWindowStamp(t *testing.T) {
+	w := newTimeWindow[float64](time.Minute)
+	assert.Equal(t, at(5.), w.stamp(TimedValue{Time: at(5.)}).Time, "must keep the time of the first value")
+
+	w.push(TimedValue{Time: at(5.), Value: 1.})
+	assert.Equal(t, at(5.), w.stamp(TimedValue{Time: at(3.)}).Time, "must move an earlier time to the most recent one")
+	assert.Equal(t, at(5.), w.stamp(TimedValue{Time: at(5.)}).Time, "must keep a duplicate time")
+	assert.Equal(t, at(6.), w.stamp(TimedValue{Time: at(6.)}).Time, "must keep a later time")
+}
+
+func TestTimeWindowExpired(t *testing.T) {
+	w := newTimeWindow[float64](3 * time.Second)
+	for _, seconds := range []float64{0., 1., 1., 2.5} {
+		w.push(TimedValue{Time: at(seconds)})
+	}
+
+	assert.False(t, w.expired(0, at(2.9)), "must keep values younger than the window")
+	assert.True(t, w.expired(0, at(3.)), "must evict values as old as the window")
+	assert.False(t, w.expired(1, at(3.)), "must keep values younger than the window")
+	assert.True(t, w.expired(2, at(4.)), "must evict every value with a duplicate time")
+	assert.False(t, w.expired(4, at(10.)), "must not report values past the back of the window")
+}
+
+func TestDeque(t *testing.T) {
+	var q deque[int]
+	for i := 0; i < 20; i++ {
+		q.pushBack(i)
+		if i%3 == 0 {
+			q.popFront()
+		}
+	}
+
+	want := []int{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	got := make([]int, q.len())
+	for i := range got {
+		got[i] = q.at(i)
+	}
+	assert.Equal(t, want, got, "must keep the elements in order across growth")
+	assert.Equal(t, 19, q.popBack())
+	assert.Equal(t, 7, q.front())
+	assert.Equal(t, 18, q.back())
+}
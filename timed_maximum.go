@@ -0,0 +1,159 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+TimedMaximumOf returns the highest of the values observed within a duration _d_ of the most recent one.
+It is the time-windowed counterpart of Maximum: values leave the window by age rather than by count.
+It keeps the candidates for the highest value in a monotonic queue, so each value is pushed and evicted
+at most once.
+
+A value timestamped before the most recent one is taken to be observed at the time of the most
+recent one. Values with equal timestamps are all kept.
+
+# Parameters
+
+* _d_ - duration of the window (greater than 0)
+
+# Example
+```
+max, _ := NewTimedMaximum(5 * time.Minute)
+max.Next(TimedValue{Time: tick.Time, Value: tick.Price})
+```
+*/
+type TimedMaximumOf[T Float] struct {
+	timeWindow[T]
+
+	// sequence numbers of the values that are > every later value, oldest first
+	candidates deque[int]
+}
+
+// TimedMaximum is a TimedMaximumOf float64 values
+type TimedMaximum = TimedMaximumOf[float64]
+
+// NewTimedMaximum creates a new TimedMaximum with the given window duration
+// Example: NewTimedMaximum(5 * time.Minute)
+func NewTimedMaximum(d time.Duration) (*TimedMaximum, error) {
+	return NewTimedMaximumOf[float64](d)
+}
+
+// NewTimedMaximumOf creates a new TimedMaximumOf values of type T with the given window duration
+// Example: NewTimedMaximumOf[float32](5 * time.Minute)
+func NewTimedMaximumOf[T Float](d time.Duration) (*TimedMaximumOf[T], error) {
+	if d <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &TimedMaximumOf[T]{
+		timeWindow: newTimeWindow[T](d),
+	}, nil
+}
+
+// Next takes the next input and returns the next TimedMaximum value
+func (m *TimedMaximumOf[T]) Next(input TimedValueOf[T]) T {
+	input = m.stamp(input)
+	for m.expired(0, input.Time) {
+		m.evict()
+	}
+	for m.candidates.len() > 0 && m.candidates.front() < m.first {
+		m.candidates.popFront()
+	}
+
+	m.push(input)
+	m.addCandidate(m.next() - 1)
+	return m.bySequence(m.candidates.front()).Value
+}
+
+// addCandidate adds the value with sequence number seq, the most recent one, to the candidates,
+// dropping the candidates it outlives
+func (m *TimedMaximumOf[T]) addCandidate(seq int) {
+	value := m.bySequence(seq).Value
+	for m.candidates.len() > 0 && m.bySequence(m.candidates.back()).Value <= value {
+		m.candidates.popBack()
+	}
+	m.candidates.pushBack(seq)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *TimedMaximumOf[T]) Peek(input TimedValueOf[T]) T {
+	input = m.stamp(input)
+	expired := 0
+	for m.expired(expired, input.Time) {
+		expired++
+	}
+
+	// the oldest candidate left in the window is its highest value
+	for i := 0; i < m.candidates.len(); i++ {
+		if seq := m.candidates.at(i); seq >= m.first+expired {
+			if value := m.bySequence(seq).Value; value > input.Value {
+				return value
+			}
+			break
+		}
+	}
+	return input.Value
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated TimedMaximum value.
+// The replacement is taken to be observed no earlier than the input it replaces, and values that left the
+// window are not restored. If no input has been committed yet it behaves like Next.
+func (m *TimedMaximumOf[T]) UpdateLast(input TimedValueOf[T]) T {
+	if m.values.len() == 0 {
+		return m.Next(input)
+	}
+
+	// the most recent value is always the last candidate; the values it outlived
+	// follow the candidate before it and become candidates again
+	_, input = m.unpush(input)
+	m.candidates.popBack()
+	from := m.first
+	if m.candidates.len() > 0 {
+		from = m.candidates.back() + 1
+	}
+	for seq := from; seq < m.next(); seq++ {
+		m.addCandidate(seq)
+	}
+
+	return m.Next(input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *TimedMaximumOf[T]) Compute(inputs []TimedValueOf[T], out []T) []T {
+	return computeTimed(m.Next, inputs, out)
+}
+
+// Reset resets the indicators to a clean state
+func (m *TimedMaximumOf[T]) Reset() {
+	m.reset()
+	m.candidates.reset()
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *TimedMaximumOf[T]) Clone() *TimedMaximumOf[T] {
+	clone := *m
+	clone.timeWindow = m.clone()
+	clone.candidates = m.candidates.clone()
+	return &clone
+}
+
+func (m *TimedMaximumOf[T]) String() string {
+	return fmt.Sprintf("TimedMax(%s)", m.d)
+}
+
+// ComputeTimedMaximum computes a TimedMaximum with window duration d over inputs and writes the results to out (see Compute)
+func ComputeTimedMaximum[T Float](d time.Duration, inputs []TimedValueOf[T], out []T) ([]T, error) {
+	m, err := NewTimedMaximumOf[T](d)
+	if err != nil {
+		return nil, err
+	}
+	return m.Compute(inputs, out), nil
+}
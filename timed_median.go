@@ -0,0 +1,138 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+TimedMedianOf returns the median of the values observed within a duration _d_ of the most recent one.
+It is the time-windowed counterpart of Median: values leave the window by age rather than by count,
+and the window is kept sorted as they do.
+
+# Formula
+
+See Median documentation, with the values observed in (t - d, t].
+
+A value timestamped before the most recent one is taken to be observed at the time of the most
+recent one. Values with equal timestamps are all kept.
+
+# Parameters
+
+* _d_ - duration of the window (greater than 0)
+
+# Example
+```
+median, _ := NewTimedMedian(5 * time.Minute)
+median.Next(TimedValue{Time: tick.Time, Value: tick.Price})
+```
+*/
+type TimedMedianOf[T Float] struct {
+	timeWindow[T]
+
+	// values of the window in ascending order
+	sorted []T
+}
+
+// TimedMedian is a TimedMedianOf float64 values
+type TimedMedian = TimedMedianOf[float64]
+
+// NewTimedMedian creates a new TimedMedian with the given window duration
+// Example: NewTimedMedian(5 * time.Minute)
+func NewTimedMedian(d time.Duration) (*TimedMedian, error) {
+	return NewTimedMedianOf[float64](d)
+}
+
+// NewTimedMedianOf creates a new TimedMedianOf values of type T with the given window duration
+// Example: NewTimedMedianOf[float32](5 * time.Minute)
+func NewTimedMedianOf[T Float](d time.Duration) (*TimedMedianOf[T], error) {
+	if d <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &TimedMedianOf[T]{
+		timeWindow: newTimeWindow[T](d),
+	}, nil
+}
+
+// Next takes the next input and returns the next TimedMedian value
+func (m *TimedMedianOf[T]) Next(input TimedValueOf[T]) T {
+	input = m.stamp(input)
+	for m.expired(0, input.Time) {
+		m.sorted = removeSorted(m.sorted, m.evict().Value)
+	}
+
+	m.push(input)
+	m.sorted = insertSorted(m.sorted, input.Value)
+	return quantile(m.sorted, 0.5)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (m *TimedMedianOf[T]) Peek(input TimedValueOf[T]) T {
+	input = m.stamp(input)
+	expired := 0
+	for ; m.expired(expired, input.Time); expired++ {
+		m.sorted = removeSorted(m.sorted, m.values.at(expired).Value)
+	}
+	m.sorted = insertSorted(m.sorted, input.Value)
+
+	median := quantile(m.sorted, 0.5)
+
+	// restore the window
+	m.sorted = removeSorted(m.sorted, input.Value)
+	for i := 0; i < expired; i++ {
+		m.sorted = insertSorted(m.sorted, m.values.at(i).Value)
+	}
+	return median
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated TimedMedian value.
+// The replacement is taken to be observed no earlier than the input it replaces, and values that left the
+// window are not restored. If no input has been committed yet it behaves like Next.
+func (m *TimedMedianOf[T]) UpdateLast(input TimedValueOf[T]) T {
+	if m.values.len() == 0 {
+		return m.Next(input)
+	}
+
+	last, input := m.unpush(input)
+	m.sorted = removeSorted(m.sorted, last.Value)
+	return m.Next(input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (m *TimedMedianOf[T]) Compute(inputs []TimedValueOf[T], out []T) []T {
+	return computeTimed(m.Next, inputs, out)
+}
+
+// Reset resets the indicators to a clean state
+func (m *TimedMedianOf[T]) Reset() {
+	m.reset()
+	m.sorted = nil
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (m *TimedMedianOf[T]) Clone() *TimedMedianOf[T] {
+	clone := *m
+	clone.timeWindow = m.clone()
+	clone.sorted = cloneSlice(m.sorted)
+	return &clone
+}
+
+func (m *TimedMedianOf[T]) String() string {
+	return fmt.Sprintf("TimedMedian(%s)", m.d)
+}
+
+// ComputeTimedMedian computes a TimedMedian with window duration d over inputs and writes the results to out (see Compute)
+func ComputeTimedMedian[T Float](d time.Duration, inputs []TimedValueOf[T], out []T) ([]T, error) {
+	m, err := NewTimedMedianOf[T](d)
+	if err != nil {
+		return nil, err
+	}
+	return m.Compute(inputs, out), nil
+}
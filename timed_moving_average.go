@@ -0,0 +1,145 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+TimedMovingAverageOf returns the average of the values observed within a duration _d_ of the most
+recent one, such as the mean price over the last 5 minutes of irregular ticks. It is the time-windowed
+counterpart of MovingAverage and Mean: values leave the window by age rather than by count. The sum is
+updated as they do and recomputed from the window once every N updates so that rounding errors do not
+accumulate over long streams.
+
+# Formula
+
+TimedMA<sub>t</sub> = Σ p<sub>i</sub> / N, for every p<sub>i</sub> observed in (t - d, t]
+
+Where:
+
+* _p<sub>i</sub>_ - input value observed at time _i_
+* _N_ - number of probes in observation.
+
+A value timestamped before the most recent one is taken to be observed at the time of the most
+recent one. Values with equal timestamps are all kept.
+
+# Parameters
+
+* _d_ - duration of the window (greater than 0)
+
+# Example
+```
+ma, _ := NewTimedMovingAverage(5 * time.Minute)
+ma.Next(TimedValue{Time: tick.Time, Value: tick.Price})
+```
+*/
+type TimedMovingAverageOf[T Float] struct {
+	timeWindow[T]
+
+	// internal parameters for calculations
+	sum T
+}
+
+// TimedMovingAverage is a TimedMovingAverageOf float64 values
+type TimedMovingAverage = TimedMovingAverageOf[float64]
+
+// NewTimedMovingAverage creates a new TimedMovingAverage with the given window duration
+// Example: NewTimedMovingAverage(5 * time.Minute)
+func NewTimedMovingAverage(d time.Duration) (*TimedMovingAverage, error) {
+	return NewTimedMovingAverageOf[float64](d)
+}
+
+// NewTimedMovingAverageOf creates a new TimedMovingAverageOf values of type T with the given window duration
+// Example: NewTimedMovingAverageOf[float32](5 * time.Minute)
+func NewTimedMovingAverageOf[T Float](d time.Duration) (*TimedMovingAverageOf[T], error) {
+	if d <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &TimedMovingAverageOf[T]{
+		timeWindow: newTimeWindow[T](d),
+	}, nil
+}
+
+// Next takes the next input and returns the next TimedMovingAverage value
+func (ma *TimedMovingAverageOf[T]) Next(input TimedValueOf[T]) T {
+	input = ma.stamp(input)
+	for ma.expired(0, input.Time) {
+		ma.sum -= ma.evict().Value
+	}
+
+	if ma.due(ma.values.len() + 1) {
+		ma.sum = ma.sumFrom(0, input.Value)
+	} else {
+		ma.sum += input.Value
+	}
+	ma.push(input)
+	return ma.sum / T(ma.values.len())
+}
+
+// Peek returns the value Next would return for input without committing it
+func (ma *TimedMovingAverageOf[T]) Peek(input TimedValueOf[T]) T {
+	input = ma.stamp(input)
+	sum, count := ma.sum, ma.values.len()
+	i := 0
+	for ; ma.expired(i, input.Time); i++ {
+		sum -= ma.values.at(i).Value
+		count--
+	}
+
+	if ma.peekDue(count + 1) {
+		return ma.sumFrom(i, input.Value) / T(count+1)
+	}
+	return (sum + input.Value) / T(count+1)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated TimedMovingAverage value.
+// The replacement is taken to be observed no earlier than the input it replaces, and values that left the
+// window are not restored. If no input has been committed yet it behaves like Next.
+func (ma *TimedMovingAverageOf[T]) UpdateLast(input TimedValueOf[T]) T {
+	if ma.values.len() == 0 {
+		return ma.Next(input)
+	}
+
+	last, input := ma.unpush(input)
+	ma.sum -= last.Value
+	return ma.Next(input)
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (ma *TimedMovingAverageOf[T]) Compute(inputs []TimedValueOf[T], out []T) []T {
+	return computeTimed(ma.Next, inputs, out)
+}
+
+// Reset resets the indicators to a clean state
+func (ma *TimedMovingAverageOf[T]) Reset() {
+	ma.reset()
+	ma.sum = 0
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (ma *TimedMovingAverageOf[T]) Clone() *TimedMovingAverageOf[T] {
+	clone := *ma
+	clone.timeWindow = ma.clone()
+	return &clone
+}
+
+func (ma *TimedMovingAverageOf[T]) String() string {
+	return fmt.Sprintf("TimedMA(%s)", ma.d)
+}
+
+// ComputeTimedMovingAverage computes a TimedMovingAverage with window duration d over inputs and writes the results to out (see Compute)
+func ComputeTimedMovingAverage[T Float](d time.Duration, inputs []TimedValueOf[T], out []T) ([]T, error) {
+	ma, err := NewTimedMovingAverageOf[T](d)
+	if err != nil {
+		return nil, err
+	}
+	return ma.Compute(inputs, out), nil
+}
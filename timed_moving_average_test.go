@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimedMovingAverage(t *testing.T) {
+	tests := map[string]struct {
+		input   time.Duration
+		want    *TimedMovingAverage
+		wantErr error
+	}{
+		"negative d": {input: -time.Second, want: nil, wantErr: ErrInvalidParameters},
+		"zero d":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive d": {input: time.Minute, want: &TimedMovingAverage{timeWindow: newTimeWindow[float64](time.Minute)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewTimedMovingAverage(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestTimedMovingAverageNext(t *testing.T) {
+	ma, _ := NewTimedMovingAverage(3 * time.Second)
+	tests := []struct {
+		input TimedValue
+		want  float64
+	}{
+		{input: TimedValue{Time: at(0.), Value: 2.}, want: 2.},
+		{input: TimedValue{Time: at(1.), Value: 8.}, want: 5.},
+		{input: TimedValue{Time: at(1.), Value: 0.}, want: 3.33333},
+		{input: TimedValue{Time: at(2.), Value: 4.}, want: 3.5},
+		{input: TimedValue{Time: at(5.), Value: 1.}, want: 1.},
+		{input: TimedValue{Time: at(5.), Value: 9.}, want: 5.},
+		{input: TimedValue{Time: at(6.), Value: 9.}, want: 6.33333},
+		{input: TimedValue{Time: at(4.), Value: 0.}, want: 4.75},
+		{input: TimedValue{Time: at(7.), Value: 3.}, want: 4.4},
+		{input: TimedValue{Time: at(12.), Value: 5.}, want: 5.},
+		{input: TimedValue{Time: at(12.5), Value: 7.}, want: 6.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := ma.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeTimedMovingAverage(t *testing.T) {
+	inputs := []TimedValue{
+		{Time: at(0.), Value: 2.},
+		{Time: at(1.), Value: 8.},
+		{Time: at(1.), Value: 0.},
+		{Time: at(2.), Value: 4.},
+		{Time: at(5.), Value: 1.},
+	}
+	tests := map[string]struct {
+		d       time.Duration
+		want    []float64
+		wantErr error
+	}{
+		"zero d":     {d: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive d": {d: 3 * time.Second, want: []float64{2., 5., 3.33333, 3.5, 1.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeTimedMovingAverage(tc.d, inputs, nil)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestTimedMovingAverageEviction(t *testing.T) {
+	tests := map[string]struct {
+		inputs []TimedValue
+		want   float64
+	}{
+		"exactly d old": {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(3.), Value: 8.}}, want: 8.},
+		"just inside d": {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(2.9), Value: 8.}}, want: 5.},
+		"out of order":  {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(2.5), Value: 8.}, {Time: at(1.), Value: 4.}, {Time: at(4.2), Value: 6.}}, want: 6.},
+		"equal times":   {inputs: []TimedValue{{Time: at(1.), Value: 2.}, {Time: at(1.), Value: 8.}, {Time: at(1.), Value: 5.}}, want: 5.},
+		"long gap":      {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(1.), Value: 8.}, {Time: at(2.), Value: 4.}, {Time: at(60.), Value: 7.}}, want: 7.},
+		"large offset":  {inputs: []TimedValue{{Time: at(0.), Value: 1e9 + 2.}, {Time: at(1.), Value: 1e9 + 8.}}, want: 1e9 + 5.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ma, _ := NewTimedMovingAverage(3 * time.Second)
+			got := ma.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestTimedMovingAverageOfFloat32LongStream(t *testing.T) {
+	ma, _ := NewTimedMovingAverageOf[float32](10 * time.Second)
+	fresh, _ := NewMovingAverage(10)
+	testTimedLongStream(t, ma, fresh, 10, 1e-6)
+}
+
+func TestTimedMovingAverageString(t *testing.T) {
+	ma, _ := NewTimedMovingAverage(5 * time.Minute)
+	assert.Equal(t, "TimedMA(5m0s)", ma.String())
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+/*
+TimedStandardDeviationOf returns the standard deviation of the values observed within a duration _d_ of
+the most recent one. It is the time-windowed counterpart of StandardDeviation: values leave the window
+by age rather than by count, and the moments are updated online as they do and recomputed from the
+window once every N updates so that rounding errors do not accumulate over long streams.
+
+# Formula
+
+See StandardDeviation documentation, with _N_ the number of values observed in (t - d, t].
+
+A value timestamped before the most recent one is taken to be observed at the time of the most
+recent one. Values with equal timestamps are all kept.
+
+# Parameters
+
+* _d_ - duration of the window (greater than 0)
+
+# Example
+```
+sd, _ := NewTimedStandardDeviation(5 * time.Minute)
+sd.Next(TimedValue{Time: tick.Time, Value: tick.Price})
+```
+*/
+type TimedStandardDeviationOf[T Float] struct {
+	timeWindow[T]
+
+	// internal parameters for calculations
+	moments centralMoments[T]
+}
+
+// TimedStandardDeviation is a TimedStandardDeviationOf float64 values
+type TimedStandardDeviation = TimedStandardDeviationOf[float64]
+
+// NewTimedStandardDeviation creates a new TimedStandardDeviation with the given window duration
+// Example: NewTimedStandardDeviation(5 * time.Minute)
+func NewTimedStandardDeviation(d time.Duration) (*TimedStandardDeviation, error) {
+	return NewTimedStandardDeviationOf[float64](d)
+}
+
+// NewTimedStandardDeviationOf creates a new TimedStandardDeviationOf values of type T with the given window duration
+// Example: NewTimedStandardDeviationOf[float32](5 * time.Minute)
+func NewTimedStandardDeviationOf[T Float](d time.Duration) (*TimedStandardDeviationOf[T], error) {
+	if d <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	return &TimedStandardDeviationOf[T]{
+		timeWindow: newTimeWindow[T](d),
+	}, nil
+}
+
+// Next takes the next input and returns the next TimedStandardDeviation value
+func (sd *TimedStandardDeviationOf[T]) Next(input TimedValueOf[T]) T {
+	input = sd.stamp(input)
+	for sd.expired(0, input.Time) {
+		sd.moments.remove(sd.evict().Value)
+	}
+
+	if sd.due(sd.values.len() + 1) {
+		sd.moments = sd.momentsFrom(0, input.Value)
+	} else {
+		sd.moments.add(input.Value)
+	}
+	sd.push(input)
+	return standardDeviation(sd.moments)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (sd *TimedStandardDeviationOf[T]) Peek(input TimedValueOf[T]) T {
+	input = sd.stamp(input)
+	moments := sd.moments
+	i := 0
+	for ; sd.expired(i, input.Time); i++ {
+		moments.remove(sd.values.at(i).Value)
+	}
+
+	if sd.peekDue(moments.count + 1) {
+		return standardDeviation(sd.momentsFrom(i, input.Value))
+	}
+	moments.add(input.Value)
+	return standardDeviation(moments)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated TimedStandardDeviation value.
+// The replacement is taken to be observed no earlier than the input it replaces, and values that left the
+// window are not restored. If no input has been committed yet it behaves like Next.
+func (sd *TimedStandardDeviationOf[T]) UpdateLast(input TimedValueOf[T]) T {
+	if sd.values.len() == 0 {
+		return sd.Next(input)
+	}
+
+	last, input := sd.unpush(input)
+	sd.moments.remove(last.Value)
+	return sd.Next(input)
+}
+
+// standardDeviation returns the population standard deviation of the values in moments
+func standardDeviation[T Float](moments centralMoments[T]) T {
+	if moments.count == 0 || moments.m2 <= 0 {
+		return 0
+	}
+	return T(math.Sqrt(float64(moments.m2 / T(moments.count))))
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (sd *TimedStandardDeviationOf[T]) Compute(inputs []TimedValueOf[T], out []T) []T {
+	return computeTimed(sd.Next, inputs, out)
+}
+
+// Reset resets the indicators to a clean state
+func (sd *TimedStandardDeviationOf[T]) Reset() {
+	sd.reset()
+	sd.moments = centralMoments[T]{}
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (sd *TimedStandardDeviationOf[T]) Clone() *TimedStandardDeviationOf[T] {
+	clone := *sd
+	clone.timeWindow = sd.clone()
+	return &clone
+}
+
+func (sd *TimedStandardDeviationOf[T]) String() string {
+	return fmt.Sprintf("TimedSD(%s)", sd.d)
+}
+
+// ComputeTimedStandardDeviation computes a TimedStandardDeviation with window duration d over inputs and writes the results to out (see Compute)
+func ComputeTimedStandardDeviation[T Float](d time.Duration, inputs []TimedValueOf[T], out []T) ([]T, error) {
+	sd, err := NewTimedStandardDeviationOf[T](d)
+	if err != nil {
+		return nil, err
+	}
+	return sd.Compute(inputs, out), nil
+}
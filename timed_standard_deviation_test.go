@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimedStandardDeviation(t *testing.T) {
+	tests := map[string]struct {
+		input   time.Duration
+		want    *TimedStandardDeviation
+		wantErr error
+	}{
+		"negative d": {input: -time.Second, want: nil, wantErr: ErrInvalidParameters},
+		"zero d":     {input: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive d": {input: time.Minute, want: &TimedStandardDeviation{timeWindow: newTimeWindow[float64](time.Minute)}, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewTimedStandardDeviation(tc.input)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			assert.Equal(t, tc.want, got, "must return the correct value")
+		})
+	}
+}
+
+func TestTimedStandardDeviationNext(t *testing.T) {
+	sd, _ := NewTimedStandardDeviation(3 * time.Second)
+	tests := []struct {
+		input TimedValue
+		want  float64
+	}{
+		{input: TimedValue{Time: at(0.), Value: 2.}, want: 0.},
+		{input: TimedValue{Time: at(1.), Value: 8.}, want: 3.},
+		{input: TimedValue{Time: at(1.), Value: 0.}, want: 3.39935},
+		{input: TimedValue{Time: at(2.), Value: 4.}, want: 2.95804},
+		{input: TimedValue{Time: at(5.), Value: 1.}, want: 0.},
+		{input: TimedValue{Time: at(5.), Value: 9.}, want: 4.},
+		{input: TimedValue{Time: at(6.), Value: 9.}, want: 3.77124},
+		{input: TimedValue{Time: at(4.), Value: 0.}, want: 4.26468},
+		{input: TimedValue{Time: at(7.), Value: 3.}, want: 3.87814},
+		{input: TimedValue{Time: at(12.), Value: 5.}, want: 0.},
+		{input: TimedValue{Time: at(12.5), Value: 7.}, want: 1.},
+	}
+	for _, tc := range tests {
+		t.Run("", func(t *testing.T) {
+			got := sd.Next(tc.input)
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeTimedStandardDeviation(t *testing.T) {
+	inputs := []TimedValue{
+		{Time: at(0.), Value: 2.},
+		{Time: at(1.), Value: 8.},
+		{Time: at(1.), Value: 0.},
+		{Time: at(2.), Value: 4.},
+		{Time: at(5.), Value: 1.},
+	}
+	tests := map[string]struct {
+		d       time.Duration
+		want    []float64
+		wantErr error
+	}{
+		"zero d":     {d: 0, want: nil, wantErr: ErrInvalidParameters},
+		"positive d": {d: 3 * time.Second, want: []float64{0., 3., 3.39935, 2.95804, 0.}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ComputeTimedStandardDeviation(tc.d, inputs, nil)
+			if tc.wantErr != nil { // only check error returned if expecting one
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+			}
+			diff := cmp.Diff(tc.want, got, approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestTimedStandardDeviationEviction(t *testing.T) {
+	tests := map[string]struct {
+		inputs []TimedValue
+		want   float64
+	}{
+		"exactly d old": {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(3.), Value: 8.}}, want: 0.},
+		"just inside d": {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(2.9), Value: 8.}}, want: 3.},
+		"out of order":  {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(2.5), Value: 8.}, {Time: at(1.), Value: 4.}, {Time: at(4.2), Value: 6.}}, want: 1.63299},
+		"equal times":   {inputs: []TimedValue{{Time: at(1.), Value: 2.}, {Time: at(1.), Value: 8.}, {Time: at(1.), Value: 5.}}, want: 2.44949},
+		"long gap":      {inputs: []TimedValue{{Time: at(0.), Value: 2.}, {Time: at(1.), Value: 8.}, {Time: at(2.), Value: 4.}, {Time: at(60.), Value: 7.}}, want: 0.},
+		"large offset":  {inputs: []TimedValue{{Time: at(0.), Value: 1e9 + 2.}, {Time: at(1.), Value: 1e9 + 8.}}, want: 3.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sd, _ := NewTimedStandardDeviation(3 * time.Second)
+			got := sd.Compute(tc.inputs, nil)
+			diff := cmp.Diff(tc.want, got[len(got)-1], approxComparer)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestTimedStandardDeviationOfFloat32LongStream(t *testing.T) {
+	sd, _ := NewTimedStandardDeviationOf[float32](10 * time.Second)
+	fresh, _ := NewStandardDeviation(10)
+	testTimedLongStream(t, sd, fresh, 10, 1e-3)
+}
+
+func TestTimedStandardDeviationString(t *testing.T) {
+	sd, _ := NewTimedStandardDeviation(5 * time.Minute)
+	assert.Equal(t, "TimedSD(5m0s)", sd.String())
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+YangZhangOf returns the Yang-Zhang volatility of the last _n_ bars. It combines the variance of the
+overnight jumps from the previous close to the open, the variance of the open-to-close returns and the
+RogersSatchell variance, which makes it unbiased both when prices drift and when they jump at the open.
+
+# Formula
+
+σ<sup>2</sup> = σ<sub>o</sub><sup>2</sup> + k·σ<sub>c</sub><sup>2</sup> + (1 - k)·σ<sub>rs</sub><sup>2</sup>, k = 0.34 / (1.34 + (N + 1) / (N - 1))
+
+Where:
+
+* _σ<sub>o</sub><sup>2</sup>_ - sample variance of ln(O<sub>i</sub> / C<sub>i-1</sub>), the overnight returns
+* _σ<sub>c</sub><sup>2</sup>_ - sample variance of ln(C<sub>i</sub> / O<sub>i</sub>), the open-to-close returns
+* _σ<sub>rs</sub><sup>2</sup>_ - Rogers-Satchell variance, see RogersSatchell
+* _N_ - number of bars in observation.
+
+The first bar, which has no previous close, is taken to open at the previous close. With a single
+bar in the window only the Rogers-Satchell variance is defined, and it is returned alone.
+The result is the volatility of a single period, see HistoricalVolatility to annualise it.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+yz, _ := NewYangZhang(20)
+yz.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5})
+```
+*/
+type YangZhangOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	overnight *StandardDeviationOf[T]
+	openClose *StandardDeviationOf[T]
+	rs        *MovingAverageOf[T]
+
+	// close of the most recent bar, and the close before it that its overnight return started from
+	lastClose     T
+	previousClose T
+}
+
+// YangZhang is a YangZhangOf float64 values
+type YangZhang = YangZhangOf[float64]
+
+// NewYangZhang creates a new YangZhang with the given number of periods
+// Example: NewYangZhang(20)
+func NewYangZhang(n int) (*YangZhang, error) {
+	return NewYangZhangOf[float64](n)
+}
+
+// NewYangZhangOf creates a new YangZhangOf values of type T with the given number of periods
+// Example: NewYangZhangOf[float32](20)
+func NewYangZhangOf[T Float](n int) (*YangZhangOf[T], error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	overnight, _ := NewStandardDeviationOf[T](n)
+	openClose, _ := NewStandardDeviationOf[T](n)
+	rs, _ := NewMovingAverageOf[T](n)
+	return &YangZhangOf[T]{
+		n: n,
+
+		overnight: overnight,
+		openClose: openClose,
+		rs:        rs,
+	}, nil
+}
+
+// Next takes the next bar and returns the next YangZhang value
+func (yz *YangZhangOf[T]) Next(bar BarOf[T]) T {
+	if yz.rs.count == 0 {
+		yz.previousClose = bar.Open
+	} else {
+		yz.previousClose = yz.lastClose
+	}
+	yz.lastClose = bar.Close
+
+	overnight := yz.overnight.Next(logRatio(bar.Open, yz.previousClose))
+	openClose := yz.openClose.Next(logRatio(bar.Close, bar.Open))
+	rs := yz.rs.Next(rogersSatchellTerm(bar))
+	return yangZhang(yz.rs.count, overnight, openClose, rs)
+}
+
+// Peek returns the value Next would return for bar without committing it
+func (yz *YangZhangOf[T]) Peek(bar BarOf[T]) T {
+	previousClose := yz.lastClose
+	if yz.rs.count == 0 {
+		previousClose = bar.Open
+	}
+
+	count := yz.rs.count
+	if count < yz.n {
+		count++
+	}
+
+	return yangZhang(count,
+		yz.overnight.Peek(logRatio(bar.Open, previousClose)),
+		yz.openClose.Peek(logRatio(bar.Close, bar.Open)),
+		yz.rs.Peek(rogersSatchellTerm(bar)))
+}
+
+// UpdateLast replaces the most recent bar with bar, such as a bar still in progress,
+// and returns the updated YangZhang value. If no bar has been committed yet it behaves like Next.
+func (yz *YangZhangOf[T]) UpdateLast(bar BarOf[T]) T {
+	if yz.rs.count == 0 {
+		return yz.Next(bar)
+	}
+
+	if yz.rs.count == 1 {
+		yz.previousClose = bar.Open
+	}
+	yz.lastClose = bar.Close
+
+	return yangZhang(yz.rs.count,
+		yz.overnight.UpdateLast(logRatio(bar.Open, yz.previousClose)),
+		yz.openClose.UpdateLast(logRatio(bar.Close, bar.Open)),
+		yz.rs.UpdateLast(rogersSatchellTerm(bar)))
+}
+
+// yangZhang combines the population standard deviations of the overnight and open-to-close returns
+// and the Rogers-Satchell variance of the count bars in the window
+func yangZhang[T Float](count int, overnight, openClose, rs T) T {
+	if count < 2 {
+		return volatility(rs)
+	}
+
+	m := T(count)
+	k := 0.34 / (1.34 + (m+1)/(m-1))
+	sample := m / (m - 1)
+	return volatility(sample*overnight*overnight + k*sample*openClose*openClose + (1-k)*rs)
+}
+
+// Compute feeds every bar of bars through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of bars.
+func (yz *YangZhangOf[T]) Compute(bars []BarOf[T], out []T) []T {
+	return computeBars(yz.Next, bars, out)
+}
+
+// Reset resets the indicators to a clean state
+func (yz *YangZhangOf[T]) Reset() {
+	yz.overnight.Reset()
+	yz.openClose.Reset()
+	yz.rs.Reset()
+
+	yz.lastClose = 0
+	yz.previousClose = 0
+}
+
+// Clone returns a deep copy of the indicator, including its windows
+func (yz *YangZhangOf[T]) Clone() *YangZhangOf[T] {
+	clone := *yz
+	clone.overnight = yz.overnight.Clone()
+	clone.openClose = yz.openClose.Clone()
+	clone.rs = yz.rs.Clone()
+	return &clone
+}
+
+func (yz *YangZhangOf[T]) String() string {
+	return fmt.Sprintf("YangZhang(%d)", yz.n)
+}
+
+// ComputeYangZhang computes a YangZhang with n periods over bars and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeYangZhang[T Float](n int, bars []BarOf[T], out []T, fillNaN bool) ([]T, error) {
+	yz, err := NewYangZhangOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = yz.Compute(bars, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}
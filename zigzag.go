@@ -0,0 +1,230 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+// PivotOf is a swing high or low of a series of bars of values of type T
+type PivotOf[T Float] struct {
+	// Index is the index of the bar of the swing, counted from 0 for the first bar
+	Index int
+	// Price is the high of the bar of a swing high, or the low of the bar of a swing low
+	Price T
+	// High is true for a swing high and false for a swing low
+	High bool
+}
+
+// Pivot is a PivotOf float64 values
+type Pivot = PivotOf[float64]
+
+/*
+ZigZagOf connects the swings of bars that reverse by more than a percentage of the price, or a multiple of
+the AverageTrueRange of the bars. It follows the highest high of a rising leg, or the lowest low of a falling
+one, and confirms it as a pivot when a bar reverses far enough from it, starting the next leg from that bar.
+
+Until the first reversal, the highest high and lowest low of the bars so far are followed and the earlier one
+is confirmed. With an ATR threshold no pivot is confirmed until the AverageTrueRange is seeded.
+
+# Parameters
+
+* _percent_ - reversal in percent of the price of the swing (greater than 0, usually 5)
+* _n_ - number of periods of the AverageTrueRange (integer greater than 0)
+* _multiplier_ - reversal in multiples of the AverageTrueRange (greater than 0)
+
+# Example
+```
+zigzag, _ := NewZigZag(5.)
+if pivot, ok := zigzag.Next(Bar{Open: 10., High: 11., Low: 9.5, Close: 10.5}); ok {
+fmt.Println(pivot.Index, pivot.Price, pivot.High)
+}
+```
+*/
+type ZigZagOf[T Float] struct {
+	// reversal in percent, or in multiples of the AverageTrueRange
+	percent    T
+	atr        *AverageTrueRangeOf[T]
+	multiplier T
+
+	// internal parameters for calculations
+	count int
+	// direction of the leg in progress: 1 rising, -1 falling, 0 before the first reversal
+	direction int
+	// extremes of the leg in progress
+	high, low PivotOf[T]
+}
+
+// ZigZag is a ZigZagOf float64 values
+type ZigZag = ZigZagOf[float64]
+
+// NewZigZag creates a new ZigZag with the given reversal in percent
+// Example: NewZigZag(5.)
+func NewZigZag(percent float64) (*ZigZag, error) {
+	return NewZigZagOf(percent)
+}
+
+// NewZigZagOf creates a new ZigZagOf values of type T with the given reversal in percent
+// Example: NewZigZagOf[float32](5.)
+func NewZigZagOf[T Float](percent T) (*ZigZagOf[T], error) {
+	if !(percent > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &ZigZagOf[T]{
+		percent: percent,
+	}, nil
+}
+
+// NewATRZigZag creates a new ZigZag with a reversal of multiplier times the AverageTrueRange of n periods
+// Example: NewATRZigZag(14, 3.)
+func NewATRZigZag(n int, multiplier float64) (*ZigZag, error) {
+	return NewATRZigZagOf(n, multiplier)
+}
+
+// NewATRZigZagOf creates a new ZigZagOf values of type T with a reversal of multiplier times
+// the AverageTrueRange of n periods
+// Example: NewATRZigZagOf[float32](14, 3.)
+func NewATRZigZagOf[T Float](n int, multiplier T) (*ZigZagOf[T], error) {
+	if !(multiplier > 0) {
+		return nil, ErrInvalidParameters
+	}
+
+	atr, err := NewAverageTrueRangeOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZigZagOf[T]{
+		atr:        atr,
+		multiplier: multiplier,
+	}, nil
+}
+
+// Next takes the next bar and returns the pivot it confirms, if any
+func (z *ZigZagOf[T]) Next(bar BarOf[T]) (PivotOf[T], bool) {
+	index := z.count
+	z.count++
+
+	var atr T
+	if z.atr != nil {
+		atr = z.atr.Next(bar)
+		if z.atr.count < z.atr.n {
+			atr = 0
+		}
+	}
+
+	high := PivotOf[T]{Index: index, Price: bar.High, High: true}
+	low := PivotOf[T]{Index: index, Price: bar.Low}
+	if index == 0 {
+		z.high, z.low = high, low
+		return PivotOf[T]{}, false
+	}
+
+	switch z.direction {
+	case 1:
+		if bar.High > z.high.Price {
+			z.high = high
+		} else if z.reversed(z.high.Price, bar.Low, atr) {
+			z.direction, z.low = -1, low
+			return z.high, true
+		}
+	case -1:
+		if bar.Low < z.low.Price {
+			z.low = low
+		} else if z.reversed(z.low.Price, bar.High, atr) {
+			z.direction, z.high = 1, high
+			return z.low, true
+		}
+	default:
+		if bar.High > z.high.Price {
+			z.high = high
+		}
+		if bar.Low < z.low.Price {
+			z.low = low
+		}
+		if z.high.Index == z.low.Index {
+			break
+		}
+
+		if z.high.Index < z.low.Index && z.reversed(z.high.Price, z.low.Price, atr) {
+			z.direction = -1
+			return z.high, true
+		}
+		if z.low.Index < z.high.Index && z.reversed(z.low.Price, z.high.Price, atr) {
+			z.direction = 1
+			return z.low, true
+		}
+	}
+	return PivotOf[T]{}, false
+}
+
+// reversed reports whether the move from the price of a swing to price reaches the threshold, given
+// the current AverageTrueRange, or 0 while it is not seeded
+func (z *ZigZagOf[T]) reversed(from, to, atr T) bool {
+	move := to - from
+	if move < 0 {
+		move = -move
+	}
+
+	if z.atr == nil {
+		threshold := from * z.percent / 100
+		if threshold < 0 {
+			threshold = -threshold
+		}
+		return move >= threshold
+	}
+	return atr > 0 && move >= z.multiplier*atr
+}
+
+// Current returns the extreme of the leg in progress, the pivot the next reversal would confirm.
+// It returns false before the first reversal.
+func (z *ZigZagOf[T]) Current() (PivotOf[T], bool) {
+	switch z.direction {
+	case 1:
+		return z.high, true
+	case -1:
+		return z.low, true
+	default:
+		return PivotOf[T]{}, false
+	}
+}
+
+// Compute feeds every bar of bars through Next and appends the pivots it confirms to out[:0],
+// reusing out when it has enough capacity
+func (z *ZigZagOf[T]) Compute(bars []BarOf[T], out []PivotOf[T]) []PivotOf[T] {
+	out = out[:0]
+	for _, bar := range bars {
+		if pivot, ok := z.Next(bar); ok {
+			out = append(out, pivot)
+		}
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (z *ZigZagOf[T]) Reset() {
+	if z.atr != nil {
+		z.atr.Reset()
+	}
+	z.count = 0
+	z.direction = 0
+	z.high, z.low = PivotOf[T]{}, PivotOf[T]{}
+}
+
+// Clone returns a deep copy of the indicator, including its AverageTrueRange
+func (z *ZigZagOf[T]) Clone() *ZigZagOf[T] {
+	clone := *z
+	if z.atr != nil {
+		clone.atr = z.atr.Clone()
+	}
+	return &clone
+}
+
+func (z *ZigZagOf[T]) String() string {
+	if z.atr != nil {
+		return fmt.Sprintf("ZigZag(%s,%g)", z.atr, float64(z.multiplier))
+	}
+	return fmt.Sprintf("ZigZag(%g%%)", float64(z.percent))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var zigzagBars = highLowBars(
+	[]float64{100., 105., 110., 108., 100., 97., 101., 103.},
+	[]float64{95., 100., 104., 98., 90., 92., 96., 99.},
+)
+
+func TestNewZigZag(t *testing.T) {
+	tests := map[string]struct {
+		new     func() (*ZigZag, error)
+		wantErr error
+	}{
+		"zero percent":        {new: func() (*ZigZag, error) { return NewZigZag(0.) }, wantErr: ErrInvalidParameters},
+		"NaN percent":         {new: func() (*ZigZag, error) { return NewZigZag(math.NaN()) }, wantErr: ErrInvalidParameters},
+		"zero n":              {new: func() (*ZigZag, error) { return NewATRZigZag(0, 3.) }, wantErr: ErrInvalidParameters},
+		"negative multiplier": {new: func() (*ZigZag, error) { return NewATRZigZag(14, -1.) }, wantErr: ErrInvalidParameters},
+		"percent":             {new: func() (*ZigZag, error) { return NewZigZag(5.) }, wantErr: nil},
+		"ATR":                 {new: func() (*ZigZag, error) { return NewATRZigZag(14, 3.) }, wantErr: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := tc.new()
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestZigZagNext(t *testing.T) {
+	zigzag, _ := NewZigZag(10.)
+	want := map[int]Pivot{
+		1: {Index: 0, Price: 95., High: false},
+		3: {Index: 2, Price: 110., High: true},
+		6: {Index: 4, Price: 90., High: false},
+	}
+	for i, bar := range zigzagBars {
+		pivot, ok := zigzag.Next(bar)
+		wantPivot, wantOK := want[i]
+		assert.Equal(t, wantOK, ok, "bar %d", i)
+		assert.Equal(t, wantPivot, pivot, "bar %d", i)
+	}
+
+	current, ok := zigzag.Current()
+	assert.True(t, ok)
+	assert.Equal(t, Pivot{Index: 7, Price: 103., High: true}, current, "must follow the leg in progress")
+}
+
+func TestZigZagATR(t *testing.T) {
+	bars := []Bar{
+		{High: 11., Low: 9., Close: 10.},
+		{High: 13., Low: 11., Close: 12.},
+		{High: 15., Low: 13., Close: 14.},
+		{High: 12., Low: 10., Close: 11.},
+		{High: 10., Low: 8., Close: 9.},
+	}
+
+	zigzag, _ := NewATRZigZag(2, 1.)
+	want := []Pivot{{Index: 0, Price: 9.}, {Index: 2, Price: 15., High: true}}
+	assert.Equal(t, want, zigzag.Compute(bars, nil))
+	current, _ := zigzag.Current()
+	assert.Equal(t, Pivot{Index: 4, Price: 8.}, current)
+
+	zigzag, _ = NewATRZigZag(3, 1.)
+	_, ok := zigzag.Next(bars[0])
+	assert.False(t, ok)
+	_, ok = zigzag.Next(bars[1])
+	assert.False(t, ok, "must not confirm a pivot before the AverageTrueRange is seeded")
+	pivot, ok := zigzag.Next(bars[2])
+	assert.True(t, ok)
+	assert.Equal(t, Pivot{Index: 0, Price: 9.}, pivot)
+}
+
+func TestZigZagCurrent(t *testing.T) {
+	zigzag, _ := NewZigZag(50.)
+	zigzag.Compute(zigzagBars, nil)
+	_, ok := zigzag.Current()
+	assert.False(t, ok, "must not return a leg before the first reversal")
+}
+
+func TestZigZagCompute(t *testing.T) {
+	zigzag, _ := NewZigZag(10.)
+	out := make([]Pivot, 1, 10)
+	got := zigzag.Compute(zigzagBars, out)
+	want := []Pivot{{Index: 0, Price: 95.}, {Index: 2, Price: 110., High: true}, {Index: 4, Price: 90.}}
+	assert.Equal(t, want, got)
+	assert.Same(t, &out[0], &got[0], "must reuse the output buffer")
+}
+
+func TestZigZagClone(t *testing.T) {
+	zigzag, _ := NewATRZigZag(2, 1.)
+	zigzag.Compute(zigzagBars[:5], nil)
+	want, _ := NewATRZigZag(2, 1.)
+	want.Compute(zigzagBars[:5], nil)
+
+	clone := zigzag.Clone()
+	assert.Equal(t, want.Clone().Compute(zigzagBars[5:], nil), clone.Compute(zigzagBars[5:], nil),
+		"clone must continue from the same state")
+	assert.Equal(t, want, zigzag, "original must not see the clone's inputs")
+}
+
+func TestZigZagReset(t *testing.T) {
+	zigzag, _ := NewATRZigZag(2, 1.)
+	zigzag.Compute(zigzagBars, nil)
+	zigzag.Reset()
+
+	want, _ := NewATRZigZag(2, 1.)
+	assert.Equal(t, want, zigzag, "must return to a clean state")
+}
+
+func TestZigZagString(t *testing.T) {
+	zigzag, _ := NewZigZag(5.)
+	assert.Equal(t, "ZigZag(5%)", zigzag.String())
+	zigzag, _ = NewATRZigZag(14, 3.)
+	assert.Equal(t, "ZigZag(ATR(14),3)", zigzag.String())
+}
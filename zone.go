@@ -0,0 +1,165 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+ZoneOf reports when a series enters or leaves a zone between two levels, such as an oscillator
+entering its oversold zone below 30.
+
+# Formula
+
+The series is in the zone when lower ≤ x ≤ upper and out of it when x < lower - h or x > upper + h,
+otherwise it stays on its previous side. The series starts out of the zone, so EnterZone is reported
+for a first input in the zone.
+
+Where:
+
+* _h_ - hysteresis band, see WithHysteresis
+
+# Parameters
+
+* _lower_ - lower level of the zone, which may be -Inf
+* _upper_ - upper level of the zone (greater than lower), which may be +Inf
+* _options_ - optional SignalOption values: WithHysteresis, WithMinBars and WithConfirmation
+
+# Example
+```
+oversold, _ := NewZone(math.Inf(-1), 30.)
+oversold.Next(25.)
+```
+*/
+type ZoneOf[T Float] struct {
+	lower  T
+	upper  T
+	config signalConfig
+
+	// internal parameters for calculations
+	filter signalFilter
+	count  int
+
+	// filter before the most recent input, used to replace it
+	previous signalFilter
+}
+
+// Zone is a ZoneOf float64 values
+type Zone = ZoneOf[float64]
+
+// NewZone creates a new Zone between the given levels with the given options
+// Example: NewZone(70., math.Inf(1))
+func NewZone(lower, upper float64, options ...SignalOption) (*Zone, error) {
+	return NewZoneOf[float64](lower, upper, options...)
+}
+
+// NewZoneOf creates a new ZoneOf values of type T between the given levels with the given options
+// Example: NewZoneOf[float32](70., float32(math.Inf(1)))
+func NewZoneOf[T Float](lower, upper T, options ...SignalOption) (*ZoneOf[T], error) {
+	config, err := newSignalConfig(options)
+	if !(lower < upper) || err != nil {
+		return nil, ErrInvalidParameters
+	}
+
+	return &ZoneOf[T]{
+		lower:  lower,
+		upper:  upper,
+		config: config,
+
+		filter: newSignalFilter(config, -1),
+	}, nil
+}
+
+// Next takes the next input and returns the Signal of the series entering or leaving the zone
+func (z *ZoneOf[T]) Next(input T) Signal {
+	z.previous = z.filter
+	z.count++
+	return zoneSignal(z.filter.next(z.side(input)))
+}
+
+// Peek returns the value Next would return for input without committing it
+func (z *ZoneOf[T]) Peek(input T) Signal {
+	filter := z.filter
+	return zoneSignal(filter.next(z.side(input)))
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated Signal.
+// If no input has been committed yet it behaves like Next.
+func (z *ZoneOf[T]) UpdateLast(input T) Signal {
+	if z.count == 0 {
+		return z.Next(input)
+	}
+
+	z.filter = z.previous
+	return zoneSignal(z.filter.next(z.side(input)))
+}
+
+// InZone reports whether the series is in the zone, as of the last reported change
+func (z *ZoneOf[T]) InZone() bool {
+	return z.filter.side == 1
+}
+
+// side returns 1 if input is in the zone, -1 if it is beyond the hysteresis band around it, or 0
+func (z *ZoneOf[T]) side(input T) int {
+	band := T(z.config.band)
+	switch {
+	case input >= z.lower && input <= z.upper:
+		return 1
+	case input < z.lower-band || input > z.upper+band:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (z *ZoneOf[T]) Compute(inputs []T, out []Signal) []Signal {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = z.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (z *ZoneOf[T]) Reset() {
+	z.filter = newSignalFilter(z.config, -1)
+	z.previous = signalFilter{}
+	z.count = 0
+}
+
+// Clone returns a deep copy of the indicator that can be advanced independently
+func (z *ZoneOf[T]) Clone() *ZoneOf[T] {
+	clone := *z
+	return &clone
+}
+
+func (z *ZoneOf[T]) String() string {
+	return z.config.name("Zone", fmt.Sprintf("%g", float64(z.lower)), fmt.Sprintf("%g", float64(z.upper)))
+}
+
+// ComputeZone computes a Zone between the given levels with the given options over inputs and writes
+// the results to out (see Compute)
+func ComputeZone[T Float](lower, upper T, inputs []T, out []Signal, options ...SignalOption) ([]Signal, error) {
+	z, err := NewZoneOf[T](lower, upper, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return z.Compute(inputs, out), nil
+}
+
+// zoneSignal returns the Signal of a change to side
+func zoneSignal(side int) Signal {
+	switch side {
+	case 1:
+		return EnterZone
+	case -1:
+		return ExitZone
+	default:
+		return NoSignal
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewZone(t *testing.T) {
+	tests := map[string]struct {
+		lower   float64
+		upper   float64
+		options []SignalOption
+		wantErr error
+	}{
+		"inverted levels": {lower: 70., upper: 30., wantErr: ErrInvalidParameters},
+		"equal levels":    {lower: 30., upper: 30., wantErr: ErrInvalidParameters},
+		"NaN level":       {lower: math.NaN(), upper: 30., wantErr: ErrInvalidParameters},
+		"invalid option":  {lower: 30., upper: 70., options: []SignalOption{WithHysteresis(-1.)}, wantErr: ErrInvalidParameters},
+		"bounded":         {lower: 30., upper: 70.},
+		"unbounded":       {lower: math.Inf(-1), upper: 30.},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NewZone(tc.lower, tc.upper, tc.options...)
+			if tc.wantErr != nil {
+				assert.EqualError(t, gotErr, tc.wantErr.Error(), "must return the correct error")
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.False(t, got.InZone(), "must start out of the zone")
+		})
+	}
+}
+
+func TestZoneNext(t *testing.T) {
+	tests := map[string]struct {
+		options []SignalOption
+		inputs  []float64
+		want    []Signal
+	}{
+		"without hysteresis": {
+			inputs: []float64{50., 80., 72., 60., 28., 20., 40.},
+			want:   []Signal{EnterZone, ExitZone, NoSignal, EnterZone, ExitZone, NoSignal, EnterZone},
+		},
+		"with hysteresis": {
+			options: []SignalOption{WithHysteresis(5.)},
+			inputs:  []float64{50., 80., 72., 60., 28., 20., 40.},
+			want:    []Signal{EnterZone, ExitZone, NoSignal, EnterZone, NoSignal, ExitZone, EnterZone},
+		},
+		"with confirmation": {
+			options: []SignalOption{WithConfirmation(2)},
+			inputs:  []float64{50., 60., 80., 60., 80., 90.},
+			want:    []Signal{NoSignal, EnterZone, NoSignal, NoSignal, NoSignal, ExitZone},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone, _ := NewZone(30., 70., tc.options...)
+			got := zone.Compute(tc.inputs, nil)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestZoneOversold(t *testing.T) {
+	oversold, _ := NewZone(math.Inf(-1), 30.)
+	got, _ := ComputeZone(math.Inf(-1), 30., []float64{45., 25., 20., 35.}, nil)
+	assert.Equal(t, []Signal{NoSignal, EnterZone, NoSignal, ExitZone}, got)
+
+	oversold.Next(25.)
+	assert.True(t, oversold.InZone())
+	oversold.Next(35.)
+	assert.False(t, oversold.InZone())
+}
+
+func TestZonePeek(t *testing.T) {
+	zone, _ := NewZone(30., 70.)
+	for _, input := range []float64{50., 80., 72., 60., 28., 20., 40.} {
+		zone.Peek(100. - input) // must not be committed
+		got := zone.Peek(input)
+		assert.Equal(t, got, zone.Next(input), "must return the same value as Next")
+	}
+}
+
+func TestZoneUpdateLast(t *testing.T) {
+	zone, _ := NewZone(30., 70.)
+	assert.Equal(t, EnterZone, zone.UpdateLast(50.), "must behave like Next when empty")
+	assert.Equal(t, NoSignal, zone.UpdateLast(80.), "must undo the entry")
+	assert.False(t, zone.InZone())
+	assert.Equal(t, EnterZone, zone.UpdateLast(60.), "must redo the entry")
+	assert.Equal(t, ExitZone, zone.Next(20.))
+}
+
+func TestZoneClone(t *testing.T) {
+	zone, _ := NewZone(30., 70.)
+	zone.Next(50.)
+	want, _ := NewZone(30., 70.)
+	want.Next(50.)
+
+	clone := zone.Clone()
+	assert.Equal(t, ExitZone, clone.Next(80.), "clone must continue from the same state")
+	assert.Equal(t, want, zone, "original must not see the clone's inputs")
+}
+
+func TestZoneReset(t *testing.T) {
+	zone, _ := NewZone(30., 70., WithConfirmation(2))
+	zone.Compute([]float64{50., 60., 80.}, nil)
+	zone.Reset()
+
+	want, _ := NewZone(30., 70., WithConfirmation(2))
+	assert.Equal(t, want, zone, "must return to a clean state")
+}
+
+func TestZoneString(t *testing.T) {
+	zone, _ := NewZone(30., 70.)
+	assert.Equal(t, "Zone(30,70)", zone.String())
+	zone, _ = NewZone(math.Inf(-1), 30., WithHysteresis(2.))
+	assert.Equal(t, "Zone(-Inf,30,2,0,1)", zone.String())
+}
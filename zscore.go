@@ -0,0 +1,151 @@
+/*
+Copyright 2020 Binh Nguyen
+Licensed under terms of MIT license (see LICENSE)
+*/
+
+package tago
+
+import "fmt"
+
+/*
+ZScoreOf returns how many standard deviations the input is away from the mean of the last n values,
+the input included. The mean and standard deviation come from a StandardDeviation over the same window.
+
+# Formula
+
+z = (x - x̄) / σ
+
+Where:
+
+* _x_ - input value
+* _x̄_ - mean of the last n values
+* _σ_ - standard deviation of the last n values, see StandardDeviation
+
+The z-score is 0 while the standard deviation is 0, and for a window of equal values.
+
+# Parameters
+
+* _n_ - number of periods (integer greater than 0)
+
+# Example
+```
+z, _ := NewZScore(20)
+z.Next(10.)
+```
+*/
+type ZScoreOf[T Float] struct {
+	// number of periods (must be an integer greater than 0)
+	n int
+
+	// internal parameters for calculations
+	sd  *StandardDeviationOf[T]
+	run equalRun[T]
+}
+
+// ZScore is a ZScoreOf float64 values
+type ZScore = ZScoreOf[float64]
+
+// NewZScore creates a new ZScore with the given number of periods
+// Example: NewZScore(20)
+func NewZScore(n int) (*ZScore, error) {
+	return NewZScoreOf[float64](n)
+}
+
+// NewZScoreOf creates a new ZScoreOf values of type T with the given number of periods
+// Example: NewZScoreOf[float32](20)
+func NewZScoreOf[T Float](n int) (*ZScoreOf[T], error) {
+	sd, err := NewStandardDeviationOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZScoreOf[T]{
+		n: n,
+
+		sd: sd,
+	}, nil
+}
+
+// Next takes the next input and returns the next ZScore value
+func (z *ZScoreOf[T]) Next(input T) T {
+	sd := z.sd.Next(input)
+	z.run.push(input)
+	if z.run.length >= z.sd.count {
+		return 0
+	}
+	return zscore(input, z.sd.Mean(), sd)
+}
+
+// Peek returns the value Next would return for input without committing it
+func (z *ZScoreOf[T]) Peek(input T) T {
+	count := z.sd.count
+	if count < z.n {
+		count++
+	}
+	if z.run.peek(input) >= count {
+		return 0
+	}
+
+	mean, sd := z.sd.peek(input)
+	return zscore(input, mean, sd)
+}
+
+// UpdateLast replaces the most recent input with input and returns the updated ZScore value.
+// If no input has been committed yet it behaves like Next.
+func (z *ZScoreOf[T]) UpdateLast(input T) T {
+	sd := z.sd.UpdateLast(input)
+	z.run.replaceLast(input)
+	if z.run.length >= z.sd.count {
+		return 0
+	}
+	return zscore(input, z.sd.Mean(), sd)
+}
+
+func zscore[T Float](input, mean, sd T) T {
+	if sd == 0 {
+		return 0
+	}
+	return (input - mean) / sd
+}
+
+// Compute feeds every value of inputs through Next and writes the results to out, which is reused
+// when it has enough capacity. It returns out resliced to the length of inputs.
+func (z *ZScoreOf[T]) Compute(inputs, out []T) []T {
+	out = resize(out, len(inputs))
+	for i, input := range inputs {
+		out[i] = z.Next(input)
+	}
+	return out
+}
+
+// Reset resets the indicators to a clean state
+func (z *ZScoreOf[T]) Reset() {
+	z.sd.Reset()
+	z.run = equalRun[T]{}
+}
+
+// Clone returns a deep copy of the indicator, including its StandardDeviation
+func (z *ZScoreOf[T]) Clone() *ZScoreOf[T] {
+	clone := *z
+	clone.sd = z.sd.Clone()
+	return &clone
+}
+
+func (z *ZScoreOf[T]) String() string {
+	return fmt.Sprintf("ZScore(%d)", z.n)
+}
+
+// ComputeZScore computes a ZScore with n periods over inputs and writes the results to out (see Compute).
+// If fillNaN is true the first n-1 results, produced before the window is full, are set to NaN.
+func ComputeZScore[T Float](n int, inputs, out []T, fillNaN bool) ([]T, error) {
+	z, err := NewZScoreOf[T](n)
+	if err != nil {
+		return nil, err
+	}
+
+	out = z.Compute(inputs, out)
+	if fillNaN {
+		fillWarmup(out, n-1)
+	}
+	return out, nil
+}